@@ -0,0 +1,98 @@
+package ginm
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogConfig 包含访问日志中间件的配置。
+type AccessLogConfig struct {
+	// Logger 是输出日志的 slog.Logger。默认值: slog.Default()
+	Logger *slog.Logger
+	// SkipPaths 是不记录日志的路径，与请求路径或路由模板（c.FullPath()）完全匹配，例如 /healthz。
+	SkipPaths []string
+	// SampleRate 是状态码小于 400 的请求的采样比例，取值 (0, 1]，按请求顺序均匀采样；
+	// 错误请求始终记录。默认值: 1
+	SampleRate float64
+	// Message 是日志消息。默认值: "http request"
+	Message string
+}
+
+// AccessLog 返回结构化访问日志中间件，每个请求结束后输出一条 slog 记录，包含 method、path、route、
+// status、latency、client_ip，以及已设置时的 request_id、user_id、tenant_id 和 c.Errors。
+// 日志级别按状态码确定：5xx 为 Error，4xx 为 Warn，其余为 Info。
+//
+//	r.Use(ginm.AccessLog(ginm.AccessLogConfig{SkipPaths: []string{"/healthz"}, SampleRate: 0.1}))
+func AccessLog(cfg AccessLogConfig) gin.HandlerFunc {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = 1
+	}
+	if cfg.Message == "" {
+		cfg.Message = "http request"
+	}
+	skip := make(map[string]bool, len(cfg.SkipPaths))
+	for _, p := range cfg.SkipPaths {
+		skip[p] = true
+	}
+	var sampled atomic.Uint64
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		path := c.Request.URL.Path
+		route := c.FullPath()
+		if skip[path] || (route != "" && skip[route]) {
+			return
+		}
+		status := c.Writer.Status()
+		if status < http.StatusBadRequest && cfg.SampleRate < 1 {
+			// 第 n 个请求使 floor(n*rate) 增加时记录，保证采样比例均匀且确定
+			n := float64(sampled.Add(1))
+			if math.Floor(n*cfg.SampleRate) == math.Floor((n-1)*cfg.SampleRate) {
+				return
+			}
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if v, ok := Get(c, RequestIDKey); ok {
+			attrs = append(attrs, slog.String("request_id", v))
+		}
+		if v, ok := Get(c, UserIDKey); ok {
+			attrs = append(attrs, slog.Int64("user_id", v))
+		}
+		if v, ok := Get(c, TenantIDKey); ok {
+			attrs = append(attrs, slog.String("tenant_id", v))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		cfg.Logger.LogAttrs(c.Request.Context(), accessLogLevel(status), cfg.Message, attrs...)
+	}
+}
+
+func accessLogLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,88 @@
+package ginm
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accessLogWithBuffer 返回以 JSON 格式记录到缓冲区的访问日志中间件。
+func accessLogWithBuffer(cfg AccessLogConfig) (gin.HandlerFunc, *bytes.Buffer) {
+	var buf bytes.Buffer
+	cfg.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	return AccessLog(cfg), &buf
+}
+
+// accessLogUser 设置请求 ID、用户和租户后返回 200。
+func accessLogUser(c *gin.Context) {
+	Set(c, RequestIDKey, "req-1")
+	Set(c, UserIDKey, int64(42))
+	Set(c, TenantIDKey, "acme")
+	c.Status(http.StatusOK)
+}
+
+func accessLogFail(c *gin.Context) { handleError(c, ErrNotFound("missing")) }
+
+func accessLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for line := range strings.Lines(buf.String()) {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestAccessLog_Attributes(t *testing.T) {
+	accessLog, buf := accessLogWithBuffer(AccessLogConfig{})
+	r := newTestEngine(accessLog)
+	r.GET("/users/:id", accessLogUser)
+	r.GET("/fail", accessLogFail)
+	serve(r, http.MethodGet, "/users/7?x=1", "")
+	serve(r, http.MethodGet, "/fail", "")
+
+	lines := accessLogLines(t, buf)
+	require.Len(t, lines, 2)
+	ok := lines[0]
+	assert.Equal(t, "INFO", ok["level"])
+	assert.Equal(t, "http request", ok["msg"])
+	assert.Equal(t, "GET", ok["method"])
+	assert.Equal(t, "/users/7", ok["path"])
+	assert.Equal(t, "/users/:id", ok["route"])
+	assert.EqualValues(t, 200, ok["status"])
+	assert.Equal(t, "req-1", ok["request_id"])
+	assert.EqualValues(t, 42, ok["user_id"])
+	assert.Equal(t, "acme", ok["tenant_id"])
+	assert.Contains(t, ok, "latency")
+
+	fail := lines[1]
+	assert.Equal(t, "WARN", fail["level"])
+	assert.EqualValues(t, 404, fail["status"])
+	assert.NotContains(t, fail, "request_id")
+}
+
+func TestAccessLog_SkipAndSample(t *testing.T) {
+	accessLog, buf := accessLogWithBuffer(AccessLogConfig{SkipPaths: []string{"/healthz"}, SampleRate: 0.25})
+	r := newTestEngine(accessLog)
+	r.GET("/users/:id", accessLogUser)
+	r.GET("/fail", accessLogFail)
+	r.GET("/healthz", okHandler)
+	serve(r, http.MethodGet, "/healthz", "")
+	for range 8 {
+		serve(r, http.MethodGet, "/users/1", "")
+	}
+	serve(r, http.MethodGet, "/fail", "")
+
+	lines := accessLogLines(t, buf)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "/users/1", lines[0]["path"])
+	assert.Equal(t, "/users/1", lines[1]["path"])
+	assert.Equal(t, "/fail", lines[2]["path"])
+}
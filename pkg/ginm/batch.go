@@ -0,0 +1,293 @@
+package ginm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// DefaultBatchLimit 是 WithBatchCreate 未指定上限时单次批量创建的最大元素数。
+const DefaultBatchLimit = 100
+
+// BatchItemError 是批量操作中单个元素的错误，字段含义与错误响应相同。
+type BatchItemError struct {
+	Message string            `json:"message"`
+	Errors  []ValidationError `json:"errors,omitempty"`
+	Code    int               `json:"code"`
+	Status  int               `json:"status"`
+}
+
+// BatchItemResult 是批量操作中单个元素的结果，Data 和 Error 二者之一不为空。
+type BatchItemResult[T any] struct {
+	Data  *T              `json:"data,omitempty"`
+	Error *BatchItemError `json:"error,omitempty"`
+	Index int             `json:"index"`
+}
+
+// BatchResponse 是允许部分成功的批量操作结果，Items 与请求元素一一对应。
+type BatchResponse[T any] struct {
+	Items     []BatchItemResult[T] `json:"items"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+}
+
+// WithBatchCreate 为资源额外注册 POST /batch 路由，请求体为 CI 的 JSON 数组，逐个校验并调用 Create。
+// 单个元素失败不影响其他元素：全部成功时返回 201，否则返回 207 Multi-Status，
+// 每个元素的结果见 BatchResponse。Create 的中间件和钩子同样作用于批量创建（钩子按元素执行）。
+// maxItems 小于等于 0 时使用 DefaultBatchLimit，超过上限时整个请求返回 400。
+func WithBatchCreate(maxItems int) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		if maxItems <= 0 {
+			maxItems = DefaultBatchLimit
+		}
+		cfg.BatchCreateLimit = maxItems
+	}
+}
+
+// registerBatchCreate 注册 POST /batch 路由，未启用或 Create 被排除时不做任何事。
+func registerBatchCreate[T any, ID comparable, CI any, UI any, LQ any](
+	group *gin.RouterGroup,
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+	hooks resourceHooks[T, ID, CI, UI],
+) {
+	if cfg.BatchCreateLimit <= 0 || !cfg.enabled(ActionCreate) {
+		return
+	}
+	if cfg.OpenAPI != nil {
+		cfg.OpenAPI.add(&openAPIOperation{
+			method: http.MethodPost, path: joinRoutePath(group.BasePath(), "/batch"),
+			body: reflect.TypeFor[[]CI](), resp: reflect.TypeFor[Response[BatchResponse[T]]](), status: http.StatusCreated,
+		})
+	}
+	group.POST("/batch", cfg.handlers(ActionCreate, resourceBatchCreateHandler(resource, hooks, cfg.BatchCreateLimit))...)
+}
+
+// resourceBatchCreateHandler 创建资源的批量创建路由处理器。
+func resourceBatchCreateHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	hooks resourceHooks[T, ID, CI, UI],
+	limit int,
+) gin.HandlerFunc {
+	w := newWrapper[[]json.RawMessage, BatchResponse[T]](http.StatusCreated, bindBatch, nil)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+		raw := *req
+		if len(raw) == 0 {
+			w.fail(c, req, ErrBadRequest("batch is empty"))
+			return
+		}
+		if len(raw) > limit {
+			w.fail(c, req, ErrBadRequest(fmt.Sprintf("batch exceeds %d items", limit)))
+			return
+		}
+
+		resp := BatchResponse[T]{Items: make([]BatchItemResult[T], len(raw))}
+		for i, data := range raw {
+			resp.Items[i].Index = i
+			item, err := batchCreateItem(c, resource, hooks, data)
+			if err != nil {
+				resp.Items[i].Error = batchItemError(c, err)
+				resp.Failed++
+				continue
+			}
+			resp.Items[i].Data = item
+			resp.Succeeded++
+		}
+
+		w.succeed(c, req, resp)
+		status := http.StatusCreated
+		if resp.Failed > 0 {
+			status = http.StatusMultiStatus
+		}
+		JSON(c, status, OK(resp))
+	}
+}
+
+// bindBatch 将请求体绑定为原始 JSON 数组，元素在创建时逐个解码和校验。
+func bindBatch(c *gin.Context) (*[]json.RawMessage, error) {
+	var raw []json.RawMessage
+	if err := c.ShouldBindJSON(&raw); err != nil {
+		return nil, NewBindError("json", err)
+	}
+	return &raw, nil
+}
+
+// batchCreateItem 解码、校验并创建单个元素，前后执行 Create 钩子。
+func batchCreateItem[T any, ID comparable, CI any, UI any, LQ any](
+	c *gin.Context,
+	resource Resource[T, ID, CI, UI, LQ],
+	hooks resourceHooks[T, ID, CI, UI],
+	data []byte,
+) (*T, error) {
+	var input CI
+	if err := binding.JSON.BindBody(data, &input); err != nil {
+		return nil, bindError[CI](c, "json", err)
+	}
+	bindContext(c, &input)
+
+	err := runHooks(hooks.beforeCreate, func(fn func(*gin.Context, *CI) error) error { return fn(c, &input) })
+	if err != nil {
+		return nil, err
+	}
+	item, err := resource.Create(c, &input)
+	if err != nil {
+		return nil, err
+	}
+	err = runHooks(hooks.afterCreate, func(fn func(*gin.Context, *CI, *T) error) error { return fn(c, &input, item) })
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// batchItemError 按错误处理的分类规则转换单个元素的错误，APIError 的附加响应头不会写入。
+func batchItemError(c *gin.Context, err error) *BatchItemError {
+	info := classifyError(c, err)
+	itemErr := &BatchItemError{Message: info.message, Code: info.code, Status: info.status}
+	if info.validation != nil {
+		itemErr.Errors = info.validation.Errors
+	}
+	return itemErr
+}
+
+// --- 批量删除 ---
+
+// BulkDeleter 是资源可选实现的批量删除接口。未实现时批量删除逐个调用 Delete。
+type BulkDeleter[ID comparable] interface {
+	// DeleteMany 删除 ids，返回删除失败的 ID 及其错误，未出现在结果中的 ID 视为删除成功。
+	// 返回的 error 不为 nil 时整个请求失败。
+	DeleteMany(c *gin.Context, ids []ID) (map[ID]error, error)
+}
+
+// BulkDeleteRequest 是批量删除的请求体。
+type BulkDeleteRequest[ID any] struct {
+	IDs []ID `binding:"required,min=1" json:"ids"`
+}
+
+// BulkDeleteItemResult 是单个 ID 的删除结果。
+type BulkDeleteItemResult[ID any] struct {
+	Error *BatchItemError `json:"error,omitempty"`
+	ID    ID              `json:"id"`
+}
+
+// BulkDeleteResponse 是允许部分成功的批量删除结果，Items 与去重后的请求 ID 一一对应。
+type BulkDeleteResponse[ID any] struct {
+	Items     []BulkDeleteItemResult[ID] `json:"items"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+}
+
+// WithBulkDelete 为资源额外注册 POST /delete-batch 路由，请求体为 {"ids": [...]}，重复的 ID 被忽略。
+// 资源实现 BulkDeleter 时调用一次 DeleteMany，否则逐个调用 Delete。全部成功时返回 200，
+// 否则返回 207 Multi-Status。Delete 的中间件和钩子同样作用于批量删除（钩子按 ID 执行）。
+// maxItems 小于等于 0 时使用 DefaultBatchLimit，超过上限时整个请求返回 400。
+func WithBulkDelete(maxItems int) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		if maxItems <= 0 {
+			maxItems = DefaultBatchLimit
+		}
+		cfg.BulkDeleteLimit = maxItems
+	}
+}
+
+// registerBulkDelete 注册 POST /delete-batch 路由，未启用或 Delete 被排除时不做任何事。
+func registerBulkDelete[T any, ID comparable, CI any, UI any, LQ any](
+	group *gin.RouterGroup,
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+	hooks resourceHooks[T, ID, CI, UI],
+) {
+	if cfg.BulkDeleteLimit <= 0 || !cfg.enabled(ActionDelete) {
+		return
+	}
+	if cfg.OpenAPI != nil {
+		cfg.OpenAPI.add(&openAPIOperation{
+			method: http.MethodPost, path: joinRoutePath(group.BasePath(), "/delete-batch"),
+			body: reflect.TypeFor[BulkDeleteRequest[ID]](), resp: reflect.TypeFor[Response[BulkDeleteResponse[ID]]](), status: http.StatusOK,
+		})
+	}
+	group.POST("/delete-batch", cfg.handlers(ActionDelete, resourceBulkDeleteHandler(resource, hooks, cfg.BulkDeleteLimit))...)
+}
+
+// resourceBulkDeleteHandler 创建资源的批量删除路由处理器。
+func resourceBulkDeleteHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	hooks resourceHooks[T, ID, CI, UI],
+	limit int,
+) gin.HandlerFunc {
+	w := newWrapper[BulkDeleteRequest[ID], BulkDeleteResponse[ID]](http.StatusOK, BindJSON[BulkDeleteRequest[ID]], nil)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+		ids := gox.Unique(req.IDs)
+		if len(ids) > limit {
+			w.fail(c, req, ErrBadRequest(fmt.Sprintf("batch exceeds %d items", limit)))
+			return
+		}
+
+		// 先执行 BeforeDelete 钩子，未通过的 ID 不再删除
+		failures := make(map[ID]error)
+		var pending []ID
+		for _, id := range ids {
+			err := runHooks(hooks.beforeDelete, func(fn func(*gin.Context, ID) error) error { return fn(c, id) })
+			if err != nil {
+				failures[id] = err
+				continue
+			}
+			pending = append(pending, id)
+		}
+
+		if bulk, ok := resource.(BulkDeleter[ID]); ok && len(pending) > 0 {
+			errs, err := bulk.DeleteMany(c, pending)
+			if err != nil {
+				w.fail(c, req, err)
+				return
+			}
+			for id, err := range errs {
+				if err != nil {
+					failures[id] = err
+				}
+			}
+		} else {
+			for _, id := range pending {
+				if err := resource.Delete(c, id); err != nil {
+					failures[id] = err
+				}
+			}
+		}
+
+		resp := BulkDeleteResponse[ID]{Items: make([]BulkDeleteItemResult[ID], len(ids))}
+		for i, id := range ids {
+			resp.Items[i].ID = id
+			err, failed := failures[id]
+			if !failed {
+				err = runHooks(hooks.afterDelete, func(fn func(*gin.Context, ID) error) error { return fn(c, id) })
+			}
+			if err != nil {
+				resp.Items[i].Error = batchItemError(c, err)
+				resp.Failed++
+				continue
+			}
+			resp.Succeeded++
+		}
+
+		w.succeed(c, req, resp)
+		status := http.StatusOK
+		if resp.Failed > 0 {
+			status = http.StatusMultiStatus
+		}
+		JSON(c, status, OK(resp))
+	}
+}
@@ -0,0 +1,152 @@
+package ginm
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchInput struct {
+	Title string `json:"title" binding:"required,min=2"`
+}
+
+type batchResource struct {
+	BaseResource[hookPost, int, batchInput, batchInput, PageQuery]
+	posts []hookPost
+}
+
+func (r *batchResource) Create(c *gin.Context, in *batchInput) (*hookPost, error) {
+	if in.Title == "dup" {
+		return nil, ErrConflict("title already exists").WithHeader("X-Item", "dup")
+	}
+	p := hookPost{ID: len(r.posts) + 1, Title: in.Title}
+	r.posts = append(r.posts, p)
+	return &p, nil
+}
+
+func TestWithBatchCreate_AllSucceeded(t *testing.T) {
+	res := &batchResource{}
+	var hooked []string
+	r := newTestEngine()
+	RegisterResource(r.Group("/posts"), res, WithBatchCreate(0), WithBeforeCreate(func(c *gin.Context, in *batchInput) error {
+		hooked = append(hooked, in.Title)
+		return nil
+	}))
+
+	w := serve(r, http.MethodPost, "/posts/batch", `[{"title":"aa"},{"title":"bb"}]`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp Response[BatchResponse[hookPost]]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Data.Succeeded)
+	assert.Equal(t, 0, resp.Data.Failed)
+	assert.Equal(t, &hookPost{ID: 2, Title: "bb"}, resp.Data.Items[1].Data)
+	assert.Equal(t, []string{"aa", "bb"}, hooked)
+}
+
+func TestWithBatchCreate_PartialSuccess(t *testing.T) {
+	res := &batchResource{}
+	r := newTestEngine()
+	RegisterResource(r.Group("/posts"), res, WithBatchCreate(10))
+
+	w := serve(r, http.MethodPost, "/posts/batch", `[{"title":"aa"},{"title":"x"},{"title":"dup"},"bad"]`)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Empty(t, w.Header().Get("X-Item"))
+	assert.JSONEq(t, `{"code":0,"data":{
+		"succeeded": 1,
+		"failed": 3,
+		"items": [
+			{"index":0,"data":{"id":1,"title":"aa"}},
+			{"index":1,"error":{"status":422,"code":422,"message":"validation failed",
+				"errors":[{"field":"title","message":"must be at least 2 characters"}]}},
+			{"index":2,"error":{"status":409,"code":409,"message":"title already exists"}},
+			{"index":3,"error":{"status":400,"code":400,
+				"message":"binding error (json): json: cannot unmarshal string into Go value of type ginm.batchInput"}}
+		]
+	}}`, w.Body.String())
+	assert.Len(t, res.posts, 1)
+}
+
+func TestWithBatchCreate_RejectsInvalidBatch(t *testing.T) {
+	r := newTestEngine()
+	RegisterResource(r.Group("/posts"), &batchResource{}, WithBatchCreate(2))
+
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/posts/batch", `{"title":"aa"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/posts/batch", `[]`).Code)
+	w := serve(r, http.MethodPost, "/posts/batch", "["+strings.Repeat(`{"title":"aa"},`, 2)+`{"title":"aa"}]`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "batch exceeds 2 items")
+}
+
+func TestWithBatchCreate_UsesCreateMiddlewareAndIsOptional(t *testing.T) {
+	r := newTestEngine()
+	RegisterResource(r.Group("/posts"), &batchResource{}, WithBatchCreate(0), WithCreateMiddleware(requireHeader("X-Admin")))
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPost, "/posts/batch", `[{"title":"aa"}]`).Code)
+	assert.Equal(t, http.StatusCreated, serve(r, http.MethodPost, "/posts/batch", `[{"title":"aa"}]`, "X-Admin", "1").Code)
+
+	r = newTestEngine()
+	RegisterResource(r.Group("/posts"), &batchResource{})
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/posts/batch", `[{"title":"aa"}]`).Code)
+}
+
+// bulkResource 实现 BulkDeleter。
+type bulkResource struct {
+	hookPostResource
+	calls [][]int
+}
+
+func (r *bulkResource) DeleteMany(c *gin.Context, ids []int) (map[int]error, error) {
+	r.calls = append(r.calls, ids)
+	errs := make(map[int]error)
+	for _, id := range ids {
+		if _, ok := r.posts[id]; !ok {
+			errs[id] = ErrNotFound("post not found")
+			continue
+		}
+		delete(r.posts, id)
+	}
+	return errs, nil
+}
+
+func TestWithBulkDelete_FallsBackToDelete(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: map[int]*hookPost{1: {ID: 1}, 2: {ID: 2}, 3: {ID: 3}}}
+	var after []int
+	RegisterResource(r.Group("/posts"), res, WithBulkDelete(0),
+		WithBeforeDelete(func(c *gin.Context, id int) error {
+			if id == 3 {
+				return ErrForbidden("locked")
+			}
+			return nil
+		}),
+		WithAfterDelete(func(c *gin.Context, id int) error { after = append(after, id); return nil }))
+
+	w := serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[1,2,2,3]}`)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":{"succeeded":2,"failed":1,"items":[
+		{"id":1},{"id":2},{"id":3,"error":{"status":403,"code":403,"message":"locked"}}
+	]}}`, w.Body.String())
+	assert.Equal(t, []int{1, 2}, after)
+	assert.Len(t, res.posts, 1)
+}
+
+func TestWithBulkDelete_UsesBulkDeleter(t *testing.T) {
+	r := newTestEngine()
+	res := &bulkResource{hookPostResource: hookPostResource{posts: map[int]*hookPost{1: {ID: 1}, 2: {ID: 2}}}}
+	RegisterResource(r.Group("/posts"), res, WithBulkDelete(3))
+
+	w := serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[1,2]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, [][]int{{1, 2}}, res.calls)
+
+	w = serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[1]}`)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"post not found"`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[1,2,3,4]}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[]}`).Code)
+}
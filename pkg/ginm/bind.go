@@ -1,17 +1,75 @@
 package ginm
 
 import (
+	"reflect"
+	"sync"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
 )
 
+// ctxField 描述带 ctx 标签的结构体字段。
+type ctxField struct {
+	key   string
+	index int
+}
+
+// ctxFieldsCache 缓存每个类型的 ctx 标签字段。
+var ctxFieldsCache sync.Map // map[reflect.Type][]ctxField
+
+// ctxFields 返回结构体类型中带 ctx 标签的顶层字段。
+func ctxFields(t reflect.Type) []ctxField {
+	if cached, ok := ctxFieldsCache.Load(t); ok {
+		return cached.([]ctxField)
+	}
+	var fields []ctxField
+	if t.Kind() == reflect.Struct {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if key, ok := f.Tag.Lookup("ctx"); ok && key != "" && f.IsExported() {
+				fields = append(fields, ctxField{key: key, index: i})
+			}
+		}
+	}
+	ctxFieldsCache.Store(t, fields)
+	return fields
+}
+
+// bindContext 将上下文中的值注入带 ctx 标签的字段。
+// 标签值为上下文键名，例如 `ctx:"ginm:client_info"`；字段先被清零，
+// 值不存在或类型不匹配时保持零值，请求数据无法伪造这类字段。
+func bindContext[T any](c *gin.Context, req *T) {
+	v := reflect.ValueOf(req).Elem()
+	for _, f := range ctxFields(v.Type()) {
+		field := v.Field(f.index)
+		field.SetZero()
+		value, ok := c.Get(f.key)
+		if !ok || value == nil {
+			continue
+		}
+		rv := reflect.ValueOf(value)
+		if rv.Type().AssignableTo(field.Type()) {
+			field.Set(rv)
+		}
+	}
+}
+
 // Bind 根据 Content-Type 自动绑定请求体到类型化结构体。
+//
+// 所有 Bind 系列函数在绑定成功后还会将上下文值注入带 ctx 标签的字段，
+// 请求数据中的同名字段被丢弃；这类字段通常同时标记 form:"-" json:"-"：
+//
+//	type CreateOrderReq struct {
+//	    Client ginm.ClientInfo `ctx:"ginm:client_info" form:"-" json:"-"`
+//	    Item   string          `json:"item"`
+//	}
 func Bind[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBind(&req); err != nil {
-		return nil, NewBindError("body", err)
+		return nil, bindError[T](c, "body", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -19,8 +77,9 @@ func Bind[T any](c *gin.Context) (*T, error) {
 func BindJSON[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindJSON(&req); err != nil {
-		return nil, NewBindError("json", err)
+		return nil, bindError[T](c, "json", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -28,8 +87,9 @@ func BindJSON[T any](c *gin.Context) (*T, error) {
 func BindXML[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindXML(&req); err != nil {
-		return nil, NewBindError("xml", err)
+		return nil, bindError[T](c, "xml", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -37,8 +97,9 @@ func BindXML[T any](c *gin.Context) (*T, error) {
 func BindQuery[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindQuery(&req); err != nil {
-		return nil, NewBindError("query", err)
+		return nil, bindError[T](c, "query", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -46,8 +107,9 @@ func BindQuery[T any](c *gin.Context) (*T, error) {
 func BindURI[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindUri(&req); err != nil {
-		return nil, NewBindError("uri", err)
+		return nil, bindError[T](c, "uri", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -55,8 +117,9 @@ func BindURI[T any](c *gin.Context) (*T, error) {
 func BindHeader[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindHeader(&req); err != nil {
-		return nil, NewBindError("header", err)
+		return nil, bindError[T](c, "header", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -64,8 +127,9 @@ func BindHeader[T any](c *gin.Context) (*T, error) {
 func BindForm[T any](c *gin.Context) (*T, error) {
 	var req T
 	if err := c.ShouldBindWith(&req, binding.Form); err != nil {
-		return nil, NewBindError("form", err)
+		return nil, bindError[T](c, "form", err)
 	}
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -109,22 +173,23 @@ func BindAll[T any](c *gin.Context, config BindConfig) (*T, error) {
 
 	if config.URI {
 		if err := c.ShouldBindUri(&req); err != nil {
-			return nil, NewBindError("uri", err)
+			return nil, bindError[T](c, "uri", err)
 		}
 	}
 
 	if config.Query {
 		if err := c.ShouldBindQuery(&req); err != nil {
-			return nil, NewBindError("query", err)
+			return nil, bindError[T](c, "query", err)
 		}
 	}
 
 	if config.Body {
 		if err := c.ShouldBind(&req); err != nil {
-			return nil, NewBindError("body", err)
+			return nil, bindError[T](c, "body", err)
 		}
 	}
 
+	bindContext(c, &req)
 	return &req, nil
 }
 
@@ -0,0 +1,59 @@
+package ginm
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bodyLimitOrigKey 保存第一次限制前的原始请求体，供路由级 BodyLimit 覆盖分组级限制。
+var bodyLimitOrigKey = NewContextKey[io.ReadCloser]("ginm:body_limit_orig")
+
+// BodyLimit 返回限制请求体大小的中间件。读取请求体超出 maxBytes 时返回 *http.MaxBytesError
+// （Content-Length 已超出限制时第一次读取即返回），Bind 系列函数和 Wrap 系列处理器会将其按 413
+// 和标准错误信封输出，请求体不会被完整读入内存。
+//
+// 后注册的 BodyLimit 覆盖先注册的限制（可放宽也可收紧），用于为单个路由单独设置：
+//
+//	api := r.Group("/api", ginm.BodyLimit(1<<20))                  // 默认 1 MiB
+//	api.POST("/uploads", ginm.BodyLimit(50<<20), uploadHandler)   // 上传放宽到 50 MiB
+//
+// maxBytes 小于等于 0 时不限制。
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+		orig, ok := Get(c, bodyLimitOrigKey)
+		if !ok {
+			orig = c.Request.Body
+			Set(c, bodyLimitOrigKey, orig)
+		}
+		if maxBytes <= 0 {
+			c.Request.Body = orig
+		} else {
+			c.Request.Body = &limitedBody{
+				ReadCloser: http.MaxBytesReader(c.Writer, orig, maxBytes),
+				tooLarge:   c.Request.ContentLength > maxBytes,
+				limit:      maxBytes,
+			}
+		}
+		c.Next()
+	}
+}
+
+// limitedBody 在 Content-Length 已超出限制时不读取请求体，直接返回 *http.MaxBytesError。
+type limitedBody struct {
+	io.ReadCloser
+	tooLarge bool
+	limit    int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.tooLarge {
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	return b.ReadCloser.Read(p)
+}
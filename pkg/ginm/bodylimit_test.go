@@ -0,0 +1,61 @@
+package ginm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoName 返回请求体中的 name。
+var echoName = WrapJSON(func(c *gin.Context, req *handlerReq) (string, error) { return req.Name, nil })
+
+// postChunked 发送不带 Content-Length 的请求体，绕过预检查。
+func postChunked(r http.Handler, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBodyLimit(t *testing.T) {
+	r := newTestEngine(BodyLimit(16))
+	r.POST("/small", echoName)
+	r.POST("/raw", func(c *gin.Context) {
+		if _, err := c.GetRawData(); err != nil {
+			handleError(c, NewBindError("body", err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	long := `{"name":"` + strings.Repeat("x", 30) + `"}`
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPost, "/small", `{"name":"a"}`).Code)
+
+	w := serve(r, http.MethodPost, "/small", long)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `{"code":413,"message":"request body too large"}`, w.Body.String())
+
+	w = postChunked(r, "/small", long)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `{"code":413,"message":"request body too large"}`, w.Body.String())
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postChunked(r, "/raw", long).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serve(r, http.MethodPost, "/raw", long).Code)
+	assert.Equal(t, http.StatusNoContent, postChunked(r, "/raw", "{}").Code)
+}
+
+func TestBodyLimit_RouteOverride(t *testing.T) {
+	r := newTestEngine(BodyLimit(16))
+	r.POST("/large", BodyLimit(64), echoName)
+	long := `{"name":"` + strings.Repeat("x", 30) + `"}`
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPost, "/large", long).Code)
+	assert.Equal(t, http.StatusOK, postChunked(r, "/large", long).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postChunked(r, "/large", `{"name":"`+strings.Repeat("x", 80)+`"}`).Code)
+}
@@ -0,0 +1,333 @@
+package ginm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStore 是响应缓存的存储接口，实现需并发安全。多实例部署时应使用共享存储（如 Redis）。
+type CacheStore interface {
+	// Get 返回键对应的值，不存在或已过期时 ok 为 false。
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set 写入键值，ttl 后过期。
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix 删除所有以 prefix 开头的键。
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// CacheConfig 包含响应缓存的配置。
+type CacheConfig struct {
+	// Store 是缓存存储。默认值: NewMemoryCacheStore()
+	Store CacheStore
+	// TTL 是缓存条目的有效期。默认值: 1m
+	TTL time.Duration
+	// VaryHeaders 是参与缓存键的请求头，例如 Accept、Accept-Language。
+	VaryHeaders []string
+	// KeyPrefix 是存储中所有键的前缀，多个缓存共用一个存储时用于区分。默认值: "ginm:cache:"
+	KeyPrefix string
+	// CacheAuthenticated 为 true 时缓存带 Authorization 或 Cookie 请求头的请求，并以这两个请求头的哈希
+	// 区分缓存键，每个凭据只命中自己的缓存。默认不读取也不写入缓存，避免已认证用户的响应被其他调用方读取。
+	CacheAuthenticated bool
+	// InvalidateOnMutation 为 true 时，经过中间件的 POST、PUT、PATCH、DELETE 请求成功（2xx）后
+	// 自动失效请求路径及其子路径的缓存，以及各级父路径（集合）本身的缓存。
+	InvalidateOnMutation bool
+}
+
+// ResponseCache 按 method + path + query（以及 VaryHeaders）缓存 GET 请求的成功响应。
+// 默认不缓存带 Authorization 或 Cookie 的请求，见 CacheConfig.CacheAuthenticated。
+type ResponseCache struct {
+	cfg CacheConfig
+}
+
+// NewResponseCache 创建新的响应缓存。
+//
+//	cache := ginm.NewResponseCache(ginm.CacheConfig{TTL: 30 * time.Second, InvalidateOnMutation: true})
+//	users := r.Group("/users", cache.Middleware())
+//	ginm.RegisterResource(users, userResource)
+func NewResponseCache(cfg CacheConfig) *ResponseCache {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryCacheStore()
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = time.Minute
+	}
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = "ginm:cache:"
+	}
+	return &ResponseCache{cfg: cfg}
+}
+
+// cachedResponse 是存储中的缓存条目。
+type cachedResponse struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	Status int         `json:"status"`
+}
+
+// Middleware 返回缓存中间件。命中时直接输出缓存的响应并设置 X-Cache: HIT，否则执行处理器，
+// 仅缓存 200 响应及其后续处理器设置的响应头，前面的中间件设置的响应头既不缓存也不会在命中时被覆盖；响应设置了 Set-Cookie 或 Cache-Control 含 no-store/private 时不缓存。
+// 存储出错时按未命中处理，错误通过 c.Error 记录。
+func (rc *ResponseCache) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			if rc.cfg.InvalidateOnMutation && isMutation(c.Request.Method) && isSuccessStatus(c.Writer.Status()) {
+				if err := rc.invalidateMutation(c.Request.Context(), c.Request.URL.Path); err != nil {
+					_ = c.Error(err)
+				}
+			}
+			return
+		}
+
+		if !rc.cfg.CacheAuthenticated && hasCredentials(c.Request) {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := rc.key(c)
+		if data, ok, err := rc.cfg.Store.Get(ctx, key); err != nil {
+			_ = c.Error(err)
+		} else if ok {
+			var entry cachedResponse
+			if err := json.Unmarshal(data, &entry); err == nil {
+				writeCachedResponse(c, entry)
+				return
+			}
+		}
+
+		// 前面的中间件（CORS、请求 ID、限流等）按每个请求设置的响应头不属于缓存内容
+		upstream := c.Writer.Header().Clone()
+		w := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Header("X-Cache", "MISS")
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.Status() != http.StatusOK || !cacheable(w.Header()) {
+			return
+		}
+		header := changedHeaders(upstream, w.Header())
+		header.Del("X-Cache")
+		data, err := json.Marshal(cachedResponse{Status: w.Status(), Header: header, Body: w.body.Bytes()})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if err := rc.cfg.Store.Set(ctx, key, data, rc.cfg.TTL); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// Invalidate 失效给定路径的缓存，包括该路径任意查询参数的响应以及其下所有子路径：
+// Invalidate(ctx, "/users") 会失效 /users、/users?page=2 和 /users/1，但不影响 /users2。
+// paths 为解码后的路径，与 URL.Path 相同。
+func (rc *ResponseCache) Invalidate(ctx context.Context, paths ...string) error {
+	for _, p := range paths {
+		p = strings.TrimSuffix(p, "/")
+		base := rc.keyPath(p)
+		if err := rc.cfg.Store.DeletePrefix(ctx, base+"?"); err != nil {
+			return err
+		}
+		if err := rc.cfg.Store.DeletePrefix(ctx, base+"/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidateMutation 失效 p 及其子路径，以及各级父路径本身（不含其他子路径）的缓存。
+// 例如 PUT /users/1 失效 /users/1、/users/1/orders 和 /users?page=2，但不影响 /users/2。
+func (rc *ResponseCache) invalidateMutation(ctx context.Context, p string) error {
+	if err := rc.Invalidate(ctx, p); err != nil {
+		return err
+	}
+	for parent := path.Dir(strings.TrimSuffix(p, "/")); ; parent = path.Dir(parent) {
+		if err := rc.cfg.Store.DeletePrefix(ctx, rc.keyPath(parent)+"?"); err != nil {
+			return err
+		}
+		if parent == "/" || parent == "." {
+			return nil
+		}
+	}
+}
+
+// InvalidateAll 失效该缓存的所有条目。
+func (rc *ResponseCache) InvalidateAll(ctx context.Context) error {
+	return rc.cfg.Store.DeletePrefix(ctx, rc.cfg.KeyPrefix)
+}
+
+// key 生成缓存键: <prefix>GET <path>?<排序后的查询参数>[#<vary 头>...][#auth:<凭据哈希>]。
+// 路径和请求头的值均经过转义，不含分隔符 ?、# 和 ,，不同请求的各组成部分不会拼接出相同的键。
+func (rc *ResponseCache) key(c *gin.Context) string {
+	var b strings.Builder
+	b.WriteString(rc.keyPath(c.Request.URL.Path))
+	b.WriteByte('?')
+	// Encode 按键排序并转义，使参数顺序不同的相同查询命中同一条目
+	b.WriteString(c.Request.URL.Query().Encode())
+	for _, h := range rc.cfg.VaryHeaders {
+		b.WriteByte('#')
+		for i, v := range c.Request.Header.Values(h) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(url.QueryEscape(v))
+		}
+	}
+	if rc.cfg.CacheAuthenticated && hasCredentials(c.Request) {
+		// 只写入哈希，避免凭据明文出现在共享存储中
+		sum := sha256.Sum256([]byte(strings.Join(c.Request.Header.Values("Authorization"), ",") +
+			"\n" + strings.Join(c.Request.Header.Values("Cookie"), ";")))
+		b.WriteString("#auth:")
+		b.WriteString(hex.EncodeToString(sum[:16]))
+	}
+	return b.String()
+}
+
+// keyPath 返回路径 p 的 GET 缓存键前缀，p 按 URL 路径转义，包含 ? 或 # 的路径不会与其他路径的键重叠。
+func (rc *ResponseCache) keyPath(p string) string {
+	return rc.cfg.KeyPrefix + http.MethodGet + " " + (&url.URL{Path: p}).EscapedPath()
+}
+
+// hasCredentials 判断请求是否携带 Authorization 或 Cookie 请求头。
+func hasCredentials(r *http.Request) bool {
+	return r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != ""
+}
+
+// changedHeaders 返回 after 中相对 before 新增或修改的响应头。
+func changedHeaders(before, after http.Header) http.Header {
+	changed := make(http.Header)
+	for k, v := range after {
+		if !slices.Equal(before[k], v) {
+			changed[k] = slices.Clone(v)
+		}
+	}
+	return changed
+}
+
+// writeCachedResponse 输出缓存的响应，已由前面的中间件设置的响应头保持不变。
+func writeCachedResponse(c *gin.Context, entry cachedResponse) {
+	h := c.Writer.Header()
+	for k, v := range entry.Header {
+		if len(h[k]) == 0 {
+			h[k] = v
+		}
+	}
+	h.Set("X-Cache", "HIT")
+	c.Status(entry.Status)
+	_, _ = c.Writer.Write(entry.Body)
+	c.Abort()
+}
+
+// cacheable 判断响应头是否允许缓存。
+func cacheable(h http.Header) bool {
+	if len(h.Values("Set-Cookie")) > 0 {
+		return false
+	}
+	cc := strings.ToLower(strings.Join(h.Values("Cache-Control"), ","))
+	return !strings.Contains(cc, "no-store") && !strings.Contains(cc, "private")
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isSuccessStatus(status int) bool {
+	return status >= 200 && status < 300
+}
+
+// cacheWriter 在写出响应的同时记录响应体。
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// --- 内存存储 ---
+
+// MemoryCacheStore 是进程内的 CacheStore 实现，过期条目在读取时或每写入 1024 次时清理，
+// 适用于单实例部署和测试。
+type MemoryCacheStore struct {
+	entries map[string]memoryCacheEntry
+	writes  int
+	mu      sync.Mutex
+}
+
+type memoryCacheEntry struct {
+	expires time.Time
+	value   []byte
+}
+
+// NewMemoryCacheStore 创建新的内存缓存存储。
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get 实现 CacheStore。
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(e.expires) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set 实现 CacheStore。
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.writes++; s.writes%1024 == 0 {
+		for k, e := range s.entries {
+			if now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	s.entries[key] = memoryCacheEntry{value: slices.Clone(value), expires: now.Add(ttl)}
+	return nil
+}
+
+// DeletePrefix 实现 CacheStore。
+func (s *MemoryCacheStore) DeletePrefix(_ context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(s.entries, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package ginm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countHits 按请求路径计数，并以本次计数作为响应数据。
+func countHits(hits map[string]int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hits[c.Request.URL.Path]++
+		Success(c, hits[c.Request.URL.Path])
+	}
+}
+
+func TestResponseCache_HitAndMiss(t *testing.T) {
+	hits := map[string]int{}
+	r := newTestEngine(NewResponseCache(CacheConfig{}).Middleware())
+	r.GET("/users", countHits(hits))
+	r.GET("/users/:id/private", func(c *gin.Context) {
+		c.Header("Cache-Control", "private")
+		countHits(hits)(c)
+	})
+
+	w := serve(r, http.MethodGet, "/users?b=2&a=1", "")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.JSONEq(t, `{"code":0,"data":1}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/users?a=1&b=2", "")
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"code":0,"data":1}`, w.Body.String())
+
+	assert.JSONEq(t, `{"code":0,"data":2}`, serve(r, http.MethodGet, "/users?a=2", "").Body.String())
+	assert.Equal(t, 2, hits["/users"])
+
+	serve(r, http.MethodGet, "/users/1/private", "")
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1/private", "").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_VaryHeaders(t *testing.T) {
+	hits := map[string]int{}
+	r := newTestEngine(NewResponseCache(CacheConfig{VaryHeaders: []string{"Accept-Language"}}).Middleware())
+	r.GET("/users", countHits(hits))
+	get := func(lang string) *httptest.ResponseRecorder {
+		return serve(r, http.MethodGet, "/users", "", "Accept-Language", lang)
+	}
+
+	get("en")
+	assert.Equal(t, "HIT", get("en").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", get("zh").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_Credentials(t *testing.T) {
+	hits := map[string]int{}
+	r := newTestEngine(NewResponseCache(CacheConfig{}).Middleware())
+	r.GET("/users/:id", countHits(hits))
+
+	w := serve(r, http.MethodGet, "/users/1", "", "Authorization", "Bearer alice")
+	assert.Empty(t, w.Header().Get("X-Cache"))
+	assert.Empty(t, serve(r, http.MethodGet, "/users/1", "", "Cookie", "session=alice").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1", "").Header().Get("X-Cache"))
+	assert.Equal(t, 3, hits["/users/1"])
+
+	hits = map[string]int{}
+	r = newTestEngine(NewResponseCache(CacheConfig{CacheAuthenticated: true}).Middleware())
+	r.GET("/users/:id", countHits(hits))
+	serve(r, http.MethodGet, "/users/1", "", "Authorization", "Bearer alice")
+	assert.Equal(t, "HIT", serve(r, http.MethodGet, "/users/1", "", "Authorization", "Bearer alice").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1", "", "Authorization", "Bearer bob").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1", "").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_InvalidateOnMutation(t *testing.T) {
+	hits := map[string]int{}
+	r := newTestEngine(NewResponseCache(CacheConfig{InvalidateOnMutation: true}).Middleware())
+	r.GET("/users", countHits(hits))
+	r.GET("/users/:id", countHits(hits))
+	r.PUT("/users/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.POST("/users", func(c *gin.Context) { handleError(c, ErrBadRequest("invalid")) })
+	for _, p := range []string{"/users", "/users?page=2", "/users/1", "/users/2"} {
+		serve(r, http.MethodGet, p, "")
+	}
+
+	// 失败的变更不失效缓存
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/users", "").Code)
+	assert.Equal(t, "HIT", serve(r, http.MethodGet, "/users", "").Header().Get("X-Cache"))
+
+	assert.Equal(t, http.StatusNoContent, serve(r, http.MethodPut, "/users/1", "").Code)
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users", "").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users?page=2", "").Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1", "").Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", serve(r, http.MethodGet, "/users/2", "").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_Invalidate(t *testing.T) {
+	cache := NewResponseCache(CacheConfig{})
+	r := newTestEngine(cache.Middleware())
+	r.GET("/users/:id", countHits(map[string]int{}))
+	serve(r, http.MethodGet, "/users/1", "")
+	serve(r, http.MethodGet, "/users/10", "")
+
+	require.NoError(t, cache.Invalidate(context.Background(), "/users/1"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/1", "").Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", serve(r, http.MethodGet, "/users/10", "").Header().Get("X-Cache"))
+
+	require.NoError(t, cache.InvalidateAll(context.Background()))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/10", "").Header().Get("X-Cache"))
+}
+
+func TestMemoryCacheStore_Expires(t *testing.T) {
+	s := NewMemoryCacheStore()
+	ctx := context.Background()
+	require.NoError(t, s.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, s.Set(ctx, "b", []byte("2"), -time.Second))
+
+	v, ok, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(v))
+	_, ok, _ = s.Get(ctx, "b")
+	assert.False(t, ok)
+
+	// 第 1024 次写入时清理过期条目，只剩 a 和本次写入的条目
+	for i := range 1022 {
+		require.NoError(t, s.Set(ctx, "k"+strconv.Itoa(i), nil, -time.Second))
+	}
+	assert.Len(t, s.entries, 2)
+}
+
+func TestResponseCache_KeyComponentsDoNotCollide(t *testing.T) {
+	rc := NewResponseCache(CacheConfig{VaryHeaders: []string{"X-A", "X-B"}})
+	key := func(target string, headers ...string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		for i := 0; i < len(headers); i += 2 {
+			c.Request.Header.Add(headers[i], headers[i+1])
+		}
+		return rc.key(c)
+	}
+
+	assert.NotEqual(t, key("/a", "X-A", "x#y"), key("/a", "X-A", "x", "X-B", "y"))
+	assert.NotEqual(t, key("/a", "X-A", "x,y"), key("/a", "X-A", "x", "X-A", "y"))
+	assert.NotEqual(t, key("/a", "X-B", "#auth:00"), key("/a", "X-B", "", "X-B", "auth:00"))
+	assert.NotEqual(t, key("/a%3Fb=1"), key("/a?b=1"))
+	assert.Equal(t, key("/a", "X-A", "x y"), key("/a", "X-A", "x y"))
+}
+
+func TestResponseCache_InvalidateDoesNotMatchEscapedPath(t *testing.T) {
+	cache := NewResponseCache(CacheConfig{})
+	r := newTestEngine(cache.Middleware())
+	r.GET("/users/:id", countHits(map[string]int{}))
+
+	serve(r, http.MethodGet, "/users/a%3Fb", "")
+	serve(r, http.MethodGet, "/users/a", "")
+	require.NoError(t, cache.Invalidate(context.Background(), "/users/a"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/a", "").Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", serve(r, http.MethodGet, "/users/a%3Fb", "").Header().Get("X-Cache"))
+
+	require.NoError(t, cache.Invalidate(context.Background(), "/users/a?b"))
+	assert.Equal(t, "MISS", serve(r, http.MethodGet, "/users/a%3Fb", "").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_KeepsUpstreamHeaders(t *testing.T) {
+	r := newTestEngine()
+	seq := 0
+	r.Use(func(c *gin.Context) {
+		seq++
+		c.Header("X-Request-ID", "req-"+strconv.Itoa(seq))
+	})
+	r.Use(CORS(CORSConfig{AllowOrigins: []string{"https://a.com", "https://b.com"}}))
+	r.GET("/items", NewResponseCache(CacheConfig{}).Middleware(), func(c *gin.Context) {
+		c.Header("X-Handler", "items")
+		Success(c, "ok")
+	})
+	get := func(origin string) *httptest.ResponseRecorder {
+		return serve(r, http.MethodGet, "/items", "", "Origin", origin)
+	}
+
+	w := get("https://a.com")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.Equal(t, "https://a.com", w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = get("https://b.com")
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.Equal(t, "https://b.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "req-2", w.Header().Get("X-Request-ID"))
+	assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	assert.Equal(t, "items", w.Header().Get("X-Handler"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
@@ -1,6 +1,12 @@
 package ginm
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
 
 // HandlerChain 提供组合中间件和处理器的流式 API。
 type HandlerChain struct {
@@ -90,19 +96,66 @@ func ChainOf(middlewares ...gin.HandlerFunc) *HandlerChain {
 // --- 路由辅助 ---
 
 // RouterChain 使用中间件链包装 gin.RouterGroup。
+// 通过 RouterChain 注册的路由会被记录，重复注册时给出清晰的错误信息，
+// 并可通过 CheckRoutes 在启动时检测参数冲突和遮蔽。
 type RouterChain struct {
-	group *gin.RouterGroup
-	chain *HandlerChain
+	group  *gin.RouterGroup
+	chain  *HandlerChain
+	routes *gin.RoutesInfo
 }
 
 // WithChain 创建用于流式路由注册的 RouterChain。
 func WithChain(group *gin.RouterGroup, middlewares ...gin.HandlerFunc) *RouterChain {
 	return &RouterChain{
-		group: group,
-		chain: Chain(middlewares...),
+		group:  group,
+		chain:  Chain(middlewares...),
+		routes: &gin.RoutesInfo{},
 	}
 }
 
+// anyMethods 是 gin 的 Any 注册的 HTTP 方法。
+var anyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodConnect,
+	http.MethodTrace,
+}
+
+// record 记录路由，重复注册时 panic 并给出清晰的冲突报告。
+func (rc *RouterChain) record(method, relativePath string) {
+	fullPath := joinRoutePath(rc.group.BasePath(), relativePath)
+	for _, r := range *rc.routes {
+		if r.Method != method {
+			continue
+		}
+		if c, ok := compareRoutes(method, r.Path, fullPath); ok && c.Kind != RouteShadowed {
+			panic(&RouteConflictError{Conflicts: []RouteConflict{c}})
+		}
+	}
+	*rc.routes = append(*rc.routes, gin.RouteInfo{Method: method, Path: fullPath})
+}
+
+// joinRoutePath 拼接分组路径和相对路径，保留尾部斜杠。
+func joinRoutePath(base, relativePath string) string {
+	if relativePath == "" {
+		return base
+	}
+	joined := path.Join(base, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// Routes 返回通过该 RouterChain（及其子分组）注册的所有路由。
+func (rc *RouterChain) Routes() gin.RoutesInfo {
+	return append(gin.RoutesInfo{}, *rc.routes...)
+}
+
+// CheckRoutes 检查通过该 RouterChain 注册的路由是否存在冲突。
+func (rc *RouterChain) CheckRoutes(opts ...RouteCheckOption) error {
+	return CheckRoutes(*rc.routes, opts...)
+}
+
 // Use 添加中间件到路由链。
 func (rc *RouterChain) Use(middleware gin.HandlerFunc) *RouterChain {
 	rc.chain.Use(middleware)
@@ -111,48 +164,58 @@ func (rc *RouterChain) Use(middleware gin.HandlerFunc) *RouterChain {
 
 // GET 注册带链中中间件的 GET 路由。
 func (rc *RouterChain) GET(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodGet, path)
 	rc.group.GET(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // POST 注册带链中中间件的 POST 路由。
 func (rc *RouterChain) POST(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodPost, path)
 	rc.group.POST(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // PUT 注册带链中中间件的 PUT 路由。
 func (rc *RouterChain) PUT(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodPut, path)
 	rc.group.PUT(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // DELETE 注册带链中中间件的 DELETE 路由。
 func (rc *RouterChain) DELETE(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodDelete, path)
 	rc.group.DELETE(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // PATCH 注册带链中中间件的 PATCH 路由。
 func (rc *RouterChain) PATCH(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodPatch, path)
 	rc.group.PATCH(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // OPTIONS 注册带链中中间件的 OPTIONS 路由。
 func (rc *RouterChain) OPTIONS(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodOptions, path)
 	rc.group.OPTIONS(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // HEAD 注册带链中中间件的 HEAD 路由。
 func (rc *RouterChain) HEAD(path string, handler gin.HandlerFunc) *RouterChain {
+	rc.record(http.MethodHead, path)
 	rc.group.HEAD(path, rc.chain.Handle(handler))
 	return rc
 }
 
 // Any 为所有 HTTP 方法注册路由。
 func (rc *RouterChain) Any(path string, handler gin.HandlerFunc) *RouterChain {
+	for _, method := range anyMethods {
+		rc.record(method, path)
+	}
 	rc.group.Any(path, rc.chain.Handle(handler))
 	return rc
 }
@@ -160,7 +223,8 @@ func (rc *RouterChain) Any(path string, handler gin.HandlerFunc) *RouterChain {
 // Group 使用相同的链创建子分组。
 func (rc *RouterChain) Group(path string) *RouterChain {
 	return &RouterChain{
-		group: rc.group.Group(path),
-		chain: rc.chain.Clone(),
+		group:  rc.group.Group(path),
+		chain:  rc.chain.Clone(),
+		routes: rc.routes,
 	}
 }
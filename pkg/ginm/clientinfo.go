@@ -0,0 +1,122 @@
+package ginm
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientInfo 是请求来源的客户端元数据。
+type ClientInfo struct {
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	// Platform 来自 Sec-CH-UA-Platform 客户端提示，例如 "Android"。
+	Platform string `json:"platform,omitempty"`
+	// Mobile 来自 Sec-CH-UA-Mobile 客户端提示，缺失时根据 User-Agent 推断。
+	Mobile bool `json:"mobile"`
+}
+
+// ClientInfoKey 用于存储当前请求的 ClientInfo，也可用作 ctx 标签值。
+var ClientInfoKey = NewContextKey[ClientInfo]("ginm:client_info")
+
+// ClientInfoConfig 包含客户端信息中间件的配置。
+type ClientInfoConfig struct {
+	// TrustedProxies 是可信代理的 IP 或 CIDR 列表。
+	// 为空时使用 gin 引擎的可信代理设置（c.ClientIP()）。
+	TrustedProxies []string
+	// IPHeader 是代理写入客户端地址的请求头。默认值: X-Forwarded-For
+	IPHeader string
+}
+
+// WithClientInfo 创建解析客户端信息并存储到 ClientInfoKey 的中间件。
+// 无效的 TrustedProxies 条目会导致 panic，以便在启动时暴露配置错误。
+func WithClientInfo(cfg ClientInfoConfig) gin.HandlerFunc {
+	if cfg.IPHeader == "" {
+		cfg.IPHeader = "X-Forwarded-For"
+	}
+	trusted := make([]netip.Prefix, 0, len(cfg.TrustedProxies))
+	for _, p := range cfg.TrustedProxies {
+		trusted = append(trusted, mustParsePrefix(p))
+	}
+
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		if len(trusted) > 0 {
+			ip = resolveClientIP(c, cfg.IPHeader, trusted)
+		}
+
+		ua := c.GetHeader("User-Agent")
+		info := ClientInfo{
+			IP:        ip,
+			UserAgent: ua,
+			Platform:  strings.Trim(c.GetHeader("Sec-CH-UA-Platform"), `"`),
+		}
+		if hint := c.GetHeader("Sec-CH-UA-Mobile"); hint != "" {
+			info.Mobile = hint == "?1"
+		} else {
+			info.Mobile = strings.Contains(ua, "Mobi")
+		}
+
+		Set(c, ClientInfoKey, info)
+		c.Next()
+	}
+}
+
+// GetClientInfo 是获取客户端信息的便捷函数。
+func GetClientInfo(c *gin.Context) (ClientInfo, bool) {
+	return Get(c, ClientInfoKey)
+}
+
+// resolveClientIP 从右向左遍历代理链，返回第一个不可信的地址。
+func resolveClientIP(c *gin.Context, header string, trusted []netip.Prefix) string {
+	remote := remoteAddrIP(c.Request.RemoteAddr)
+	if !isTrustedIP(remote, trusted) {
+		return remote
+	}
+
+	hops := strings.Split(c.GetHeader(header), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrustedIP(hop, trusted) {
+			return hop
+		}
+	}
+	return remote
+}
+
+// remoteAddrIP 去除 RemoteAddr 中的端口。
+func remoteAddrIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// isTrustedIP 判断地址是否属于可信代理。
+func isTrustedIP(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParsePrefix 将 IP 或 CIDR 解析为 netip.Prefix。
+func mustParsePrefix(s string) netip.Prefix {
+	if strings.Contains(s, "/") {
+		return netip.MustParsePrefix(s)
+	}
+	addr := netip.MustParseAddr(s)
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
@@ -0,0 +1,106 @@
+package ginm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runClientInfo(cfg ClientInfoConfig, setup func(r *http.Request)) ClientInfo {
+	r := newTestEngine()
+	var info ClientInfo
+	r.GET("/", WithClientInfo(cfg), func(c *gin.Context) {
+		info, _ = GetClientInfo(c)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	setup(req)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	return info
+}
+
+func TestWithClientInfo_ResolvesThroughTrustedProxies(t *testing.T) {
+	info := runClientInfo(ClientInfoConfig{TrustedProxies: []string{"10.0.0.0/8"}}, func(r *http.Request) {
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.Header.Set("X-Forwarded-For", "1.1.1.1, 203.0.113.7, 10.0.0.2")
+	})
+	assert.Equal(t, "203.0.113.7", info.IP)
+}
+
+func TestWithClientInfo_IgnoresForwardedHeaderFromUntrustedPeer(t *testing.T) {
+	info := runClientInfo(ClientInfoConfig{TrustedProxies: []string{"10.0.0.1"}}, func(r *http.Request) {
+		r.RemoteAddr = "198.51.100.1:1234"
+		r.Header.Set("X-Forwarded-For", "203.0.113.7")
+	})
+	assert.Equal(t, "198.51.100.1", info.IP)
+}
+
+func TestWithClientInfo_ParsesDeviceHints(t *testing.T) {
+	info := runClientInfo(ClientInfoConfig{}, func(r *http.Request) {
+		r.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 14) Mobile Safari")
+		r.Header.Set("Sec-CH-UA-Platform", `"Android"`)
+	})
+	assert.Equal(t, "Mozilla/5.0 (Linux; Android 14) Mobile Safari", info.UserAgent)
+	assert.Equal(t, "Android", info.Platform)
+	assert.True(t, info.Mobile)
+
+	info = runClientInfo(ClientInfoConfig{}, func(r *http.Request) {
+		r.Header.Set("User-Agent", "Mobile")
+		r.Header.Set("Sec-CH-UA-Mobile", "?0")
+	})
+	assert.False(t, info.Mobile)
+}
+
+func TestWithClientInfo_PanicsOnInvalidProxy(t *testing.T) {
+	assert.Panics(t, func() { WithClientInfo(ClientInfoConfig{TrustedProxies: []string{"bad"}}) })
+}
+
+func TestBind_InjectsContextTaggedFields(t *testing.T) {
+	type req struct {
+		Client ClientInfo `ctx:"ginm:client_info"`
+		UserID int64      `ctx:"ginm:user_id"`
+		Name   string     `json:"name"`
+	}
+
+	c := createTestContext(http.MethodPost, "/", []byte(`{"name":"John"}`), "application/json")
+	Set(c, ClientInfoKey, ClientInfo{IP: "1.2.3.4"})
+	SetUserID(c, 9)
+
+	result, err := BindJSON[req](c)
+	require.NoError(t, err)
+	assert.Equal(t, "John", result.Name)
+	assert.Equal(t, "1.2.3.4", result.Client.IP)
+	assert.Equal(t, int64(9), result.UserID)
+}
+
+func TestBind_SkipsMismatchedContextValues(t *testing.T) {
+	type req struct {
+		UserID string `ctx:"ginm:user_id"`
+	}
+
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	SetUserID(c, 9)
+
+	result, err := BindQuery[req](c)
+	require.NoError(t, err)
+	assert.Empty(t, result.UserID)
+}
+
+func TestBind_ClearsContextTaggedFieldsFromRequest(t *testing.T) {
+	type req struct {
+		Client ClientInfo `ctx:"ginm:client_info" json:"client"`
+		UserID int64      `ctx:"ginm:user_id"     json:"user_id"`
+		Name   string     `json:"name"`
+	}
+
+	c := createTestContext(http.MethodPost, "/", []byte(`{"name":"John","user_id":1,"client":{"ip":"6.6.6.6"}}`), "application/json")
+
+	result, err := BindJSON[req](c)
+	require.NoError(t, err)
+	assert.Equal(t, "John", result.Name)
+	assert.Zero(t, result.UserID)
+	assert.Zero(t, result.Client)
+}
@@ -0,0 +1,50 @@
+package ginm
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotModifiedSince 输出 Last-Modified 响应头，并在客户端缓存仍然有效时返回 304，此时返回 true，
+// 调用方应直接返回而不再输出响应体：
+//
+//	if ginm.NotModifiedSince(c, report.UpdatedAt) {
+//	    return
+//	}
+//	ginm.Success(c, report)
+//
+// 仅 GET 和 HEAD 请求会返回 304；请求带 If-None-Match 时按 RFC 9110 忽略 If-Modified-Since。
+// lastModified 为零值时不做任何处理。
+func NotModifiedSince(c *gin.Context, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	method := c.Request.Method
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	if c.GetHeader("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+	return true
+}
+
+// SuccessWithLastModified 发送 HTTP 200 的成功 JSON 响应并输出 Last-Modified，
+// 客户端缓存仍然有效时改为返回 304，见 NotModifiedSince。
+func SuccessWithLastModified[T any](c *gin.Context, lastModified time.Time, data T) {
+	if NotModifiedSince(c, lastModified) {
+		return
+	}
+	Success(c, data)
+}
@@ -0,0 +1,53 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessWithLastModified(t *testing.T) {
+	modified := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+	r := newTestEngine()
+	r.Match([]string{http.MethodGet, http.MethodPut}, "/report", func(c *gin.Context) {
+		SuccessWithLastModified(c, modified, "report")
+	})
+
+	w := serve(r, http.MethodGet, "/report", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Sun, 01 Mar 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.JSONEq(t, `{"code":0,"data":"report"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/report", "", "If-Modified-Since", "Sun, 01 Mar 2026 12:00:00 GMT")
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	older := "Sun, 01 Mar 2026 11:59:59 GMT"
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/report", "", "If-Modified-Since", older).Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/report", "", "If-Modified-Since", "garbage").Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPut, "/report", "", "If-Modified-Since", "Sun, 01 Mar 2026 12:00:00 GMT").Code)
+}
+
+func TestNotModifiedSince_IfNoneMatchTakesPrecedence(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/report", func(c *gin.Context) {
+		if NotModifiedSince(c, time.Unix(1000, 0)) {
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := serve(r, http.MethodGet, "/report", "", "If-Modified-Since", time.Unix(2000, 0).UTC().Format(http.TimeFormat), "If-None-Match", `"x"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNotModifiedSince_ZeroTime(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/report", func(c *gin.Context) {
+		assert.False(t, NotModifiedSince(c, time.Time{}))
+	})
+
+	w := serve(r, http.MethodGet, "/report", "", "If-Modified-Since", "Sun, 01 Mar 2026 12:00:00 GMT")
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
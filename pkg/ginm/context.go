@@ -1,6 +1,10 @@
 package ginm
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
 
 // ContextKey 是类型安全的上下文键。
 type ContextKey[T any] string
@@ -96,3 +100,50 @@ func SetTenantID(c *gin.Context, tenantID string) {
 func GetTenantID(c *gin.Context) (string, bool) {
 	return Get(c, TenantIDKey)
 }
+
+// --- context.Context 互操作 ---
+
+// keyName 返回键在 gin.Context 中的名称，供 valuesContext 识别类型化的键。
+func (k ContextKey[T]) keyName() string {
+	return string(k)
+}
+
+// namedKey 由所有 ContextKey 实现。
+type namedKey interface {
+	keyName() string
+}
+
+// valuesContext 在标准 context.Context 上附加 gin.Context 中的值快照。
+type valuesContext struct {
+	context.Context
+	keys map[any]any
+}
+
+func (v *valuesContext) Value(key any) any {
+	if k, ok := key.(namedKey); ok {
+		if value, ok := v.keys[k.keyName()]; ok {
+			return value
+		}
+	}
+	return v.Context.Value(key)
+}
+
+// StdContext 返回不依赖 gin 的 context.Context：截止时间和取消信号来自请求的 Context，
+// 同时附带调用时 gin.Context 中所有值的快照（请求 ID、用户 ID 等），可用 FromContext 读取。
+// 返回的 Context 在处理器返回后仍可安全使用。
+func StdContext(c *gin.Context) context.Context {
+	return &valuesContext{Context: c.Request.Context(), keys: c.Copy().Keys}
+}
+
+// FromContext 从 StdContext 返回的 Context（或 *gin.Context）中获取类型化的值。
+//
+//	func (s *UserService) Create(ctx context.Context, req *CreateUserReq) (*User, error) {
+//	    requestID, _ := ginm.FromContext(ctx, ginm.RequestIDKey)
+//	}
+func FromContext[T any](ctx context.Context, key ContextKey[T]) (T, bool) {
+	if c, ok := ctx.(*gin.Context); ok {
+		return Get(c, key)
+	}
+	value, ok := ctx.Value(key).(T)
+	return value, ok
+}
@@ -0,0 +1,71 @@
+package ginm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxEchoReq struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ctxEchoResp struct {
+	Name      string `json:"name"`
+	RequestID string `json:"request_id"`
+	UserID    int64  `json:"user_id"`
+	Deadline  bool   `json:"deadline"`
+}
+
+// echoService 模拟不依赖 gin 的服务层函数。
+func echoService(ctx context.Context, req *ctxEchoReq) (ctxEchoResp, error) {
+	requestID, _ := FromContext(ctx, RequestIDKey)
+	userID, _ := FromContext(ctx, UserIDKey)
+	_, hasDeadline := ctx.Deadline()
+	return ctxEchoResp{Name: req.Name, RequestID: requestID, UserID: userID, Deadline: hasDeadline}, nil
+}
+
+func TestWrapCtx(t *testing.T) {
+	r := newTestEngine()
+	r.Use(func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		SetRequestID(c, "req-1")
+		SetUserID(c, 42)
+		c.Next()
+	})
+	r.POST("/echo", WrapCtx(echoService))
+
+	w := serve(r, http.MethodPost, "/echo", `{"name":"a"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":{"name":"a","request_id":"req-1","user_id":42,"deadline":true}}`, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/echo", `{}`).Code)
+}
+
+func TestStdContext_SnapshotsValues(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	SetTenantID(c, "t1")
+
+	ctx := StdContext(c)
+	SetTenantID(c, "t2")
+
+	tenant, ok := FromContext(ctx, TenantIDKey)
+	require.True(t, ok)
+	assert.Equal(t, "t1", tenant)
+	_, ok = FromContext(ctx, UserIDKey)
+	assert.False(t, ok)
+	_, ok = FromContext(ctx, NewContextKey[int]("ginm:tenant_id"))
+	assert.False(t, ok, "type mismatch")
+
+	tenant, ok = FromContext(context.Context(c), TenantIDKey)
+	require.True(t, ok)
+	assert.Equal(t, "t2", tenant)
+}
@@ -0,0 +1,155 @@
+package ginm
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig 包含 CORS 中间件的配置。
+type CORSConfig struct {
+	// AllowOrigins 是允许的来源列表，"*" 允许任意来源，"https://*.example.com" 允许该域名的任意子域。
+	// 与 AllowOriginFunc 同时设置时任一匹配即允许。
+	AllowOrigins []string
+	// AllowOriginFunc 按请求动态判断是否允许来源，例如按租户配置的域名校验。
+	AllowOriginFunc func(c *gin.Context, origin string) bool
+	// AllowMethods 是预检响应允许的方法。默认值: GET, POST, PUT, PATCH, DELETE, HEAD
+	AllowMethods []string
+	// AllowHeaders 是预检响应允许的请求头，"*" 表示允许预检请求中声明的任意请求头。
+	// 默认值: Origin, Accept, Content-Type, Authorization
+	AllowHeaders []string
+	// ExposeHeaders 是浏览器可读取的响应头，例如 X-Total-Count、Link、ETag、X-Request-ID。
+	ExposeHeaders []string
+	// AllowCredentials 为 true 时允许携带 Cookie 和认证信息，并回显具体来源。此时必须通过 AllowOrigins
+	// 或 AllowOriginFunc 明确列出来源，AllowOrigins 不能包含 "*"，否则 CORS 在创建时 panic。
+	AllowCredentials bool
+	// MaxAge 是预检结果的缓存时间，为 0 时不输出 Access-Control-Max-Age。
+	MaxAge time.Duration
+}
+
+// CORS 返回跨域资源共享中间件。预检请求（带 Access-Control-Request-Method 的 OPTIONS 请求）
+// 直接以 204 响应并中止，来源不被允许的预检请求返回 403；来源不被允许的普通请求照常处理但不输出 CORS 响应头。
+//
+// 中间件只在调用 c.Next() 之前写响应头，可用于 r.Use、分组和 HandlerChain。通过 r.Use 注册时未注册
+// OPTIONS 路由的预检请求也会被处理；用于分组或 HandlerChain 时需要为对应路径注册 OPTIONS 路由。
+//
+//	r.Use(ginm.CORS(ginm.CORSConfig{
+//		AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+//		AllowCredentials: true,
+//		ExposeHeaders:    []string{"X-Total-Count", "Link"},
+//		MaxAge:           12 * time.Hour,
+//	}))
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	allowAll := slices.Contains(cfg.AllowOrigins, "*")
+	if cfg.AllowCredentials {
+		if allowAll {
+			panic(`CORS: AllowOrigins "*" cannot be combined with AllowCredentials`)
+		}
+		if len(cfg.AllowOrigins) == 0 && cfg.AllowOriginFunc == nil {
+			panic("CORS: AllowCredentials requires AllowOrigins or AllowOriginFunc")
+		}
+	}
+	if len(cfg.AllowMethods) == 0 {
+		cfg.AllowMethods = []string{
+			http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead,
+		}
+	}
+	if len(cfg.AllowHeaders) == 0 {
+		cfg.AllowHeaders = []string{"Origin", "Accept", "Content-Type", "Authorization"}
+	}
+	anyHeader := slices.Contains(cfg.AllowHeaders, "*")
+	methods := strings.Join(cfg.AllowMethods, ", ")
+	headers := strings.Join(cfg.AllowHeaders, ", ")
+	expose := strings.Join(cfg.ExposeHeaders, ", ")
+	var maxAge string
+	if cfg.MaxAge > 0 {
+		maxAge = strconv.FormatInt(int64(cfg.MaxAge/time.Second), 10)
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+		if preflight {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !allowAll && !cfg.originAllowed(c, origin) {
+			if preflight {
+				handleError(c, ErrForbidden("origin not allowed"))
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAll {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if expose != "" {
+				h.Set("Access-Control-Expose-Headers", expose)
+			}
+			c.Next()
+			return
+		}
+
+		h.Set("Access-Control-Allow-Methods", methods)
+		if anyHeader {
+			if requested := c.GetHeader("Access-Control-Request-Headers"); requested != "" {
+				h.Set("Access-Control-Allow-Headers", requested)
+			}
+		} else {
+			h.Set("Access-Control-Allow-Headers", headers)
+		}
+		if maxAge != "" {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// originAllowed 判断来源是否匹配 AllowOrigins 或 AllowOriginFunc。
+func (cfg *CORSConfig) originAllowed(c *gin.Context, origin string) bool {
+	for _, allowed := range cfg.AllowOrigins {
+		if matchOrigin(allowed, origin) {
+			return true
+		}
+	}
+	return cfg.AllowOriginFunc != nil && cfg.AllowOriginFunc(c, origin)
+}
+
+// matchOrigin 比较来源，不区分大小写，支持 "scheme://*.domain" 形式的子域通配。
+func matchOrigin(pattern, origin string) bool {
+	if strings.EqualFold(pattern, origin) {
+		return true
+	}
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok || !strings.HasSuffix(prefix, "://") {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	rest, found := strings.CutPrefix(origin, strings.ToLower(prefix))
+	if !found {
+		return false
+	}
+	sub, found := strings.CutSuffix(rest, strings.ToLower(suffix))
+	return found && sub != "" && !strings.ContainsAny(sub, "/:")
+}
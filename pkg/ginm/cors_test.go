@@ -0,0 +1,111 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_SimpleRequest(t *testing.T) {
+	r := newTestEngine(CORS(CORSConfig{
+		AllowOrigins:  []string{"https://app.example.com"},
+		ExposeHeaders: []string{"X-Total-Count", "Link"},
+	}))
+	r.GET("/items", okHandler)
+
+	w := serve(r, http.MethodGet, "/items", "", "Origin", "https://app.example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Total-Count, Link", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+
+	w = serve(r, http.MethodGet, "/items", "", "Origin", "https://evil.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = serve(r, http.MethodGet, "/items", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Vary"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	r := newTestEngine(CORS(CORSConfig{
+		AllowOrigins:     []string{"https://*.example.com"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+	r.GET("/items", okHandler)
+
+	w := serve(r, http.MethodOptions, "/items", "", "Origin", "https://api.example.com", "Access-Control-Request-Method", http.MethodPost)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://api.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST, PUT, PATCH, DELETE, HEAD", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Origin, Accept, Content-Type, Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "43200", w.Header().Get("Access-Control-Max-Age"))
+
+	w = serve(r, http.MethodOptions, "/items", "", "Origin", "https://example.com.evil.com", "Access-Control-Request-Method", http.MethodPost)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"code":403,"message":"origin not allowed"}`, w.Body.String())
+}
+
+func TestCORS_WildcardAndOriginFunc(t *testing.T) {
+	r := newTestEngine(CORS(CORSConfig{AllowOrigins: []string{"*"}, AllowHeaders: []string{"*"}}))
+	r.GET("/items", okHandler)
+	w := serve(r, http.MethodOptions, "/items", "", "Origin", "https://any.test", "Access-Control-Request-Method", http.MethodPut, "Access-Control-Request-Headers", "X-Custom")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Custom", w.Header().Get("Access-Control-Allow-Headers"))
+
+	r = newTestEngine(CORS(CORSConfig{
+		AllowOriginFunc: func(c *gin.Context, origin string) bool { return origin == "https://tenant.test" },
+	}))
+	r.GET("/items", okHandler)
+	assert.Equal(t, "https://tenant.test", serve(r, http.MethodGet, "/items", "", "Origin", "https://tenant.test").Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, serve(r, http.MethodGet, "/items", "", "Origin", "https://other.test").Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_PanicsOnWildcardWithCredentials(t *testing.T) {
+	assert.PanicsWithValue(t, `CORS: AllowOrigins "*" cannot be combined with AllowCredentials`, func() {
+		CORS(CORSConfig{AllowOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true})
+	})
+	assert.PanicsWithValue(t, "CORS: AllowCredentials requires AllowOrigins or AllowOriginFunc", func() {
+		CORS(CORSConfig{AllowCredentials: true})
+	})
+	assert.NotPanics(t, func() {
+		CORS(CORSConfig{AllowOriginFunc: func(c *gin.Context, origin string) bool { return true }, AllowCredentials: true})
+	})
+}
+
+func TestCORS_HandlerChain(t *testing.T) {
+	r := newTestEngine()
+	rc := WithChain(&r.RouterGroup, CORS(CORSConfig{AllowOrigins: []string{"https://app.example.com"}}))
+	called := 0
+	handler := func(c *gin.Context) {
+		called++
+		Success(c, "ok")
+	}
+	rc.GET("/items", handler).OPTIONS("/items", handler)
+
+	w := serve(r, http.MethodOptions, "/items", "", "Origin", "https://app.example.com", "Access-Control-Request-Method", http.MethodGet)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, 0, called)
+
+	w = serve(r, http.MethodGet, "/items", "", "Origin", "https://app.example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, 1, called)
+}
+
+func TestMatchOrigin(t *testing.T) {
+	assert.True(t, matchOrigin("https://app.example.com", "HTTPS://App.Example.com"))
+	assert.True(t, matchOrigin("https://*.example.com", "https://a.b.example.com"))
+	assert.False(t, matchOrigin("https://*.example.com", "https://example.com"))
+	assert.False(t, matchOrigin("https://*.example.com", "http://a.example.com"))
+	assert.False(t, matchOrigin("https://*.example.com", "https://a.example.com:8443"))
+	assert.False(t, matchOrigin("https://*.example.com", "https://evil.com/.example.com"))
+}
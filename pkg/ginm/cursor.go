@@ -0,0 +1,146 @@
+package ginm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrInvalidCursor 表示游标无法解码，ParseCursor 返回的 BindError 包装此错误（400）。
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorQuery 是基于游标的分页查询结构体，适用于频繁写入的大表。
+type CursorQuery struct {
+	Cursor   string `form:"cursor"`
+	Sort     string `form:"sort"`
+	Order    string `binding:"omitempty,oneof=asc desc" form:"order"`
+	PageSize int    `binding:"min=0,max=100"            form:"page_size"`
+}
+
+// Limit 返回规范化后的页面大小。
+func (q *CursorQuery) Limit() int {
+	_, pageSize := normalizePage(DefaultPage, q.PageSize)
+	return pageSize
+}
+
+// Cursor 是游标的解码内容，K 通常为排序键（如 ID 或 {CreatedAt, ID}）。
+// 对客户端而言游标是不透明的 base64 字符串。
+type Cursor[K any] struct {
+	// Key 是边界元素的排序键，查询应返回严格位于其后（或其前）的元素。
+	Key K `json:"k"`
+	// Backward 为 true 时向前翻页，即查询 Key 之前的元素。
+	Backward bool `json:"b,omitempty"`
+
+	set bool
+}
+
+// IsSet 返回游标是否来自请求。为 false 时表示第一页。
+func (c Cursor[K]) IsSet() bool {
+	return c.set
+}
+
+// EncodeCursor 将游标编码为不透明字符串。
+func EncodeCursor[K any](cur Cursor[K]) string {
+	data, err := json.Marshal(cur)
+	if err != nil {
+		// 排序键应为可 JSON 编码的简单值
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解码游标字符串，空字符串返回未设置的游标。
+func DecodeCursor[K any](s string) (Cursor[K], error) {
+	var cur Cursor[K]
+	if s == "" {
+		return cur, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cur, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return cur, ErrInvalidCursor
+	}
+	cur.set = true
+	return cur, nil
+}
+
+// ParseCursor 解码查询中的游标，失败时返回 400 绑定错误。
+func ParseCursor[K any](q *CursorQuery) (Cursor[K], error) {
+	cur, err := DecodeCursor[K](q.Cursor)
+	if err != nil {
+		return cur, NewBindError("query", err)
+	}
+	return cur, nil
+}
+
+// CursorResponse 表示基于游标的分页数据。
+type CursorResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	// HasMore 表示翻页方向上是否还有更多数据。
+	HasMore bool `json:"has_more"`
+}
+
+// NewCursorResponse 根据按 cur 查询得到的结果创建游标分页响应。
+// items 应多查询一条（limit+1）以判断是否还有更多数据；向前翻页（cur.Backward）时
+// items 为逆序查询的结果，这里会恢复为正常顺序。key 返回元素的排序键。
+//
+//	cur, err := ginm.ParseCursor[int64](q)
+//	rows := repo.ListAfter(cur.Key, cur.Backward, q.Limit()+1)
+//	return ginm.NewCursorResponse(rows, cur, q.Limit(), func(u User) int64 { return u.ID }), nil
+func NewCursorResponse[T, K any](items []T, cur Cursor[K], limit int, key func(T) K) CursorResponse[T] {
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if items == nil {
+		items = []T{}
+	}
+	if cur.Backward {
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	}
+
+	resp := CursorResponse[T]{Items: items, HasMore: hasMore}
+	if len(items) == 0 {
+		return resp
+	}
+	// 向后翻页时是否有下一页取决于 hasMore，是否有上一页取决于是否带游标；向前翻页时相反
+	hasNext, hasPrev := hasMore, cur.IsSet()
+	if cur.Backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		resp.NextCursor = EncodeCursor(Cursor[K]{Key: key(items[len(items)-1])})
+	}
+	if hasPrev {
+		resp.PrevCursor = EncodeCursor(Cursor[K]{Key: key(items[0]), Backward: true})
+	}
+	return resp
+}
+
+// WrapCursorPage 将游标分页处理器转换为 gin.HandlerFunc，使用查询参数绑定。
+func WrapCursorPage[Req any, Item any](handler func(c *gin.Context, req *Req) (CursorResponse[Item], error), opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, CursorResponse[Item]](http.StatusOK, BindQuery[Req], opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	}
+}
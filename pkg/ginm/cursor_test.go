@@ -0,0 +1,118 @@
+package ginm
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorKey struct {
+	CreatedAt string `json:"t"`
+	ID        int    `json:"id"`
+}
+
+func TestCursor_EncodeDecodeRoundTrip(t *testing.T) {
+	s := EncodeCursor(Cursor[cursorKey]{Key: cursorKey{CreatedAt: "2024-01-01", ID: 7}, Backward: true})
+	assert.NotContains(t, s, "=")
+
+	cur, err := DecodeCursor[cursorKey](s)
+	require.NoError(t, err)
+	assert.True(t, cur.IsSet())
+	assert.True(t, cur.Backward)
+	assert.Equal(t, cursorKey{CreatedAt: "2024-01-01", ID: 7}, cur.Key)
+
+	cur, err = DecodeCursor[cursorKey]("")
+	require.NoError(t, err)
+	assert.False(t, cur.IsSet())
+
+	_, err = DecodeCursor[int]("!!")
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = DecodeCursor[int](EncodeCursor(Cursor[string]{Key: "x"}))
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestParseCursor_ReturnsBindError(t *testing.T) {
+	_, err := ParseCursor[int](&CursorQuery{Cursor: "%%%"})
+	var bindErr *BindError
+	require.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "query", bindErr.Source)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestCursorQuery_Limit(t *testing.T) {
+	assert.Equal(t, DefaultPageSize, (&CursorQuery{}).Limit())
+	assert.Equal(t, 5, (&CursorQuery{PageSize: 5}).Limit())
+	assert.Equal(t, MaxPageSize, (&CursorQuery{PageSize: 500}).Limit())
+}
+
+func TestNewCursorResponse(t *testing.T) {
+	id := func(v int) int { return v }
+	decode := func(s string) Cursor[int] {
+		cur, err := DecodeCursor[int](s)
+		require.NoError(t, err)
+		return cur
+	}
+
+	// 第一页：多查一条，有下一页，无上一页
+	first := NewCursorResponse([]int{1, 2, 3}, Cursor[int]{}, 2, id)
+	assert.Equal(t, []int{1, 2}, first.Items)
+	assert.True(t, first.HasMore)
+	assert.Empty(t, first.PrevCursor)
+	assert.Equal(t, 2, decode(first.NextCursor).Key)
+
+	// 向后翻到最后一页
+	last := NewCursorResponse([]int{3}, decode(first.NextCursor), 2, id)
+	assert.Equal(t, []int{3}, last.Items)
+	assert.False(t, last.HasMore)
+	assert.Empty(t, last.NextCursor)
+	prev := decode(last.PrevCursor)
+	assert.Equal(t, 3, prev.Key)
+	assert.True(t, prev.Backward)
+
+	// 向前翻页：逆序查询的结果恢复为正常顺序
+	back := NewCursorResponse([]int{2, 1}, prev, 2, id)
+	assert.Equal(t, []int{1, 2}, back.Items)
+	assert.False(t, back.HasMore)
+	assert.Empty(t, back.PrevCursor)
+	assert.Equal(t, 2, decode(back.NextCursor).Key)
+
+	empty := NewCursorResponse[int, int](nil, Cursor[int]{}, 2, id)
+	assert.Equal(t, []int{}, empty.Items)
+	assert.Empty(t, empty.NextCursor)
+}
+
+func TestWrapCursorPage(t *testing.T) {
+	r := newTestEngine()
+	data := []int{10, 20, 30}
+	r.GET("/items", WrapCursorPage(func(c *gin.Context, q *CursorQuery) (CursorResponse[int], error) {
+		cur, err := ParseCursor[int](q)
+		if err != nil {
+			return CursorResponse[int]{}, err
+		}
+		var rows []int
+		for _, v := range data {
+			if !cur.IsSet() || v > cur.Key {
+				rows = append(rows, v)
+			}
+		}
+		return NewCursorResponse(rows[:min(len(rows), q.Limit()+1)], cur, q.Limit(), func(v int) int { return v }), nil
+	}))
+
+	w := serve(r, http.MethodGet, "/items?page_size=2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp Response[CursorResponse[int]]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []int{10, 20}, resp.Data.Items)
+
+	w = serve(r, http.MethodGet, "/items?page_size=2&cursor="+resp.Data.NextCursor, "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []int{30}, resp.Data.Items)
+	assert.False(t, resp.Data.HasMore)
+
+	w = serve(r, http.MethodGet, "/items?cursor=***", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,70 @@
+package ginm
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler 处理 Wrap 系列处理器返回的错误并写入响应。
+type ErrorHandler func(c *gin.Context, err error)
+
+// ErrorMapper 将领域错误映射为 APIError，无法识别时返回 false。
+type ErrorMapper func(err error) (*APIError, bool)
+
+var (
+	errorHandler atomic.Pointer[ErrorHandler]
+	errorMappers atomic.Pointer[[]ErrorMapper]
+	mappersMu    sync.Mutex
+)
+
+// SetErrorHandler 替换全局错误处理器，传入 nil 恢复为 DefaultErrorHandler。通常在启动时调用一次。
+func SetErrorHandler(h ErrorHandler) {
+	if h == nil {
+		errorHandler.Store(nil)
+		return
+	}
+	errorHandler.Store(&h)
+}
+
+// RegisterErrorMapper 注册全局错误映射，按注册顺序尝试，第一个返回 true 的结果生效。
+// 错误链中已有 *APIError 时不调用映射。通常在启动时调用：
+//
+//	ginm.RegisterErrorMapper(func(err error) (*ginm.APIError, bool) {
+//	    if errors.Is(err, gorm.ErrRecordNotFound) {
+//	        return ginm.ErrNotFound("record not found"), true
+//	    }
+//	    return nil, false
+//	})
+func RegisterErrorMapper(m ErrorMapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	var mappers []ErrorMapper
+	if old := errorMappers.Load(); old != nil {
+		mappers = append(mappers, *old...)
+	}
+	mappers = append(mappers, m)
+	errorMappers.Store(&mappers)
+}
+
+// ResetErrorMappers 移除所有已注册的错误映射。
+func ResetErrorMappers() {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	errorMappers.Store(nil)
+}
+
+// mapError 依次尝试已注册的错误映射。
+func mapError(err error) (*APIError, bool) {
+	mappers := errorMappers.Load()
+	if mappers == nil {
+		return nil, false
+	}
+	for _, m := range *mappers {
+		if apiErr, ok := m(err); ok && apiErr != nil {
+			return apiErr, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,66 @@
+package ginm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRecordNotFound = errors.New("record not found")
+
+func TestRegisterErrorMapper_MapsDomainErrors(t *testing.T) {
+	RegisterErrorMapper(func(err error) (*APIError, bool) {
+		if errors.Is(err, errRecordNotFound) {
+			return ErrNotFound("user not found"), true
+		}
+		return nil, false
+	})
+	t.Cleanup(ResetErrorMappers)
+
+	r := newTestEngine()
+	r.GET("/missing", Wrap(failWith(fmt.Errorf("load user: %w", errRecordNotFound))))
+	r.GET("/other", Wrap(failWith(errors.New("boom"))))
+	r.GET("/api", Wrap(failWith(ErrConflict("taken"))))
+
+	w := serve(r, http.MethodGet, "/missing", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"message": "user not found", "code": 404}`, w.Body.String())
+
+	assert.Equal(t, http.StatusInternalServerError, serve(r, http.MethodGet, "/other", "").Code)
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodGet, "/api", "").Code)
+}
+
+func TestRegisterErrorMapper_FirstMatchWins(t *testing.T) {
+	RegisterErrorMapper(func(error) (*APIError, bool) { return nil, false })
+	RegisterErrorMapper(func(error) (*APIError, bool) { return ErrForbidden("first"), true })
+	RegisterErrorMapper(func(error) (*APIError, bool) { return ErrBadRequest("second"), true })
+	t.Cleanup(ResetErrorMappers)
+
+	apiErr, ok := mapError(errors.New("x"))
+	assert.True(t, ok)
+	assert.Equal(t, "first", apiErr.Message)
+}
+
+func TestSetErrorHandler_ReplacesDefault(t *testing.T) {
+	var got error
+	SetErrorHandler(func(c *gin.Context, err error) {
+		got = err
+		if errors.Is(err, errRecordNotFound) {
+			c.Status(http.StatusGone)
+			return
+		}
+		DefaultErrorHandler(c, err)
+	})
+	t.Cleanup(func() { SetErrorHandler(nil) })
+
+	r := newTestEngine()
+	r.GET("/gone", Wrap(failWith(errRecordNotFound)))
+	r.GET("/bad", Wrap(failWith(ErrBadRequest("bad"))))
+	assert.Equal(t, http.StatusGone, serve(r, http.MethodGet, "/gone", "").Code)
+	assert.ErrorIs(t, got, errRecordNotFound)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodGet, "/bad", "").Code)
+}
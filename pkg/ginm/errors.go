@@ -3,14 +3,22 @@ package ginm
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
 )
 
 // APIError 表示结构化的 API 错误。
 type APIError struct {
-	Err        error
+	Err error
+	// Headers 是随错误响应一起发送的额外响应头。
+	Headers    http.Header
 	Message    string
 	HTTPStatus int
 	Code       int
+	// RetryAfter 非零时以 Retry-After 响应头（向上取整的秒数）告知客户端退避时间。
+	RetryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -24,6 +32,33 @@ func (e *APIError) Unwrap() error {
 	return e.Err
 }
 
+// WithRetryAfter 设置 Retry-After 退避时间，返回错误本身以支持链式调用。
+func (e *APIError) WithRetryAfter(d time.Duration) *APIError {
+	e.RetryAfter = d
+	return e
+}
+
+// WithHeader 添加随错误响应发送的响应头，返回错误本身以支持链式调用。
+func (e *APIError) WithHeader(key, value string) *APIError {
+	if e.Headers == nil {
+		e.Headers = make(http.Header)
+	}
+	e.Headers.Add(key, value)
+	return e
+}
+
+// writeHeaders 将 Headers 和 Retry-After 写入响应。
+func (e *APIError) writeHeaders(h http.Header) {
+	for k, values := range e.Headers {
+		for _, v := range values {
+			h.Add(k, v)
+		}
+	}
+	if e.RetryAfter > 0 {
+		h.Set("Retry-After", strconv.FormatInt(int64((e.RetryAfter+time.Second-1)/time.Second), 10))
+	}
+}
+
 // NewAPIError 创建一个新的 API 错误。
 func NewAPIError(httpStatus, code int, message string) *APIError {
 	return &APIError{
@@ -85,6 +120,21 @@ func ErrNotImplemented(method string) *APIError {
 	return NewAPIError(http.StatusNotImplemented, http.StatusNotImplemented, method+" not implemented")
 }
 
+// ErrPayloadTooLarge 创建 413 请求体过大错误。
+func ErrPayloadTooLarge(message string) *APIError {
+	return NewAPIError(http.StatusRequestEntityTooLarge, http.StatusRequestEntityTooLarge, message)
+}
+
+// ErrTooManyRequests 创建 429 请求过多错误。
+func ErrTooManyRequests(message string) *APIError {
+	return NewAPIError(http.StatusTooManyRequests, http.StatusTooManyRequests, message)
+}
+
+// ErrServiceUnavailable 创建 503 服务不可用错误。
+func ErrServiceUnavailable(message string) *APIError {
+	return NewAPIError(http.StatusServiceUnavailable, http.StatusServiceUnavailable, message)
+}
+
 // BindError 表示请求绑定错误。
 type BindError struct {
 	Err    error
@@ -115,6 +165,7 @@ type ValidationError struct {
 
 // ValidationErrors 包含多个验证错误。
 type ValidationErrors struct {
+	cause  error
 	Errors []ValidationError `json:"errors"`
 }
 
@@ -122,6 +173,11 @@ func (e *ValidationErrors) Error() string {
 	return fmt.Sprintf("validation failed: %d errors", len(e.Errors))
 }
 
+// Unwrap 返回原始错误，例如绑定校验失败时的 validator.ValidationErrors。
+func (e *ValidationErrors) Unwrap() error {
+	return e.cause
+}
+
 // Add 添加一个验证错误。
 func (e *ValidationErrors) Add(field, message string) {
 	e.Errors = append(e.Errors, ValidationError{Field: field, Message: message})
@@ -131,3 +187,17 @@ func (e *ValidationErrors) Add(field, message string) {
 func (e *ValidationErrors) HasErrors() bool {
 	return len(e.Errors) > 0
 }
+
+// ValidationErrorsFrom 将带标签的 gox.MultiError 转换为 ValidationErrors，标签作为字段名。
+// 处理器直接返回带标签的 MultiError 时也会按验证错误（422）渲染。
+//
+//	errs := gox.NewMultiError()
+//	errs.AddLabeled("email", errors.New("is required"))
+//	return nil, errs.ErrorOrNil()
+func ValidationErrorsFrom(m *gox.MultiError) *ValidationErrors {
+	v := &ValidationErrors{}
+	for i, err := range m.Errors() {
+		v.Add(m.Label(i), err.Error())
+	}
+	return v
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -130,3 +133,62 @@ func TestValidationErrors_Error(t *testing.T) {
 
 	assert.Contains(t, ve.Error(), "2 errors")
 }
+
+func TestErrTooManyRequests(t *testing.T) {
+	err := ErrTooManyRequests("slow down")
+	assert.Equal(t, http.StatusTooManyRequests, err.HTTPStatus)
+	assert.Equal(t, "slow down", err.Message)
+}
+
+func TestAPIError_WithRetryAfterAndHeader(t *testing.T) {
+	err := ErrServiceUnavailable("maintenance").
+		WithRetryAfter(90*time.Second).
+		WithHeader("X-Maintenance", "true")
+
+	assert.Equal(t, http.StatusServiceUnavailable, err.HTTPStatus)
+	assert.Equal(t, 90*time.Second, err.RetryAfter)
+	assert.Equal(t, "true", err.Headers.Get("X-Maintenance"))
+}
+
+func TestHandleError_WritesBackoffHeaders(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	handleError(c, ErrTooManyRequests("slow down").
+		WithRetryAfter(1500*time.Millisecond).
+		WithHeader("X-RateLimit-Remaining", "0"))
+
+	assert.Equal(t, http.StatusTooManyRequests, c.Writer.Status())
+	assert.Equal(t, "2", c.Writer.Header().Get("Retry-After"))
+	assert.Equal(t, "0", c.Writer.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestValidationErrorsFrom_UsesLabelsAsFields(t *testing.T) {
+	m := gox.NewMultiError()
+	m.AddLabeled("email", errors.New("is required"))
+	m.AddLabeled("age", errors.New("must be positive"))
+
+	ve := ValidationErrorsFrom(m)
+	assert.Equal(t, []ValidationError{
+		{Field: "email", Message: "is required"},
+		{Field: "age", Message: "must be positive"},
+	}, ve.Errors)
+}
+
+func TestHandleError_RendersLabeledMultiErrorAsValidation(t *testing.T) {
+	r := newTestEngine()
+	r.GET("/labeled", WrapNoReq(func(c *gin.Context) (any, error) {
+		m := gox.NewMultiError()
+		m.AddLabeled("email", errors.New("is required"))
+		return nil, m.ErrorOrNil()
+	}))
+	r.GET("/plain", WrapNoReq(func(c *gin.Context) (any, error) {
+		m := gox.NewMultiError()
+		m.Add(errors.New("boom"))
+		return nil, m.ErrorOrNil()
+	}))
+
+	w := serve(r, http.MethodGet, "/labeled", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"code":422,"message":"validation failed","data":{"errors":[{"field":"email","message":"is required"}]}}`, w.Body.String())
+
+	assert.Equal(t, http.StatusInternalServerError, serve(r, http.MethodGet, "/plain", "").Code)
+}
@@ -0,0 +1,138 @@
+package ginm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETagger 由自带版本标识的数据实现，SuccessWithETag 和 WithETag 直接使用 ETag 的返回值
+// （例如版本号或更新时间）生成 ETag，不再对响应体计算哈希；协商为非 JSON 格式时追加 "-格式名"。返回值不含引号。
+type ETagger interface {
+	ETag() string
+}
+
+// SuccessWithETag 发送 HTTP 200 的成功 JSON 响应并输出强 ETag。
+// GET 和 HEAD 请求的 If-None-Match 匹配时返回 304 且不输出响应体。
+func SuccessWithETag[T any](c *gin.Context, data T) {
+	jsonWithETag(c, http.StatusOK, OK(data), false)
+}
+
+// SuccessWithWeakETag 与 SuccessWithETag 相同，但输出弱 ETag（W/ 前缀）。
+func SuccessWithWeakETag[T any](c *gin.Context, data T) {
+	jsonWithETag(c, http.StatusOK, OK(data), true)
+}
+
+// WithETag 为 Wrap 系列处理器的成功响应输出 ETag，weak 为 true 时输出弱 ETag，
+// 行为与 SuccessWithETag 相同：
+//
+//	r.GET("/reports/:id", ginm.WrapURI(getReport, ginm.WithETag(false)))
+func WithETag(weak bool) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.etag = true
+		cfg.weakETag = weak
+	}
+}
+
+// jsonWithETag 在 JSON 的基础上计算 ETag 并处理 If-None-Match，转换器只执行一次。
+func jsonWithETag[T any](c *gin.Context, status int, resp Response[T], weak bool) {
+	if untyped, ok := transformResponse(c, status, &resp); ok {
+		writeJSONWithETag(c, status, untyped, weak)
+		return
+	}
+	writeJSONWithETag(c, status, resp, weak)
+}
+
+func writeJSONWithETag[T any](c *gin.Context, status int, resp Response[T], weak bool) {
+	f := negotiateFormat(c)
+	b := jsonBuffers.Get()
+	defer jsonBuffers.Put(b)
+	body, contentType, err := encodeResponse(b, f, resp)
+	if err != nil {
+		// 无法序列化时跳过 ETag，由 writeJSON 按常规路径报告错误
+		writeJSON(c, status, resp)
+		return
+	}
+
+	tag := computeETag(resp.Data, f, body, weak)
+	c.Header("ETag", tag)
+	method := c.Request.Method
+	if (method == http.MethodGet || method == http.MethodHead) && etagMatch(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Data(status, contentType, body)
+}
+
+// encodeResponse 按格式 f 将 resp 编码到 b，返回的响应体和 Content-Type 与 writeJSON 的输出一致。
+// 计算 ETag 需要先得到完整响应体，因此启用 EnvelopeOptions.Stream 时同样缓冲输出。
+func encodeResponse[T any](b *jsonBuffer, f Format, resp Response[T]) ([]byte, string, error) {
+	if f == FormatJSON {
+		if err := b.enc.Encode(resp.envelope()); err != nil {
+			return nil, "", err
+		}
+		// 去掉 json.Encoder 追加的换行符
+		out := b.buf.Bytes()
+		return out[:len(out)-1], jsonContentType[0], nil
+	}
+	rec := &bodyRecorder{header: make(http.Header), Buffer: &b.buf}
+	if err := formatRender(f, resp).Render(rec); err != nil {
+		return nil, "", err
+	}
+	return b.buf.Bytes(), rec.header.Get("Content-Type"), nil
+}
+
+// bodyRecorder 收集 render.Render 写出的响应体和 Content-Type。
+type bodyRecorder struct {
+	*bytes.Buffer
+	header http.Header
+}
+
+func (r *bodyRecorder) Header() http.Header { return r.header }
+
+func (r *bodyRecorder) WriteHeader(int) {}
+
+// computeETag 计算响应的 ETag。data 实现 ETagger 时使用其版本标识，非 JSON 格式追加格式名；
+// 否则对协商格式和实际写出的响应体取哈希。两种方式都保证同一资源的不同表示具有不同的强 ETag。
+func computeETag(data any, f Format, body []byte, weak bool) string {
+	var value string
+	if v, ok := data.(ETagger); ok {
+		value = v.ETag()
+		if f != FormatJSON {
+			value += "-" + string(f)
+		}
+	} else {
+		h := sha256.New()
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+		h.Write(body)
+		value = hex.EncodeToString(h.Sum(nil)[:16])
+	}
+	tag := `"` + value + `"`
+	if weak {
+		tag = "W/" + tag
+	}
+	return tag
+}
+
+// etagMatch 按 If-None-Match 的弱比较规则判断 header 是否匹配 tag（RFC 9110 13.1.2）。
+func etagMatch(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for candidate := range strings.SplitSeq(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
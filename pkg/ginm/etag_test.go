@@ -0,0 +1,92 @@
+package ginm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type etagDoc struct {
+	Version string `json:"version"`
+	Body    string `json:"body"`
+}
+
+func (d etagDoc) ETag() string { return "v" + d.Version }
+
+func TestSuccessWithETag_NotModified(t *testing.T) {
+	body := "hello"
+	r := newHandlerEngine(http.MethodGet, "/doc", func(c *gin.Context) { SuccessWithETag(c, body) })
+
+	w := serve(r, http.MethodGet, "/doc", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	tag := w.Header().Get("ETag")
+	require.True(t, strings.HasPrefix(tag, `"`), tag)
+	assert.JSONEq(t, `{"code":0,"data":"hello"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/doc", "", "If-None-Match", `"other", `+tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, tag, w.Header().Get("ETag"))
+
+	body = "changed"
+	w = serve(r, http.MethodGet, "/doc", "", "If-None-Match", tag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, tag, w.Header().Get("ETag"))
+}
+
+func TestSuccessWithWeakETag_MatchesStrongHeader(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/doc", func(c *gin.Context) { SuccessWithWeakETag(c, 1) })
+
+	tag := serve(r, http.MethodGet, "/doc", "").Header().Get("ETag")
+	require.True(t, strings.HasPrefix(tag, `W/"`), tag)
+	assert.Equal(t, http.StatusNotModified, serve(r, http.MethodGet, "/doc", "", "If-None-Match", strings.TrimPrefix(tag, "W/")).Code)
+	assert.Equal(t, http.StatusNotModified, serve(r, http.MethodGet, "/doc", "", "If-None-Match", "*").Code)
+}
+
+func TestWithETag_UsesETagger(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/docs/:id", WrapURI(func(c *gin.Context, req *handlerIDReq) (etagDoc, error) {
+		return etagDoc{Version: "3", Body: "text"}, nil
+	}, WithETag(true)))
+
+	w := serve(r, http.MethodGet, "/docs/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `W/"v3"`, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, serve(r, http.MethodGet, "/docs/1", "", "If-None-Match", `W/"v3"`).Code)
+}
+
+func TestWithETag_IgnoresIfNoneMatchOnUnsafeMethods(t *testing.T) {
+	r := newHandlerEngine(http.MethodPost, "/docs", WrapNoReq(func(c *gin.Context) (etagDoc, error) {
+		return etagDoc{Version: "1"}, nil
+	}, WithETag(false)))
+
+	w := serve(r, http.MethodPost, "/docs", "", "If-None-Match", `"v1"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+}
+
+func TestWithETag_DependsOnNegotiatedFormat(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatJSON, FormatXML}}))
+	r.GET("/doc", WrapNoReq(func(c *gin.Context) (etagDoc, error) {
+		return etagDoc{Version: "3"}, nil
+	}, WithETag(false)))
+	r.GET("/item", WrapNoReq(func(c *gin.Context) (negotiateItem, error) {
+		return negotiateItem{Name: "widget"}, nil
+	}, WithETag(false)))
+
+	assert.Equal(t, `"v3"`, serve(r, http.MethodGet, "/doc", "", "Accept", "application/json").Header().Get("ETag"))
+	assert.Equal(t, `"v3-xml"`, serve(r, http.MethodGet, "/doc", "", "Accept", "application/xml").Header().Get("ETag"))
+
+	for _, f := range []Format{FormatJSON, FormatXML} {
+		w := serve(r, http.MethodGet, "/item", "", "Accept", "application/"+string(f))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/"+string(f))
+		sum := sha256.Sum256(append([]byte(string(f)+"\x00"), w.Body.Bytes()...))
+		assert.Equal(t, `"`+hex.EncodeToString(sum[:16])+`"`, w.Header().Get("ETag"), f)
+	}
+}
@@ -0,0 +1,401 @@
+package ginm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FilterOp 是过滤运算符。
+type FilterOp string
+
+// 支持的过滤运算符。
+const (
+	FilterEq   FilterOp = "eq"
+	FilterNe   FilterOp = "ne"
+	FilterGt   FilterOp = "gt"
+	FilterGte  FilterOp = "gte"
+	FilterLt   FilterOp = "lt"
+	FilterLte  FilterOp = "lte"
+	FilterLike FilterOp = "like" // 包含匹配，不区分大小写
+	FilterIn   FilterOp = "in"   // 逗号分隔的多个值
+)
+
+// Filter 是单个过滤条件，Value 已转换为字段类型（FilterIn 时为该类型的切片）。
+type Filter struct {
+	Value  any
+	Field  string
+	Column string
+	Op     FilterOp
+
+	index []int
+}
+
+// Filters 是针对类型 T 的一组过滤条件，各条件之间为 AND 关系。
+type Filters[T any] []Filter
+
+// BindFilters 解析 ?filter[age][gte]=18&filter[name][like]=jo 形式的查询参数，省略运算符时为 eq。
+// 可过滤的字段由 T 的 filter 标签声明，标签值为允许的运算符，字段名取 json 标签，列名取 db 标签（缺省同字段名）：
+//
+//	type User struct {
+//	    Name string    `json:"name" filter:"eq,like"`
+//	    Age  int       `json:"age"  filter:"eq,gte,lte,in"`
+//	    Born time.Time `json:"born" db:"born_at" filter:"gt,lt"`
+//	}
+//
+// 未声明的字段、不允许的运算符或无法转换的值返回 query 来源的 *BindError（包装 *ValidationErrors，以 422 输出）。
+// 字段类型不支持或运算符与类型不匹配时 panic。
+func BindFilters[T any](c *gin.Context) (Filters[T], error) {
+	fields := filterFieldsFor(reflect.TypeFor[T]())
+	query := c.Request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var filters Filters[T]
+	v := &ValidationErrors{}
+	for _, key := range keys {
+		name, op, ok := parseFilterKey(key)
+		if !ok {
+			v.Add(key, "malformed filter parameter")
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			v.Add(key, fmt.Sprintf("unsupported filter field: %s", name))
+			continue
+		}
+		if !slices.Contains(field.ops, op) {
+			v.Add(key, fmt.Sprintf("unsupported filter operator: %s", op))
+			continue
+		}
+		for _, raw := range query[key] {
+			value, err := field.parse(op, raw)
+			if err != nil {
+				v.Add(key, err.Error())
+				continue
+			}
+			filters = append(filters, Filter{Field: name, Column: field.column, Op: op, Value: value, index: field.index})
+		}
+	}
+	if v.HasErrors() {
+		return nil, NewBindError("query", v)
+	}
+	return filters, nil
+}
+
+// parseFilterKey 将 filter[name] 或 filter[name][op] 拆分为字段名和运算符。
+func parseFilterKey(key string) (string, FilterOp, bool) {
+	rest := strings.TrimPrefix(key, "filter[")
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	if rest == "" {
+		return name, FilterEq, true
+	}
+	op, ok := strings.CutPrefix(rest, "[")
+	if !ok || !strings.HasSuffix(op, "]") || len(op) < 2 {
+		return "", "", false
+	}
+	return name, FilterOp(strings.TrimSuffix(op, "]")), true
+}
+
+// SQL 返回 WHERE 片段（不含关键字）及绑定参数，没有条件时返回 "1=1"。
+// 列名来自 T 的结构体标签；placeholder 为 nil 时使用 "?"，PostgreSQL 使用 DollarPlaceholder。
+// FilterLike 转义通配符后生成 LIKE '%值%'，在 MySQL 默认排序规则和 SQLite 下不区分大小写，
+// 与 Match 一致；PostgreSQL 的 LIKE 区分大小写，需要一致结果时对列使用不区分大小写的排序规则。
+func (fs Filters[T]) SQL(placeholder func(n int) string) (string, []any) {
+	if len(fs) == 0 {
+		return "1=1", nil
+	}
+	if placeholder == nil {
+		placeholder = func(int) string { return "?" }
+	}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return placeholder(len(args))
+	}
+
+	conds := make([]string, len(fs))
+	for i, f := range fs {
+		switch f.Op {
+		case FilterIn:
+			values := reflect.ValueOf(f.Value)
+			marks := make([]string, values.Len())
+			for j := range marks {
+				marks[j] = arg(values.Index(j).Interface())
+			}
+			conds[i] = f.Column + " IN (" + strings.Join(marks, ", ") + ")"
+		case FilterLike:
+			conds[i] = f.Column + " LIKE " + arg("%"+likeEscaper.Replace(f.Value.(string))+"%") + " ESCAPE '!'"
+		default:
+			conds[i] = f.Column + " " + filterSQLOps[f.Op] + " " + arg(f.Value)
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+var filterSQLOps = map[FilterOp]string{
+	FilterEq: "=", FilterNe: "<>", FilterGt: ">", FilterGte: ">=", FilterLt: "<", FilterLte: "<=",
+}
+
+// likeEscaper 使用 "!" 作为 LIKE 转义字符：反斜杠在 MySQL 默认 sql_mode 下会转义字符串字面量中的引号，
+// "!" 在 MySQL、PostgreSQL 和 SQLite 中都可直接用于 ESCAPE 子句。
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+// Match 报告 item 是否满足所有条件，用于内存中过滤。字段为 nil 指针时不满足任何条件。
+// FilterLike 忽略大小写匹配子串，与 SQL 中默认排序规则下的 LIKE 一致。
+func (fs Filters[T]) Match(item T) bool {
+	v := reflect.ValueOf(&item).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	for _, f := range fs {
+		field, err := v.FieldByIndexErr(f.index)
+		if err != nil {
+			return false
+		}
+		for field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return false
+			}
+			field = field.Elem()
+		}
+		if !matchFilter(f, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// Predicate 返回 Match 的函数形式，可直接用于 gox.Filter 等工具。
+func (fs Filters[T]) Predicate() func(T) bool {
+	return fs.Match
+}
+
+// matchFilter 判断单个字段值是否满足条件。
+func matchFilter(f Filter, field reflect.Value) bool {
+	switch f.Op {
+	case FilterIn:
+		values := reflect.ValueOf(f.Value)
+		for i := range values.Len() {
+			if compareFilterValue(field, values.Index(i)) == 0 {
+				return true
+			}
+		}
+		return false
+	case FilterLike:
+		return strings.Contains(strings.ToLower(field.String()), strings.ToLower(f.Value.(string)))
+	}
+
+	c := compareFilterValue(field, reflect.ValueOf(f.Value))
+	switch f.Op {
+	case FilterEq:
+		return c == 0
+	case FilterNe:
+		return c != 0
+	case FilterGt:
+		return c > 0
+	case FilterGte:
+		return c >= 0
+	case FilterLt:
+		return c < 0
+	case FilterLte:
+		return c <= 0
+	}
+	return false
+}
+
+// compareFilterValue 比较同类型的两个值。
+func compareFilterValue(a, b reflect.Value) int {
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Compare(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		return 1
+	}
+	return 1
+}
+
+// --- 字段元数据 ---
+
+// filterField 是可过滤字段的元数据。
+type filterField struct {
+	typ    reflect.Type
+	column string
+	ops    []FilterOp
+	index  []int
+}
+
+var filterFieldCache sync.Map // reflect.Type -> map[string]*filterField
+
+// filterFieldsFor 返回 t 中声明了 filter 标签的字段，按字段名索引。
+func filterFieldsFor(t reflect.Type) map[string]*filterField {
+	if cached, ok := filterFieldCache.Load(t); ok {
+		return cached.(map[string]*filterField)
+	}
+	fields := make(map[string]*filterField)
+	collectFilterFields(derefType(t), nil, fields)
+	filterFieldCache.Store(t, fields)
+	return fields
+}
+
+func collectFilterFields(t reflect.Type, parent []int, fields map[string]*filterField) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			collectFilterFields(f.Type, index, fields)
+			continue
+		}
+		tag, ok := f.Tag.Lookup("filter")
+		if !ok || !f.IsExported() {
+			continue
+		}
+
+		name := tagName(f.Tag.Get("json"))
+		if name == "" || name == "-" {
+			name = f.Name
+		}
+		column := tagName(f.Tag.Get("db"))
+		if column == "" {
+			column = name
+		}
+		field := &filterField{typ: derefType(f.Type), column: column, index: index}
+		for op := range strings.SplitSeq(tag, ",") {
+			if op = strings.TrimSpace(op); op != "" {
+				field.ops = append(field.ops, FilterOp(op))
+			}
+		}
+		if len(field.ops) == 0 {
+			field.ops = []FilterOp{FilterEq}
+		}
+		field.validate(t.Name() + "." + f.Name)
+		if !validColumn(column) {
+			panic(fmt.Sprintf("invalid filter column for %s.%s: %q", t.Name(), f.Name, column))
+		}
+		fields[name] = field
+	}
+}
+
+// validate 检查字段类型和运算符是否受支持。
+func (f *filterField) validate(name string) {
+	ordered := true
+	switch {
+	case f.typ == timeType:
+	case f.typ.Kind() == reflect.Bool:
+		ordered = false
+	case f.typ.Kind() == reflect.String,
+		f.typ.Kind() >= reflect.Int && f.typ.Kind() <= reflect.Uint64,
+		f.typ.Kind() == reflect.Float32, f.typ.Kind() == reflect.Float64:
+	default:
+		panic(fmt.Sprintf("unsupported filter field type for %s: %s", name, f.typ))
+	}
+	for _, op := range f.ops {
+		switch op {
+		case FilterEq, FilterNe, FilterIn:
+		case FilterGt, FilterGte, FilterLt, FilterLte:
+			if !ordered {
+				panic(fmt.Sprintf("filter operator %q is not supported for %s", op, name))
+			}
+		case FilterLike:
+			if f.typ.Kind() != reflect.String {
+				panic(fmt.Sprintf("filter operator %q is not supported for %s", op, name))
+			}
+		default:
+			panic(fmt.Sprintf("unknown filter operator %q for %s", op, name))
+		}
+	}
+}
+
+// parse 将原始字符串转换为字段类型的值，FilterIn 时返回切片。
+func (f *filterField) parse(op FilterOp, raw string) (any, error) {
+	if op != FilterIn {
+		v, err := f.parseOne(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	parts := strings.Split(raw, ",")
+	values := reflect.MakeSlice(reflect.SliceOf(f.typ), 0, len(parts))
+	for _, part := range parts {
+		v, err := f.parseOne(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		values = reflect.Append(values, v)
+	}
+	return values.Interface(), nil
+}
+
+// parseOne 将单个字符串转换为字段类型的值。
+func (f *filterField) parseOne(raw string) (reflect.Value, error) {
+	v := reflect.New(f.typ).Elem()
+	if f.typ == timeType {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return v, errors.New("must be an RFC 3339 time")
+		}
+		v.Set(reflect.ValueOf(t))
+		return v, nil
+	}
+
+	var err error
+	switch f.typ.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(raw)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(raw, 10, f.typ.Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(raw, 10, f.typ.Bits())
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		n, err = strconv.ParseFloat(raw, f.typ.Bits())
+		v.SetFloat(n)
+	}
+	if err != nil {
+		return v, fmt.Errorf("must be a valid %s", f.typ.Kind())
+	}
+	return v, nil
+}
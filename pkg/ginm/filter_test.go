@@ -0,0 +1,112 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+type filterStatus string
+
+type filterUser struct {
+	Born   time.Time    `json:"born" db:"born_at" filter:"gt,lt"`
+	Nick   *string      `json:"nick" filter:"eq"`
+	Name   string       `json:"name" filter:"eq,like"`
+	Status filterStatus `json:"status" filter:"in"`
+	Secret string       `json:"secret"`
+	Age    int          `json:"age" filter:"eq,gte,lte,in"`
+	Active bool         `json:"active" filter:""`
+}
+
+func bindFilters(t *testing.T, query string) (Filters[filterUser], error) {
+	t.Helper()
+	return BindFilters[filterUser](createTestContext(http.MethodGet, "/users?"+query, nil, ""))
+}
+
+func TestBindFilters_ParsesTypedValues(t *testing.T) {
+	fs, err := bindFilters(t, "filter[age][gte]=18&filter[name][like]=jo&filter[active]=true"+
+		"&filter[status][in]=new,done&filter[born][lt]=2000-01-02T00:00:00Z&page=2")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Filter{
+		{Field: "active", Column: "active", Op: FilterEq, Value: true, index: []int{6}},
+		{Field: "age", Column: "age", Op: FilterGte, Value: 18, index: []int{5}},
+		{Field: "born", Column: "born_at", Op: FilterLt, Value: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), index: []int{0}},
+		{Field: "name", Column: "name", Op: FilterLike, Value: "jo", index: []int{2}},
+		{Field: "status", Column: "status", Op: FilterIn, Value: []filterStatus{"new", "done"}, index: []int{3}},
+	}, []Filter(fs))
+}
+
+func TestBindFilters_RejectsInvalidParameters(t *testing.T) {
+	_, err := bindFilters(t, "filter[secret]=x&filter[age][like]=1&filter[age]=old&filter[name]x=1")
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	assert.Equal(t, []ValidationError{
+		{Field: "filter[age]", Message: "must be a valid int"},
+		{Field: "filter[age][like]", Message: "unsupported filter operator: like"},
+		{Field: "filter[name]x", Message: "malformed filter parameter"},
+		{Field: "filter[secret]", Message: "unsupported filter field: secret"},
+	}, v.Errors)
+	assert.Equal(t, http.StatusUnprocessableEntity, classifyError(createTestContext(http.MethodGet, "/", nil, ""), err).status)
+}
+
+func TestBindFilters_PanicsOnInvalidDeclaration(t *testing.T) {
+	type badType struct {
+		Tags []string `json:"tags" filter:"eq"`
+	}
+	type badOp struct {
+		Age int `json:"age" filter:"like"`
+	}
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	assert.Panics(t, func() { _, _ = BindFilters[badType](c) })
+	assert.Panics(t, func() { _, _ = BindFilters[badOp](c) })
+}
+
+func TestFilters_SQL(t *testing.T) {
+	fs, err := bindFilters(t, "filter[age][gte]=18&filter[name][like]=50%25_a!&filter[status][in]=a,b&filter[born][gt]=2000-01-01T00:00:00Z")
+	require.NoError(t, err)
+
+	where, args := fs.SQL(nil)
+	assert.Equal(t, `age >= ? AND born_at > ? AND name LIKE ? ESCAPE '!' AND status IN (?, ?)`, where)
+	assert.Equal(t, []any{18, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), `%50!%!_a!!%`, filterStatus("a"), filterStatus("b")}, args)
+
+	where, _ = fs.SQL(DollarPlaceholder)
+	assert.Equal(t, `age >= $1 AND born_at > $2 AND name LIKE $3 ESCAPE '!' AND status IN ($4, $5)`, where)
+
+	where, args = Filters[filterUser](nil).SQL(nil)
+	assert.Equal(t, "1=1", where)
+	assert.Empty(t, args)
+}
+
+func TestFilters_Match(t *testing.T) {
+	nick := "jj"
+	users := []filterUser{
+		{Name: "john", Age: 30, Status: "new", Active: true, Nick: &nick},
+		{Name: "joe", Age: 17, Status: "done"},
+		{Name: "amy", Age: 40, Status: "new", Active: true},
+	}
+	names := func(query string) []string {
+		fs, err := bindFilters(t, query)
+		require.NoError(t, err)
+		return gox.Map(gox.Filter(users, fs.Predicate()), func(u filterUser) string { return u.Name })
+	}
+
+	assert.Equal(t, []string{"john"}, names("filter[name][like]=jo&filter[age][gte]=18"))
+	// 与 SQL LIKE 在默认排序规则下一致，不区分大小写
+	assert.Equal(t, []string{"john", "joe"}, names("filter[name][like]=JO"))
+	assert.Equal(t, []string{"john", "amy"}, names("filter[active]=true"))
+	assert.Equal(t, []string{"joe", "amy"}, names("filter[age][in]=17,40"))
+	assert.Equal(t, []string{"john"}, names("filter[nick]=jj"))
+	assert.Equal(t, []string{"john", "joe", "amy"}, names(""))
+
+	fs, err := BindFilters[*filterUser](createTestContext(http.MethodGet, "/?filter[age][lte]=30", nil, ""))
+	require.NoError(t, err)
+	assert.True(t, fs.Match(&users[0]))
+	assert.False(t, fs.Match(&users[2]))
+	assert.False(t, fs.Match(nil))
+}
@@ -0,0 +1,191 @@
+// Package gormadapter 基于 gorm 执行 ginm 的分页、排序和资源 CRUD。
+//
+// 分页和排序可单独用于自定义 handler：
+//
+//	func ListUsers(c *gin.Context, q *ginm.PageQuery) (ginm.PageResponse[User], error) {
+//	    db, err := gormadapter.ApplySort(db.WithContext(c.Request.Context()).Where("org_id = ?", orgID), q, userSort)
+//	    if err != nil {
+//	        return ginm.PageResponse[User]{}, err
+//	    }
+//	    return gormadapter.PaginateGorm[User](db, q)
+//	}
+//
+// GormResource 把 gorm 模型直接注册为 RESTful 资源：
+//
+//	users := gormadapter.NewGormResource[User, uint](db, gormadapter.WithSort(userSort))
+//	ginm.RegisterResource(r.Group("/users"), users)
+package gormadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+// PaginateGorm 以 db 的条件执行计数和 OFFSET/LIMIT 两条查询并返回 PageResponse。
+// db 上已有的 ORDER BY 只作用于分页查询；未设置排序时分页结果不稳定。
+func PaginateGorm[T any](db *gorm.DB, query *ginm.PageQuery) (ginm.PageResponse[T], error) {
+	p := ginm.NewPaginatorFromQuery[T](query)
+	db = db.Model(new(T)).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return ginm.PageResponse[T]{}, fmt.Errorf("count: %w", err)
+	}
+	if total == 0 || int64(p.Offset()) >= total {
+		return p.Paginate(nil, total), nil
+	}
+
+	items := make([]T, 0, p.Limit())
+	if err := db.Offset(p.Offset()).Limit(p.Limit()).Find(&items).Error; err != nil {
+		return ginm.PageResponse[T]{}, fmt.Errorf("query: %w", err)
+	}
+	return p.Paginate(items, total), nil
+}
+
+// ApplySort 按白名单解析 query 的 Sort 和 Order 并追加 ORDER BY。
+// 列名只来自 cols，字段不在白名单中时返回 ginm.SortColumns.Parse 的错误（以 422 输出）。
+func ApplySort(db *gorm.DB, query *ginm.PageQuery, cols ginm.SortColumns) (*gorm.DB, error) {
+	fields, err := query.SortFields(cols)
+	if err != nil {
+		return db, err
+	}
+	for _, f := range fields {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Column, Raw: true}, Desc: f.Desc})
+	}
+	return db, nil
+}
+
+// Option 配置 GormResource。
+type Option func(*config)
+
+type config struct {
+	sort  ginm.SortColumns
+	scope func(c *gin.Context, db *gorm.DB) *gorm.DB
+}
+
+// WithSort 设置 List 允许的排序列，未设置时带 sort 参数的请求以 422 拒绝。
+func WithSort(cols ginm.SortColumns) Option {
+	return func(cfg *config) {
+		cfg.sort = cols
+	}
+}
+
+// WithScope 为每个操作追加查询条件，例如按租户或当前用户过滤。
+// 条件同样作用于 Get、Update 和 Delete，范围之外的记录按不存在处理。
+func WithScope(fn func(c *gin.Context, db *gorm.DB) *gorm.DB) Option {
+	return func(cfg *config) {
+		cfg.scope = fn
+	}
+}
+
+// GormResource 基于 gorm 模型实现 ginm.Resource，创建和更新的输入即模型本身，列表查询参数为 ginm.PageQuery。
+// 记录按模型的主键查找，不存在时返回 404。
+type GormResource[T any, ID comparable] struct {
+	db  *gorm.DB
+	cfg config
+}
+
+// NewGormResource 创建基于 db 的资源。
+func NewGormResource[T any, ID comparable](db *gorm.DB, opts ...Option) *GormResource[T, ID] {
+	r := &GormResource[T, ID]{db: db}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+	return r
+}
+
+// session 返回绑定请求上下文并应用 WithScope 的查询。
+func (r *GormResource[T, ID]) session(c *gin.Context) *gorm.DB {
+	db := r.db.WithContext(c.Request.Context())
+	if r.cfg.scope != nil {
+		db = r.cfg.scope(c, db)
+	}
+	return db
+}
+
+// List 按 WithSort 白名单排序并分页。
+func (r *GormResource[T, ID]) List(c *gin.Context, query *ginm.PageQuery) (ginm.PageResponse[T], error) {
+	db, err := ApplySort(r.session(c), query, r.cfg.sort)
+	if err != nil {
+		return ginm.PageResponse[T]{}, err
+	}
+	return PaginateGorm[T](db, query)
+}
+
+// Get 根据主键返回记录。
+func (r *GormResource[T, ID]) Get(c *gin.Context, id ID) (*T, error) {
+	item := new(T)
+	if err := r.session(c).Where(primaryKeyEq(id)).Take(item).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return item, nil
+}
+
+// Create 插入记录，返回包含数据库生成字段的模型。
+func (r *GormResource[T, ID]) Create(c *gin.Context, input *T) (*T, error) {
+	if err := r.session(c).Create(input).Error; err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// Update 以 gorm Updates 的语义更新记录：input 的主键被设置为 id，零值字段不更新。
+func (r *GormResource[T, ID]) Update(c *gin.Context, id ID, input *T) (*T, error) {
+	if _, err := r.Get(c, id); err != nil {
+		return nil, err
+	}
+	db := r.session(c)
+	if err := setPrimaryKey(c.Request.Context(), db, input, id); err != nil {
+		return nil, err
+	}
+	if err := db.Model(input).Updates(input).Error; err != nil {
+		return nil, err
+	}
+	return r.Get(c, id)
+}
+
+// Delete 根据主键删除记录，模型包含 gorm.DeletedAt 时为软删除。
+func (r *GormResource[T, ID]) Delete(c *gin.Context, id ID) error {
+	res := r.session(c).Where(primaryKeyEq(id)).Delete(new(T))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ginm.ErrNotFound("record not found")
+	}
+	return nil
+}
+
+// primaryKeyEq 返回按模型主键匹配 id 的条件，主键列名在执行时由 gorm 根据模型解析。
+func primaryKeyEq(id any) clause.Expression {
+	return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey}, Value: id}
+}
+
+// setPrimaryKey 将 id 写入 input 的主键字段。
+func setPrimaryKey(ctx context.Context, db *gorm.DB, input any, id any) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(input); err != nil {
+		return err
+	}
+	pk := stmt.Schema.PrioritizedPrimaryField
+	if pk == nil {
+		return fmt.Errorf("gormadapter: %s has no primary key", stmt.Schema.Name)
+	}
+	return pk.Set(ctx, reflect.ValueOf(input).Elem(), id)
+}
+
+// notFound 将 gorm.ErrRecordNotFound 转换为 404。
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ginm.ErrNotFound("record not found")
+	}
+	return err
+}
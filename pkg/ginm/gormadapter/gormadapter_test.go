@@ -0,0 +1,180 @@
+package gormadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+type user struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	OrgID int    `json:"org_id"`
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+}
+
+var userSort = ginm.SortColumns{"name": "name", "age": "age"}
+
+func openDB(t *testing.T, n int) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 内存数据库按连接隔离，固定为单连接
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(&user{}))
+	for i := range n {
+		require.NoError(t, db.Create(&user{OrgID: i % 2, Name: "user" + strconv.Itoa(i+1), Age: 20 + n - i}).Error)
+	}
+	return db
+}
+
+func TestPaginateGorm(t *testing.T) {
+	db := openDB(t, 5)
+	resp, err := PaginateGorm[user](db.Order("id"), &ginm.PageQuery{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), resp.Total)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.True(t, resp.HasMore)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, uint(3), resp.Items[0].ID)
+	assert.Equal(t, uint(4), resp.Items[1].ID)
+}
+
+func TestPaginateGorm_KeepsConditionsAndSkipsPastEnd(t *testing.T) {
+	db := openDB(t, 5)
+	resp, err := PaginateGorm[user](db.Where("org_id = ?", 0), &ginm.PageQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+	assert.Len(t, resp.Items, 3)
+
+	resp, err = PaginateGorm[user](db, &ginm.PageQuery{Page: 9})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Total)
+	assert.Equal(t, []user{}, resp.Items)
+}
+
+func TestApplySort(t *testing.T) {
+	db := openDB(t, 3)
+	sorted, err := ApplySort(db, &ginm.PageQuery{Sort: "age", Order: "asc"}, userSort)
+	require.NoError(t, err)
+	resp, err := PaginateGorm[user](sorted, &ginm.PageQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{21, 22, 23}, []int{resp.Items[0].Age, resp.Items[1].Age, resp.Items[2].Age})
+
+	_, err = ApplySort(db, &ginm.PageQuery{Sort: "password"}, userSort)
+	var bindErr *ginm.BindError
+	require.ErrorAs(t, err, &bindErr)
+}
+
+func serve(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var resp ginm.Response[T]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestGormResource_CRUD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ginm.RegisterResource(r.Group("/users"), NewGormResource[user, uint](openDB(t, 3), WithSort(userSort)))
+
+	w := serve(r, http.MethodPost, "/users", `{"name":"new","age":30}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	created := decode[user](t, w)
+	assert.Equal(t, uint(4), created.ID)
+
+	w = serve(r, http.MethodGet, "/users/4", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "new", decode[user](t, w).Name)
+
+	w = serve(r, http.MethodPut, "/users/4", `{"id":99,"name":"renamed"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	updated := decode[user](t, w)
+	assert.Equal(t, user{ID: 4, Name: "renamed", Age: 30}, updated)
+
+	w = serve(r, http.MethodGet, "/users?sort=-age&page_size=2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	page := decode[ginm.PageResponse[user]](t, w)
+	assert.Equal(t, int64(4), page.Total)
+	assert.Equal(t, []uint{4, 1}, []uint{page.Items[0].ID, page.Items[1].ID})
+
+	w = serve(r, http.MethodGet, "/users?sort=org_id", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = serve(r, http.MethodDelete, "/users/4", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/users/4", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodDelete, "/users/4", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPut, "/users/4", `{"name":"x"}`).Code)
+}
+
+func TestGormResource_Scope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ginm.RegisterResource(r.Group("/users"), NewGormResource[user, uint](openDB(t, 4),
+		WithScope(func(c *gin.Context, db *gorm.DB) *gorm.DB {
+			return db.Where("org_id = ?", 1)
+		})))
+
+	page := decode[ginm.PageResponse[user]](t, serve(r, http.MethodGet, "/users", ""))
+	assert.Equal(t, int64(2), page.Total)
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/users/2", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/users/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodDelete, "/users/1", "").Code)
+}
+
+// TestGormResource_UsesRequestContext 检查查询使用请求的 Context 而不是 gin 会复用的 *gin.Context，
+// 后者会被 database/sql 在请求结束后继续读取（go test -race 报告数据竞争）。
+func TestGormResource_UsesRequestContext(t *testing.T) {
+	db := openDB(t, 1)
+	var ginCtx []string
+	check := func(name string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			if _, ok := tx.Statement.Context.(*gin.Context); ok {
+				ginCtx = append(ginCtx, name)
+			}
+		}
+	}
+	cb := db.Callback()
+	require.NoError(t, cb.Query().Before("gorm:query").Register("test:query", check("query")))
+	require.NoError(t, cb.Create().Before("gorm:create").Register("test:create", check("create")))
+	require.NoError(t, cb.Update().Before("gorm:update").Register("test:update", check("update")))
+	require.NoError(t, cb.Delete().Before("gorm:delete").Register("test:delete", check("delete")))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ginm.RegisterResource(r.Group("/users"), NewGormResource[user, uint](db))
+	serve(r, http.MethodGet, "/users", "")
+	serve(r, http.MethodPost, "/users", `{"name":"a"}`)
+	serve(r, http.MethodPut, "/users/1", `{"name":"b"}`)
+	serve(r, http.MethodDelete, "/users/1", "")
+	assert.Empty(t, ginCtx)
+}
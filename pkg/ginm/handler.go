@@ -1,228 +1,394 @@
 package ginm
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
 )
 
 // HandlerFunc 是泛型处理器类型，Resp 为响应数据类型
 type HandlerFunc[Req, Resp any] func(c *gin.Context, req *Req) (Resp, error)
 
+// CtxHandlerFunc 是不依赖 gin 的处理器类型，可直接挂载服务层函数。
+type CtxHandlerFunc[Req, Resp any] func(ctx context.Context, req *Req) (Resp, error)
+
 // HandlerFuncNoReq 是不需要请求绑定的处理器。
 type HandlerFuncNoReq[Resp any] func(c *gin.Context) (Resp, error)
 
+// wrapConfig 包含 Wrap 系列函数的请求绑定、成功响应和钩子配置。
+type wrapConfig struct {
+	// binder 是 WithBinder 设置的绑定函数，类型为 func(*gin.Context) (*Req, error)。
+	binder  any
+	message string
+	code    int
+	status  int
+	// onBind、onSuccess、onError 是 WithOnBind 等选项添加的钩子，类型在 newWrapper 中解析。
+	onBind    []any
+	onSuccess []any
+	onError   []any
+	// links 是 WithLinks 设置的链接生成函数。
+	links func(b *LinkBuilder) Links
+	// etag、weakETag 由 WithETag 设置。
+	etag     bool
+	weakETag bool
+}
+
+// WrapOption 是 Wrap 系列函数的函数式选项，用于定制单个路由的成功响应信封。
+//
+//	r.POST("/orders", ginm.WrapJSON(createOrder, ginm.WithStatus(http.StatusAccepted), ginm.WithSuccessMessage("queued")))
+type WrapOption func(*wrapConfig)
+
+// WithSuccessMessage 设置成功响应的 message 字段。
+func WithSuccessMessage(message string) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.message = message
+	}
+}
+
+// WithSuccessCode 设置成功响应的业务码，默认为 0。
+func WithSuccessCode(code int) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.code = code
+	}
+}
+
+// WithStatus 设置成功响应的 HTTP 状态码，覆盖包装函数的默认值。
+func WithStatus(status int) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.status = status
+	}
+}
+
+// WithBinder 使用 bind 代替包装函数默认的请求绑定，适用于自定义 Content-Type、先解密再绑定等场景，
+// 请求和响应的其余处理保持不变：
+//
+//	r.POST("/webhook", ginm.WrapJSON(handleWebhook, ginm.WithBinder(func(c *gin.Context) (*WebhookReq, error) {
+//	    return decryptAndBind[WebhookReq](c)
+//	})))
+//
+// bind 的请求类型必须与处理器一致，否则在创建处理器时 panic。不绑定请求的 WrapNoReq 忽略此选项。
+func WithBinder[Req any](bind func(c *gin.Context) (*Req, error)) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.binder = bind
+	}
+}
+
+// wrapBinder 返回 WithBinder 设置的绑定函数，未设置时返回 def。
+func wrapBinder[Req any](cfg *wrapConfig, def func(c *gin.Context) (*Req, error)) func(c *gin.Context) (*Req, error) {
+	if cfg.binder == nil {
+		return def
+	}
+	bind, ok := cfg.binder.(func(c *gin.Context) (*Req, error))
+	if !ok {
+		panic(fmt.Sprintf("WithBinder: binder type %s does not match request, want %s",
+			reflect.TypeOf(cfg.binder), reflect.TypeOf(def)))
+	}
+	return bind
+}
+
+func newWrapConfig(status int, opts []WrapOption) *wrapConfig {
+	cfg := &wrapConfig{status: status}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
 // Wrap 将泛型处理器转换为 gin.HandlerFunc，自动绑定请求。
 // 根据 Content-Type 自动选择绑定方式（JSON、XML、Form 等）。
-func Wrap[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+func Wrap[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, Bind[Req], opts)
 	return func(c *gin.Context) {
-		req, err := Bind[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
-// WrapJSON 将泛型处理器转换为 gin.HandlerFunc，使用 JSON 绑定。
-func WrapJSON[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+// WrapCtx 与 Wrap 相同，但处理器接收 StdContext 返回的 context.Context 而非 *gin.Context，
+// 服务层无需导入 gin 即可读取截止时间、请求 ID 等上下文值：
+//
+//	r.POST("/users", ginm.WrapCtx(userService.Create))
+func WrapCtx[Req, Resp any](handler CtxHandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, Bind[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindJSON[Req](c)
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(StdContext(c), req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	}
+}
+
+// WrapJSON 将泛型处理器转换为 gin.HandlerFunc，使用 JSON 绑定。
+func WrapJSON[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, BindJSON[Req], opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapQuery 将泛型处理器转换为 gin.HandlerFunc，使用查询参数绑定。
-func WrapQuery[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+func WrapQuery[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, BindQuery[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindQuery[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapURI 将泛型处理器转换为 gin.HandlerFunc，使用 URI 绑定。
-func WrapURI[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+func WrapURI[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, BindURI[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindURI[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapNoReq 将不需要请求绑定的处理器转换为 gin.HandlerFunc。
-func WrapNoReq[Resp any](handler HandlerFuncNoReq[Resp]) gin.HandlerFunc {
+func WrapNoReq[Resp any](handler HandlerFuncNoReq[Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[struct{}, Resp](http.StatusOK, nil, opts)
 	return func(c *gin.Context) {
 		resp, err := handler(c)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, nil, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, nil, resp)
 	}
 }
 
-// WrapPage 将分页处理器转换为 gin.HandlerFunc。
-func WrapPage[Req any, Item any](handler func(c *gin.Context, req *Req) (PageResponse[Item], error)) gin.HandlerFunc {
+// WrapPage 将分页处理器转换为 gin.HandlerFunc，启用 PaginationOptions.Headers 时同时输出分页响应头。
+func WrapPage[Req any, Item any](handler func(c *gin.Context, req *Req) (PageResponse[Item], error), opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, PageResponse[Item]](http.StatusOK, BindQuery[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindQuery[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		writePageHeaders(c, resp)
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapURIAndJSON 将同时使用 URI 和 JSON 绑定的处理器转换为 gin.HandlerFunc。
 // 适用于 PUT /users/:id 带 JSON body 的路由。
-func WrapURIAndJSON[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+func WrapURIAndJSON[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, BindURIAndBody[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindURIAndBody[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapWithStatus 将泛型处理器转换为 gin.HandlerFunc，使用自定义成功状态码。
-func WrapWithStatus[Req, Resp any](handler HandlerFunc[Req, Resp], successStatus int) gin.HandlerFunc {
+func WrapWithStatus[Req, Resp any](handler HandlerFunc[Req, Resp], successStatus int, opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](successStatus, Bind[Req], opts)
 	return func(c *gin.Context) {
-		req, err := Bind[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(successStatus, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapCreated 包装返回 HTTP 201 Created 的处理器。
-func WrapCreated[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
-	return WrapWithStatus(handler, http.StatusCreated)
+func WrapCreated[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	return WrapWithStatus(handler, http.StatusCreated, opts...)
 }
 
 // WrapCreatedJSON 包装使用 JSON 绑定并返回 HTTP 201 Created 的处理器。
-func WrapCreatedJSON[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+func WrapCreatedJSON[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusCreated, BindJSON[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindJSON[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := handler(c, req)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.JSON(http.StatusCreated, OK(resp))
+		w.respond(c, req, resp)
 	}
 }
 
 // WrapAccepted 包装返回 HTTP 202 Accepted 的处理器。
-func WrapAccepted[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
-	return WrapWithStatus(handler, http.StatusAccepted)
+func WrapAccepted[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	return WrapWithStatus(handler, http.StatusAccepted, opts...)
 }
 
-// WrapNoContent 包装返回 HTTP 204 No Content 的处理器。
-func WrapNoContent[Req any](handler func(c *gin.Context, req *Req) error) gin.HandlerFunc {
+// WrapNoContent 包装返回 HTTP 204 No Content 的处理器。响应没有响应体，仅 WithStatus 和 WithBinder 选项生效。
+func WrapNoContent[Req any](handler func(c *gin.Context, req *Req) error, opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, struct{}](http.StatusNoContent, Bind[Req], opts)
 	return func(c *gin.Context) {
-		req, err := Bind[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		if err := handler(c, req); err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.Status(http.StatusNoContent)
+		w.succeed(c, req, struct{}{})
+		c.Status(w.cfg.status)
 	}
 }
 
 // WrapNoContentJSON 包装使用 JSON 绑定并返回 HTTP 204 的处理器。
-func WrapNoContentJSON[Req any](handler func(c *gin.Context, req *Req) error) gin.HandlerFunc {
+func WrapNoContentJSON[Req any](handler func(c *gin.Context, req *Req) error, opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, struct{}](http.StatusNoContent, BindJSON[Req], opts)
 	return func(c *gin.Context) {
-		req, err := BindJSON[Req](c)
-		if err != nil {
-			handleError(c, err)
+		req, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		if err := handler(c, req); err != nil {
-			handleError(c, err)
+			w.fail(c, req, err)
 			return
 		}
 
-		c.Status(http.StatusNoContent)
+		w.succeed(c, req, struct{}{})
+		c.Status(w.cfg.status)
+	}
+}
+
+// --- 基于 Result 的处理器 ---
+
+// WrapR 与 Wrap 相同，但处理器返回 gox.Result，Err 结果交给错误处理器输出。
+func WrapR[Req, Resp any](handler func(c *gin.Context, req *Req) gox.Result[Resp], opts ...WrapOption) gin.HandlerFunc {
+	return Wrap(unwrapResult(handler), opts...)
+}
+
+// WrapJSONR 与 WrapJSON 相同，但处理器返回 gox.Result。
+//
+//	r.POST("/users", ginm.WrapJSONR(func(c *gin.Context, req *CreateUserReq) gox.Result[*User] {
+//	    return userService.Create(c, req)
+//	}))
+func WrapJSONR[Req, Resp any](handler func(c *gin.Context, req *Req) gox.Result[Resp], opts ...WrapOption) gin.HandlerFunc {
+	return WrapJSON(unwrapResult(handler), opts...)
+}
+
+// unwrapResult 将返回 Result 的处理器转换为 HandlerFunc。
+func unwrapResult[Req, Resp any](handler func(c *gin.Context, req *Req) gox.Result[Resp]) HandlerFunc[Req, Resp] {
+	return func(c *gin.Context, req *Req) (Resp, error) {
+		return handler(c, req).GetWithError()
+	}
+}
+
+// --- 基于 Optional 的处理器 ---
+
+// WrapOptional 包装按 ID 查找的处理器：请求通过 BindURIAndQuery 绑定，
+// 处理器返回 None 时输出 404，返回 Some 时输出其值。
+//
+//	r.GET("/users/:id", ginm.WrapOptional(func(c *gin.Context, req *GetUserReq) (gox.Optional[*User], error) {
+//	    return userRepo.FindByID(c, req.ID)
+//	}))
+func WrapOptional[Req, Resp any](handler func(c *gin.Context, req *Req) (gox.Optional[Resp], error), opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, BindURIAndQuery[Req], opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		value, ok := resp.Get()
+		if !ok {
+			w.fail(c, req, ErrNotFound("not found"))
+			return
+		}
+		w.respond(c, req, value)
 	}
 }
 
@@ -265,20 +431,7 @@ func HandleDelete(handler func(c *gin.Context) error) gin.HandlerFunc {
 
 // DeleteWithURI 包装带 URI 绑定的 DELETE 处理器。
 func DeleteWithURI[Req any](handler func(c *gin.Context, req *Req) error) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		req, err := BindURI[Req](c)
-		if err != nil {
-			handleError(c, err)
-			return
-		}
-
-		if err := handler(c, req); err != nil {
-			handleError(c, err)
-			return
-		}
-
-		c.Status(http.StatusNoContent)
-	}
+	return WrapNoContent(handler, WithBinder(BindURI[Req]))
 }
 
 // HandlePatch 包装使用 URI 和 JSON 绑定的 PATCH 处理器。
@@ -286,42 +439,97 @@ func HandlePatch[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc
 	return WrapURIAndJSON(handler)
 }
 
-// handleError 处理错误并发送适当的 HTTP 响应。
+// handleError 处理错误并发送适当的 HTTP 响应，设置了 SetErrorHandler 时交给自定义处理器。
 func handleError(c *gin.Context, err error) {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		errStr := ""
-		if apiErr.Err != nil && gin.Mode() != gin.ReleaseMode {
-			errStr = apiErr.Err.Error()
-		}
-		c.JSON(apiErr.HTTPStatus, FailWithError[any](apiErr.Code, apiErr.Message, errStr))
+	if h := errorHandler.Load(); h != nil {
+		(*h)(c, err)
 		return
 	}
+	DefaultErrorHandler(c, err)
+}
 
-	var bindErr *BindError
-	if errors.As(err, &bindErr) {
-		c.JSON(http.StatusBadRequest, Fail[any](http.StatusBadRequest, bindErr.Error()))
+// DefaultErrorHandler 是默认的错误处理器，可在自定义 ErrorHandler 中作为兜底调用。
+// 启用 problem+json 模式时输出 RFC 7807 文档，否则输出 Response 信封。
+func DefaultErrorHandler(c *gin.Context, err error) {
+	info := classifyError(c, err)
+	if info.apiErr != nil {
+		info.apiErr.writeHeaders(c.Writer.Header())
+	}
+	if problemEnabled(c) {
+		writeProblem(c, info)
 		return
 	}
 
-	var validationErrs *ValidationErrors
-	if errors.As(err, &validationErrs) {
-		c.JSON(http.StatusUnprocessableEntity, Response[*ValidationErrors]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: "validation failed",
-			Data:    validationErrs,
+	if info.validation != nil {
+		JSON(c, info.status, Response[*ValidationErrors]{
+			Code:    info.code,
+			Message: info.message,
+			Data:    info.validation,
 		})
 		return
 	}
+	JSON(c, info.status, FailWithError[any](info.code, info.message, info.detail))
+}
+
+// errorInfo 是错误的分类结果，与输出格式无关。
+type errorInfo struct {
+	// apiErr 是匹配到的 APIError，其附加响应头由调用方写入。
+	apiErr     *APIError
+	validation *ValidationErrors
+	message    string
+	// detail 是底层错误信息，release 模式下为空。
+	detail string
+	status int
+	code   int
+}
+
+// classifyError 将错误映射为状态码和消息，依次尝试 APIError、已注册的 ErrorMapper、
+// 验证错误（包括绑定时的 validator 校验失败）和 BindError，其余为 500。
+func classifyError(c *gin.Context, err error) errorInfo {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		apiErr, _ = mapError(err)
+	}
+	if apiErr != nil {
+		info := errorInfo{apiErr: apiErr, status: apiErr.HTTPStatus, code: apiErr.Code, message: Translate(c, apiErr.Message, apiErr.Message)}
+		if apiErr.Err != nil && gin.Mode() != gin.ReleaseMode {
+			info.detail = apiErr.Err.Error()
+		}
+		return info
+	}
+
+	var validationErrs *ValidationErrors
+	var multiErr *gox.MultiError
+	if errors.As(err, &multiErr) && multiErr.HasLabels() {
+		validationErrs = ValidationErrorsFrom(multiErr)
+	}
+	if validationErrs != nil || errors.As(err, &validationErrs) {
+		return errorInfo{
+			status:     http.StatusUnprocessableEntity,
+			code:       http.StatusUnprocessableEntity,
+			message:    Translate(c, "error.validation_failed", "validation failed"),
+			validation: validationErrs,
+		}
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return classifyError(c, ErrPayloadTooLarge("request body too large"))
+	}
+
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		return errorInfo{status: http.StatusBadRequest, code: http.StatusBadRequest, message: bindErr.Error()}
+	}
 
 	// 默认: 内部服务器错误
-	errStr := ""
+	info := errorInfo{
+		status:  http.StatusInternalServerError,
+		code:    http.StatusInternalServerError,
+		message: Translate(c, "error.internal", "internal server error"),
+	}
 	if gin.Mode() != gin.ReleaseMode {
-		errStr = err.Error()
+		info.detail = err.Error()
 	}
-	c.JSON(http.StatusInternalServerError, FailWithError[any](
-		http.StatusInternalServerError,
-		"internal server error",
-		errStr,
-	))
+	return info
 }
@@ -0,0 +1,113 @@
+package ginm
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+type handlerReq struct {
+	Name string `binding:"required" form:"name" json:"name"`
+}
+
+func TestWrapJSONR(t *testing.T) {
+	r := newHandlerEngine(http.MethodPost, "/greet", WrapJSONR(func(c *gin.Context, req *handlerReq) gox.Result[string] {
+		if req.Name == "taken" {
+			return gox.RErr[string](ErrConflict("name taken"))
+		}
+		return gox.ROk("hello " + req.Name)
+	}))
+
+	w := serve(r, http.MethodPost, "/greet", `{"name":"a"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"hello a"}`, w.Body.String())
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodPost, "/greet", `{"name":"taken"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/greet", `{}`).Code)
+}
+
+func TestWrapR(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/greet", WrapR(func(c *gin.Context, req *handlerReq) gox.Result[string] {
+		return gox.ROk(req.Name)
+	}))
+
+	w := serve(r, http.MethodGet, "/greet?name=b", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"b"}`, w.Body.String())
+}
+
+type handlerIDReq struct {
+	ID int `binding:"required" uri:"id"`
+}
+
+func TestWrapOptional(t *testing.T) {
+	users := map[int]string{1: "alice"}
+	r := newHandlerEngine(http.MethodGet, "/users/:id", WrapOptional(func(c *gin.Context, req *handlerIDReq) (gox.Optional[string], error) {
+		if req.ID < 0 {
+			return gox.ONone[string](), ErrBadRequest("negative id")
+		}
+		name, ok := users[req.ID]
+		return gox.OFromOk(name, ok), nil
+	}))
+
+	w := serve(r, http.MethodGet, "/users/1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"alice"}`, w.Body.String())
+	w = serve(r, http.MethodGet, "/users/2", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"not found"`)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodGet, "/users/-1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodGet, "/users/x", "").Code)
+}
+
+func TestWrapOptions_CustomizeEnvelope(t *testing.T) {
+	handler := func(c *gin.Context, req *handlerReq) (string, error) { return req.Name, nil }
+	r := newHandlerEngine(http.MethodPost, "/jobs", WrapJSON(handler,
+		WithStatus(http.StatusAccepted), WithSuccessMessage("queued"), WithSuccessCode(1001)))
+
+	w := serve(r, http.MethodPost, "/jobs", `{"name":"a"}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.JSONEq(t, `{"code":1001,"message":"queued","data":"a"}`, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/jobs", `{}`).Code)
+
+	r = newHandlerEngine(http.MethodPost, "/users", WrapCreated(handler, WithSuccessMessage("created")))
+	w = serve(r, http.MethodPost, "/users", `{"name":"b"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"code":0,"message":"created","data":"b"}`, w.Body.String())
+}
+
+func TestWithBinder(t *testing.T) {
+	upper := func(c *gin.Context) (*handlerReq, error) {
+		name := c.GetHeader("X-Name")
+		if name == "" {
+			return nil, ErrBadRequest("missing X-Name")
+		}
+		return &handlerReq{Name: strings.ToUpper(name)}, nil
+	}
+	r := newHandlerEngine(http.MethodPost, "/greet", WrapJSON(func(c *gin.Context, req *handlerReq) (string, error) {
+		return req.Name, nil
+	}, WithBinder(upper)))
+
+	w := serve(r, http.MethodPost, "/greet", "not json", "X-Name", "ab")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"AB"}`, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/greet", `{"name":"a"}`).Code)
+
+	r = newHandlerEngine(http.MethodDelete, "/greet", WrapNoContent(func(c *gin.Context, req *handlerReq) error {
+		return nil
+	}, WithBinder(upper)))
+	assert.Equal(t, http.StatusNoContent, serve(r, http.MethodDelete, "/greet", "", "X-Name", "ab").Code)
+}
+
+func TestWithBinder_PanicsOnTypeMismatch(t *testing.T) {
+	assert.PanicsWithValue(t,
+		"WithBinder: binder type func(*gin.Context) (*ginm.handlerIDReq, error) does not match request, want func(*gin.Context) (*ginm.handlerReq, error)",
+		func() {
+			Wrap(func(c *gin.Context, req *handlerReq) (string, error) { return "", nil },
+				WithBinder(func(c *gin.Context) (*handlerIDReq, error) { return nil, nil }))
+		})
+}
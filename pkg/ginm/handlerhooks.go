@@ -0,0 +1,186 @@
+package ginm
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandlerHooks 是 Wrap 系列处理器的全局生命周期钩子，适用于审计日志、指标等横切逻辑。
+// Route、RegisterSearch 和 RegisterResource 注册的路由同样执行这些钩子。
+// req 为绑定后的 *Req（绑定失败或不绑定请求时为 nil），resp 为处理器返回的响应值，字段为 nil 时跳过。
+type HandlerHooks struct {
+	// OnBind 在请求绑定成功后、处理器执行前调用，返回错误时中止请求并按错误处理输出。
+	OnBind func(c *gin.Context, req any) error
+	// OnSuccess 在输出成功响应前调用。
+	OnSuccess func(c *gin.Context, req, resp any)
+	// OnError 在输出错误响应前调用，包括绑定失败和 OnBind 返回的错误。
+	OnError func(c *gin.Context, req any, err error)
+}
+
+var handlerHooks atomic.Pointer[[]HandlerHooks]
+
+// RegisterHandlerHooks 注册全局处理器钩子，多次注册时按注册顺序执行。
+// 通常在启动时调用，对此后创建和已创建的处理器均生效。
+func RegisterHandlerHooks(h HandlerHooks) {
+	for {
+		old := handlerHooks.Load()
+		var hooks []HandlerHooks
+		if old != nil {
+			hooks = append(hooks, *old...)
+		}
+		hooks = append(hooks, h)
+		if handlerHooks.CompareAndSwap(old, &hooks) {
+			return
+		}
+	}
+}
+
+// ResetHandlerHooks 清除所有全局处理器钩子。
+func ResetHandlerHooks() {
+	handlerHooks.Store(nil)
+}
+
+func getHandlerHooks() []HandlerHooks {
+	if h := handlerHooks.Load(); h != nil {
+		return *h
+	}
+	return nil
+}
+
+// WithOnBind 为单个处理器添加绑定后钩子，在全局 OnBind 之后执行，返回错误时中止请求。
+func WithOnBind[Req any](fn func(c *gin.Context, req *Req) error) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.onBind = append(cfg.onBind, fn)
+	}
+}
+
+// WithOnSuccess 为单个处理器添加成功钩子，在全局 OnSuccess 之后执行。
+//
+//	ginm.WrapJSON(createOrder, ginm.WithOnSuccess(func(c *gin.Context, req *CreateOrderReq, order *Order) {
+//	    audit.Log(c, "order.create", order.ID)
+//	}))
+func WithOnSuccess[Req, Resp any](fn func(c *gin.Context, req *Req, resp Resp)) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.onSuccess = append(cfg.onSuccess, fn)
+	}
+}
+
+// WithOnError 为单个处理器添加错误钩子，在全局 OnError 之后执行，绑定失败时 req 为 nil。
+func WithOnError[Req any](fn func(c *gin.Context, req *Req, err error)) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.onError = append(cfg.onError, fn)
+	}
+}
+
+// wrapper 是按请求和响应类型解析后的 Wrap 配置，负责绑定、钩子和响应输出。
+type wrapper[Req, Resp any] struct {
+	cfg       *wrapConfig
+	binder    func(c *gin.Context) (*Req, error)
+	onBind    []func(*gin.Context, *Req) error
+	onSuccess []func(*gin.Context, *Req, Resp)
+	onError   []func(*gin.Context, *Req, error)
+}
+
+// newWrapper 解析选项，def 为默认绑定函数（不绑定请求时为 nil）。钩子类型与处理器不匹配时 panic。
+func newWrapper[Req, Resp any](status int, def func(c *gin.Context) (*Req, error), opts []WrapOption) *wrapper[Req, Resp] {
+	cfg := newWrapConfig(status, opts)
+	w := &wrapper[Req, Resp]{cfg: cfg, binder: def}
+	if def != nil {
+		w.binder = wrapBinder(cfg, def)
+	}
+	resolveWrapHooks("WithOnBind", cfg.onBind, &w.onBind)
+	resolveWrapHooks("WithOnSuccess", cfg.onSuccess, &w.onSuccess)
+	resolveWrapHooks("WithOnError", cfg.onError, &w.onError)
+	return w
+}
+
+func resolveWrapHooks[F any](option string, hooks []any, dst *[]F) {
+	for _, hook := range hooks {
+		fn, ok := hook.(F)
+		if !ok {
+			panic(fmt.Sprintf("%s: hook type %s does not match handler, want %s",
+				option, reflect.TypeOf(hook), reflect.TypeFor[F]()))
+		}
+		*dst = append(*dst, fn)
+	}
+}
+
+// bind 绑定请求并执行 OnBind 钩子，失败时已输出错误响应并返回 false。
+func (w *wrapper[Req, Resp]) bind(c *gin.Context) (*Req, bool) {
+	req, err := w.binder(c)
+	if err != nil {
+		w.fail(c, nil, err)
+		return nil, false
+	}
+	return req, w.bound(c, req)
+}
+
+// bound 对已绑定的请求执行 OnBind 钩子，供分步绑定的处理器使用，失败时已输出错误响应并返回 false。
+func (w *wrapper[Req, Resp]) bound(c *gin.Context, req *Req) bool {
+	for _, h := range getHandlerHooks() {
+		if h.OnBind == nil {
+			continue
+		}
+		if err := h.OnBind(c, req); err != nil {
+			w.fail(c, req, err)
+			return false
+		}
+	}
+	for _, fn := range w.onBind {
+		if err := fn(c, req); err != nil {
+			w.fail(c, req, err)
+			return false
+		}
+	}
+	return true
+}
+
+// fail 执行 OnError 钩子并输出错误响应。
+func (w *wrapper[Req, Resp]) fail(c *gin.Context, req *Req, err error) {
+	var anyReq any
+	if req != nil {
+		anyReq = req
+	}
+	for _, h := range getHandlerHooks() {
+		if h.OnError != nil {
+			h.OnError(c, anyReq, err)
+		}
+	}
+	for _, fn := range w.onError {
+		fn(c, req, err)
+	}
+	handleError(c, err)
+}
+
+// succeed 执行 OnSuccess 钩子，不输出响应。
+func (w *wrapper[Req, Resp]) succeed(c *gin.Context, req *Req, resp Resp) {
+	var anyReq any
+	if req != nil {
+		anyReq = req
+	}
+	for _, h := range getHandlerHooks() {
+		if h.OnSuccess != nil {
+			h.OnSuccess(c, anyReq, resp)
+		}
+	}
+	for _, fn := range w.onSuccess {
+		fn(c, req, resp)
+	}
+}
+
+// respond 执行 OnSuccess 钩子并按配置输出成功响应。
+func (w *wrapper[Req, Resp]) respond(c *gin.Context, req *Req, resp Resp) {
+	w.succeed(c, req, resp)
+	out := Response[Resp]{Code: w.cfg.code, Message: w.cfg.message, Data: resp}
+	if w.cfg.links != nil {
+		out.Links = w.cfg.links(NewLinkBuilder(c))
+	}
+	if w.cfg.etag {
+		jsonWithETag(c, w.cfg.status, out, w.cfg.weakETag)
+		return
+	}
+	JSON(c, w.cfg.status, out)
+}
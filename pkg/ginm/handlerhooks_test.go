@@ -0,0 +1,179 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerHooks_GlobalAndPerWrap(t *testing.T) {
+	var events []string
+	RegisterHandlerHooks(HandlerHooks{
+		OnBind: func(c *gin.Context, req any) error {
+			events = append(events, "global bind "+req.(*handlerReq).Name)
+			return nil
+		},
+		OnSuccess: func(c *gin.Context, req, resp any) {
+			events = append(events, "global success "+resp.(string))
+		},
+		OnError: func(c *gin.Context, req any, err error) {
+			events = append(events, "global error "+err.Error())
+		},
+	})
+	t.Cleanup(ResetHandlerHooks)
+
+	r := newHandlerEngine(http.MethodPost, "/greet", WrapJSON(func(c *gin.Context, req *handlerReq) (string, error) {
+		if req.Name == "bad" {
+			return "", ErrConflict("name taken")
+		}
+		return "hello " + req.Name, nil
+	},
+		WithOnBind(func(c *gin.Context, req *handlerReq) error {
+			events = append(events, "bind "+req.Name)
+			return nil
+		}),
+		WithOnSuccess(func(c *gin.Context, req *handlerReq, resp string) {
+			events = append(events, "success "+resp)
+		}),
+		WithOnError(func(c *gin.Context, req *handlerReq, err error) {
+			events = append(events, "error "+req.Name)
+		}),
+	))
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPost, "/greet", `{"name":"a"}`).Code)
+	assert.Equal(t, []string{"global bind a", "bind a", "global success hello a", "success hello a"}, events)
+
+	events = nil
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodPost, "/greet", `{"name":"bad"}`).Code)
+	assert.Equal(t, []string{"global bind bad", "bind bad", "global error name taken", "error bad"}, events)
+}
+
+func TestHandlerHooks_BindFailureHasNilRequest(t *testing.T) {
+	var globalReq any = "unset"
+	var typedReq *handlerReq
+	called := false
+	RegisterHandlerHooks(HandlerHooks{
+		OnError: func(c *gin.Context, req any, err error) { globalReq = req },
+	})
+	t.Cleanup(ResetHandlerHooks)
+
+	r := newHandlerEngine(http.MethodPost, "/greet", WrapJSON(func(c *gin.Context, req *handlerReq) (string, error) {
+		return req.Name, nil
+	}, WithOnError(func(c *gin.Context, req *handlerReq, err error) {
+		called, typedReq = true, req
+	})))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/greet", `{}`).Code)
+	assert.Nil(t, globalReq)
+	assert.True(t, called)
+	assert.Nil(t, typedReq)
+}
+
+func TestHandlerHooks_OnBindErrorAborts(t *testing.T) {
+	called := false
+	r := newHandlerEngine(http.MethodPost, "/greet", WrapJSON(func(c *gin.Context, req *handlerReq) (string, error) {
+		called = true
+		return req.Name, nil
+	}, WithOnBind(func(c *gin.Context, req *handlerReq) error {
+		return ErrForbidden("denied")
+	})))
+
+	w := serve(r, http.MethodPost, "/greet", `{"name":"a"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.False(t, called)
+}
+
+func TestHandlerHooks_DeleteAndNoReq(t *testing.T) {
+	var resps []any
+	RegisterHandlerHooks(HandlerHooks{
+		OnSuccess: func(c *gin.Context, req, resp any) { resps = append(resps, resp) },
+		OnError:   func(c *gin.Context, req any, err error) { resps = append(resps, err) },
+	})
+	t.Cleanup(ResetHandlerHooks)
+
+	r := newTestEngine()
+	r.DELETE("/items/:id", DeleteWithURI(func(c *gin.Context, req *handlerIDReq) error { return nil }))
+	errBoom := errors.New("boom")
+	r.GET("/fail", WrapNoReq(func(c *gin.Context) (int, error) { return 0, errBoom }))
+
+	assert.Equal(t, http.StatusNoContent, serve(r, http.MethodDelete, "/items/1", "").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(r, http.MethodGet, "/fail", "").Code)
+	assert.Equal(t, []any{struct{}{}, errBoom}, resps)
+}
+
+func TestHandlerHooks_PanicsOnTypeMismatch(t *testing.T) {
+	handler := func(c *gin.Context, req *handlerReq) (string, error) { return "", nil }
+	require.PanicsWithValue(t,
+		"WithOnSuccess: hook type func(*gin.Context, *ginm.handlerReq, int) does not match handler, want func(*gin.Context, *ginm.handlerReq, string)",
+		func() {
+			WrapJSON(handler, WithOnSuccess(func(c *gin.Context, req *handlerReq, resp int) {}))
+		})
+	require.Panics(t, func() {
+		WrapJSON(handler, WithOnBind(func(c *gin.Context, req *handlerIDReq) error { return nil }))
+	})
+}
+
+func TestHandlerHooks_FireForAllHandlers(t *testing.T) {
+	var events []string
+	RegisterHandlerHooks(HandlerHooks{
+		OnBind: func(c *gin.Context, req any) error {
+			events = append(events, "bind")
+			return nil
+		},
+		OnSuccess: func(c *gin.Context, req, resp any) { events = append(events, "success") },
+		OnError:   func(c *gin.Context, req any, err error) { events = append(events, "error") },
+	})
+	t.Cleanup(ResetHandlerHooks)
+
+	r := newTestEngine()
+	greet := func(c *gin.Context, req *handlerReq) (string, error) { return "hello " + req.Name, nil }
+	r.GET("/stream", WrapStream(func(c *gin.Context, req *streamReq) (<-chan int, error) {
+		return feed(req.N), nil
+	}, StreamNDJSON))
+	r.GET("/cached", WrapQueryCached(func(c *gin.Context, req *streamReq) (int, error) {
+		return req.N, nil
+	}, QueryCacheConfig{}))
+	Route(NewOpenAPI(OpenAPIConfig{}), r, http.MethodGet, "/route", func(c *gin.Context, req *streamReq) (int, error) {
+		return req.N, nil
+	})
+	r.POST("/problem", WrapProblem(greet))
+	r.POST("/problem-json", WrapProblemJSON(greet))
+	RegisterSearch(r.Group("/users"), func(c *gin.Context, req *PageQuery) (PageResponse[hookPost], error) {
+		return PageResponse[hookPost]{}, nil
+	})
+	RegisterResource(r.Group("/posts"), &existsResource{hookPostResource{posts: map[int]*hookPost{1: {ID: 1}}}},
+		WithBatchCreate(0), WithBulkDelete(0))
+
+	success := []string{"bind", "success"}
+	failure := []string{"bind", "error"}
+	tests := []struct {
+		method, path, body string
+		want               []string
+	}{
+		{http.MethodGet, "/stream?n=1", "", success},
+		{http.MethodGet, "/cached?n=1", "", success},
+		{http.MethodGet, "/route?n=1", "", success},
+		{http.MethodGet, "/route?n=x", "", []string{"error"}},
+		{http.MethodPost, "/problem", `{"name":"a"}`, success},
+		{http.MethodPost, "/problem-json", `{"name":"a"}`, success},
+		{http.MethodPost, "/users/search", `{}`, success},
+		{http.MethodGet, "/posts", "", failure},
+		{http.MethodGet, "/posts/1", "", failure},
+		{http.MethodHead, "/posts/1", "", success},
+		{http.MethodPost, "/posts", `{"title":"b"}`, success},
+		{http.MethodPut, "/posts/2", `{"title":"c"}`, success},
+		{http.MethodPut, "/posts/x", `{"title":"c"}`, []string{"error"}},
+		{http.MethodDelete, "/posts/2", "", success},
+		{http.MethodPost, "/posts/batch", `[{"title":"d"}]`, success},
+		{http.MethodPost, "/posts/delete-batch", `{"ids":[1]}`, success},
+	}
+	for _, tt := range tests {
+		events = nil
+		serve(r, tt.method, tt.path, tt.body)
+		assert.Equal(t, tt.want, events, "%s %s %s", tt.method, tt.path, tt.body)
+	}
+}
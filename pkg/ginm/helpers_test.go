@@ -0,0 +1,45 @@
+package ginm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestEngine 创建注册了 middleware 的 gin.Engine，测试模式由 bind_test.go 的 init 设置。
+func newTestEngine(middleware ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware...)
+	return r
+}
+
+// newHandlerEngine 创建只注册一个路由的 gin.Engine。
+func newHandlerEngine(method, path string, h gin.HandlerFunc) *gin.Engine {
+	r := newTestEngine()
+	r.Handle(method, path, h)
+	return r
+}
+
+// okHandler 返回 200 且不输出响应体。
+func okHandler(c *gin.Context) { c.Status(http.StatusOK) }
+
+// serve 发送请求并返回响应。body 不为空时以 application/json 发送；
+// headers 为成对的请求头名称和值，Host 设置请求的 Host。
+func serve(r http.Handler, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		if headers[i] == "Host" {
+			req.Host = headers[i+1]
+			continue
+		}
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,119 @@
+package ginm
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hookKind 是资源生命周期钩子的类型。
+type hookKind int
+
+const (
+	hookBeforeCreate hookKind = iota
+	hookAfterCreate
+	hookBeforeUpdate
+	hookAfterUpdate
+	hookBeforeDelete
+	hookAfterDelete
+)
+
+var hookNames = [...]string{
+	hookBeforeCreate: "WithBeforeCreate",
+	hookAfterCreate:  "WithAfterCreate",
+	hookBeforeUpdate: "WithBeforeUpdate",
+	hookAfterUpdate:  "WithAfterUpdate",
+	hookBeforeDelete: "WithBeforeDelete",
+	hookAfterDelete:  "WithAfterDelete",
+}
+
+// addHook 返回追加钩子的资源选项，同类钩子按注册顺序执行。
+func addHook(kind hookKind, fn any) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		if cfg.hooks == nil {
+			cfg.hooks = make(map[hookKind][]any)
+		}
+		cfg.hooks[kind] = append(cfg.hooks[kind], fn)
+	}
+}
+
+// WithBeforeCreate 在 Create 之前调用 fn，返回错误时中止请求并按 handleError 输出。
+//
+//	ginm.RegisterResource(g, res, ginm.WithBeforeCreate(func(c *gin.Context, in *CreateUserReq) error {
+//	    in.Email = strings.ToLower(in.Email)
+//	    return nil
+//	}))
+func WithBeforeCreate[CI any](fn func(c *gin.Context, input *CI) error) ResourceOption {
+	return addHook(hookBeforeCreate, fn)
+}
+
+// WithAfterCreate 在 Create 成功后调用 fn，适用于审计、缓存失效等。
+// 返回错误时按 handleError 输出，但已创建的元素不会回滚。
+func WithAfterCreate[CI any, T any](fn func(c *gin.Context, input *CI, item *T) error) ResourceOption {
+	return addHook(hookAfterCreate, fn)
+}
+
+// WithBeforeUpdate 在 Update 之前调用 fn，返回错误时中止请求。
+func WithBeforeUpdate[ID comparable, UI any](fn func(c *gin.Context, id ID, input *UI) error) ResourceOption {
+	return addHook(hookBeforeUpdate, fn)
+}
+
+// WithAfterUpdate 在 Update 成功后调用 fn，返回错误时按 handleError 输出，但更新不会回滚。
+func WithAfterUpdate[ID comparable, UI any, T any](fn func(c *gin.Context, id ID, input *UI, item *T) error) ResourceOption {
+	return addHook(hookAfterUpdate, fn)
+}
+
+// WithBeforeDelete 在 Delete 之前调用 fn，返回错误时中止请求。
+func WithBeforeDelete[ID comparable](fn func(c *gin.Context, id ID) error) ResourceOption {
+	return addHook(hookBeforeDelete, fn)
+}
+
+// WithAfterDelete 在 Delete 成功后调用 fn，返回错误时按 handleError 输出，但删除不会回滚。
+func WithAfterDelete[ID comparable](fn func(c *gin.Context, id ID) error) ResourceOption {
+	return addHook(hookAfterDelete, fn)
+}
+
+// resourceHooks 是按资源类型参数解析后的钩子。
+type resourceHooks[T any, ID comparable, CI any, UI any] struct {
+	beforeCreate []func(*gin.Context, *CI) error
+	afterCreate  []func(*gin.Context, *CI, *T) error
+	beforeUpdate []func(*gin.Context, ID, *UI) error
+	afterUpdate  []func(*gin.Context, ID, *UI, *T) error
+	beforeDelete []func(*gin.Context, ID) error
+	afterDelete  []func(*gin.Context, ID) error
+}
+
+// resolveHooks 将配置中的钩子转换为资源的具体类型，类型与资源不匹配时 panic，
+// 以便在注册时而非请求时发现错误。
+func resolveHooks[T any, ID comparable, CI any, UI any](cfg *ResourceConfig) resourceHooks[T, ID, CI, UI] {
+	var h resourceHooks[T, ID, CI, UI]
+	resolveHookList(cfg, hookBeforeCreate, &h.beforeCreate)
+	resolveHookList(cfg, hookAfterCreate, &h.afterCreate)
+	resolveHookList(cfg, hookBeforeUpdate, &h.beforeUpdate)
+	resolveHookList(cfg, hookAfterUpdate, &h.afterUpdate)
+	resolveHookList(cfg, hookBeforeDelete, &h.beforeDelete)
+	resolveHookList(cfg, hookAfterDelete, &h.afterDelete)
+	return h
+}
+
+func resolveHookList[F any](cfg *ResourceConfig, kind hookKind, dst *[]F) {
+	for _, hook := range cfg.hooks[kind] {
+		fn, ok := hook.(F)
+		if !ok {
+			panic(fmt.Sprintf("%s: hook type %s does not match resource, want %s",
+				hookNames[kind], reflect.TypeOf(hook), reflect.TypeFor[F]()))
+		}
+		*dst = append(*dst, fn)
+	}
+}
+
+// runHooks 依次执行钩子，遇到第一个错误时停止。
+func runHooks[F any](hooks []F, call func(F) error) error {
+	for _, fn := range hooks {
+		if err := call(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
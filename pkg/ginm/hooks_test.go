@@ -0,0 +1,123 @@
+package ginm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type hookPost struct {
+	Title string `json:"title"`
+	ID    int    `json:"id"`
+}
+
+type hookPostInput struct {
+	Title string `json:"title"`
+}
+
+// hookPostResource 是内存中的资源实现。
+type hookPostResource struct {
+	BaseResource[hookPost, int, hookPostInput, hookPostInput, PageQuery]
+	posts map[int]*hookPost
+}
+
+func (r *hookPostResource) Create(c *gin.Context, in *hookPostInput) (*hookPost, error) {
+	p := &hookPost{ID: len(r.posts) + 1, Title: in.Title}
+	r.posts[p.ID] = p
+	return p, nil
+}
+
+func (r *hookPostResource) Update(c *gin.Context, id int, in *hookPostInput) (*hookPost, error) {
+	p, ok := r.posts[id]
+	if !ok {
+		return nil, ErrNotFound("post not found")
+	}
+	p.Title = in.Title
+	return p, nil
+}
+
+func (r *hookPostResource) Delete(c *gin.Context, id int) error {
+	delete(r.posts, id)
+	return nil
+}
+
+func TestResourceHooks_RunAroundMutations(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: make(map[int]*hookPost)}
+	var log []string
+	RegisterResource(r.Group("/posts"), res,
+		WithBeforeCreate(func(c *gin.Context, in *hookPostInput) error {
+			in.Title = strings.ToUpper(in.Title)
+			log = append(log, "before create")
+			return nil
+		}),
+		WithAfterCreate(func(c *gin.Context, in *hookPostInput, p *hookPost) error {
+			log = append(log, fmt.Sprintf("after create %d %s", p.ID, p.Title))
+			return nil
+		}),
+		WithBeforeUpdate(func(c *gin.Context, id int, in *hookPostInput) error {
+			log = append(log, fmt.Sprintf("before update %d", id))
+			return nil
+		}),
+		WithAfterUpdate(func(c *gin.Context, id int, in *hookPostInput, p *hookPost) error {
+			log = append(log, fmt.Sprintf("after update %d %s", id, p.Title))
+			return nil
+		}),
+		WithBeforeDelete(func(c *gin.Context, id int) error {
+			log = append(log, fmt.Sprintf("before delete %d", id))
+			return nil
+		}),
+		WithAfterDelete(func(c *gin.Context, id int) error {
+			log = append(log, fmt.Sprintf("after delete %d", id))
+			return nil
+		}),
+	)
+
+	w := serve(r, http.MethodPost, "/posts", `{"title":"hello"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"HELLO"`)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPut, "/posts/1", `{"title":"edited"}`).Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodDelete, "/posts/1", "").Code)
+
+	assert.Equal(t, []string{
+		"before create", "after create 1 HELLO",
+		"before update 1", "after update 1 edited",
+		"before delete 1", "after delete 1",
+	}, log)
+}
+
+func TestResourceHooks_BeforeErrorAborts(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: map[int]*hookPost{1: {ID: 1, Title: "keep"}}}
+	var afterCalled bool
+	RegisterResource(r.Group("/posts"), res,
+		WithBeforeDelete(func(c *gin.Context, id int) error { return ErrForbidden("read only") }),
+		WithBeforeDelete(func(c *gin.Context, id int) error { afterCalled = true; return nil }),
+		WithAfterUpdate(func(c *gin.Context, id int, in *hookPostInput, p *hookPost) error {
+			return ErrConflict("cache busy")
+		}),
+	)
+
+	w := serve(r, http.MethodDelete, "/posts/1", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, res.posts, 1)
+	assert.False(t, afterCalled)
+
+	w = serve(r, http.MethodPut, "/posts/1", `{"title":"new"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "new", res.posts[1].Title, "after hooks do not roll back")
+}
+
+func TestResourceHooks_PanicsOnTypeMismatch(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: make(map[int]*hookPost)}
+	assert.PanicsWithValue(t,
+		"WithBeforeDelete: hook type func(*gin.Context, string) error does not match resource, want func(*gin.Context, int) error",
+		func() {
+			RegisterResource(r.Group("/posts"), res, WithBeforeDelete(func(c *gin.Context, id string) error { return nil }))
+		})
+}
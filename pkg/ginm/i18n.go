@@ -0,0 +1,158 @@
+package ginm
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Translations 是单个语言的消息表。
+// 键为内置消息键（如 "validation.required"、"error.internal"），或 APIError.Message 的原文。
+// 校验消息中的 %s 为标签参数。
+type Translations map[string]string
+
+// LocaleKey 用于显式指定当前请求的语言，优先于 Accept-Language。
+var LocaleKey = NewContextKey[string]("ginm:locale")
+
+var (
+	translations   = make(map[string]Translations)
+	translationsMu sync.RWMutex
+)
+
+// RegisterTranslations 注册语言的翻译，与已注册的同语言翻译合并。语言标签不区分大小写，通常在启动时调用：
+//
+//	ginm.RegisterTranslations("zh", ginm.ZHTranslations())
+//	ginm.RegisterTranslations("zh", ginm.Translations{"user not found": "用户不存在"})
+func RegisterTranslations(lang string, t Translations) {
+	lang = strings.ToLower(lang)
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	merged := make(Translations, len(translations[lang])+len(t))
+	for k, v := range translations[lang] {
+		merged[k] = v
+	}
+	for k, v := range t {
+		merged[k] = v
+	}
+	translations[lang] = merged
+}
+
+// ResetTranslations 移除所有已注册的翻译。
+func ResetTranslations() {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	clear(translations)
+}
+
+// Locale 返回当前请求使用的语言：LocaleKey 的值，或 Accept-Language 中优先级最高且已注册的语言
+// （zh-CN 未注册时回退到 zh）。没有匹配时返回空字符串，即使用内置英文消息。
+func Locale(c *gin.Context) string {
+	if lang, ok := Get(c, LocaleKey); ok && lang != "" {
+		return strings.ToLower(lang)
+	}
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	if len(translations) == 0 {
+		return ""
+	}
+	for _, tag := range parseAcceptLanguage(c.GetHeader("Accept-Language")) {
+		if _, ok := translations[tag]; ok {
+			return tag
+		}
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			if _, ok := translations[base]; ok {
+				return base
+			}
+		}
+	}
+	return ""
+}
+
+// Translate 返回 key 在当前请求语言下的翻译，没有翻译时返回 fallback。
+func Translate(c *gin.Context, key, fallback string) string {
+	lang := Locale(c)
+	if lang == "" {
+		return fallback
+	}
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	if msg, ok := translations[lang][key]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// parseAcceptLanguage 按 q 值降序返回小写的语言标签，忽略 * 和 q=0。
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	slices.SortStableFunc(tags, func(a, b weighted) int { return cmp.Compare(b.q, a.q) })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// ZHTranslations 返回内置消息的简体中文翻译。
+func ZHTranslations() Translations {
+	return Translations{
+		"error.validation_failed":  "参数校验失败",
+		"error.internal":           "服务器内部错误",
+		"validation.default":       "未通过 %s 校验",
+		"validation.required":      "不能为空",
+		"validation.email":         "必须是有效的邮箱地址",
+		"validation.url":           "必须是有效的 URL",
+		"validation.uri":           "必须是有效的 URI",
+		"validation.uuid":          "必须是有效的 UUID",
+		"validation.numeric":       "必须是数字",
+		"validation.alpha":         "只能包含字母",
+		"validation.alphanum":      "只能包含字母和数字",
+		"validation.oneof":         "必须是以下值之一: %s",
+		"validation.eqfield":       "必须与 %s 相同",
+		"validation.min":           "不能小于 %s",
+		"validation.min.string":    "长度不能少于 %s 个字符",
+		"validation.min.items":     "至少包含 %s 项",
+		"validation.max":           "不能大于 %s",
+		"validation.max.string":    "长度不能超过 %s 个字符",
+		"validation.max.items":     "最多包含 %s 项",
+		"validation.len":           "必须等于 %s",
+		"validation.len.string":    "长度必须为 %s 个字符",
+		"validation.len.items":     "必须包含 %s 项",
+		"validation.gt":            "必须大于 %s",
+		"validation.gte":           "必须大于或等于 %s",
+		"validation.lt":            "必须小于 %s",
+		"validation.lte":           "必须小于或等于 %s",
+		"validation.datetime":      "必须符合格式 %s",
+		"validation.ip":            "必须是有效的 IP 地址",
+		"validation.unique":        "不能包含重复值",
+		"validation.required_if":   "不能为空",
+		"validation.required_with": "不能为空",
+	}
+}
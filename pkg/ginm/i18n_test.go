@@ -0,0 +1,81 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerZH(t *testing.T) {
+	t.Helper()
+	RegisterTranslations("zh", ZHTranslations())
+	RegisterTranslations("zh", Translations{"user not found": "用户不存在"})
+	t.Cleanup(ResetTranslations)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"zh-cn", "en", "zh"},
+		parseAcceptLanguage("en;q=0.8, zh-CN, *;q=0.5, zh;q=0.3, fr;q=0"))
+	assert.Empty(t, parseAcceptLanguage(""))
+}
+
+func TestLocale(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	c.Request.Header.Set("Accept-Language", "zh-CN,en;q=0.9")
+	assert.Equal(t, "", Locale(c), "no translations registered")
+
+	registerZH(t)
+	assert.Equal(t, "zh", Locale(c), "falls back to base language")
+
+	c.Request.Header.Set("Accept-Language", "fr, de")
+	assert.Equal(t, "", Locale(c))
+
+	Set(c, LocaleKey, "ZH")
+	assert.Equal(t, "zh", Locale(c), "context locale wins")
+}
+
+func TestTranslate(t *testing.T) {
+	registerZH(t)
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	Set(c, LocaleKey, "zh")
+	assert.Equal(t, "用户不存在", Translate(c, "user not found", "user not found"))
+	assert.Equal(t, "fallback", Translate(c, "missing", "fallback"))
+
+	RegisterTranslations("zh", Translations{"extra": "额外"})
+	assert.Equal(t, "用户不存在", Translate(c, "user not found", ""), "registrations merge")
+	assert.Equal(t, "额外", Translate(c, "extra", ""))
+}
+
+func TestHandleError_LocalizedMessages(t *testing.T) {
+	registerZH(t)
+	r := newTestEngine()
+	r.GET("/missing", Wrap(failWith(NewAPIError(http.StatusNotFound, 40401, "user not found"))))
+	r.GET("/boom", Wrap(failWith(errors.New("boom"))))
+
+	w := serve(r, http.MethodGet, "/missing", "", "Accept-Language", "zh-CN")
+	assert.Contains(t, w.Body.String(), `"message":"用户不存在"`)
+	w = serve(r, http.MethodGet, "/missing", "", "Accept-Language", "en-US")
+	assert.Contains(t, w.Body.String(), `"message":"user not found"`)
+
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(gin.TestMode)
+	w = serve(r, http.MethodGet, "/boom", "", "Accept-Language", "zh")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "服务器内部错误")
+}
+
+func TestBindJSON_LocalizedValidation(t *testing.T) {
+	registerZH(t)
+	r := newTestEngine()
+	r.POST("/", Wrap(func(c *gin.Context, req *validationReq) (string, error) { return "ok", nil }))
+
+	w := serve(r, http.MethodPost, "/", `{"address":{},"name":"a","age":18}`, "Accept-Language", "zh-CN,en;q=0.5")
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "参数校验失败")
+	assert.Contains(t, w.Body.String(), `{"field":"address.city","message":"不能为空"}`)
+	assert.Contains(t, w.Body.String(), `{"field":"name","message":"长度不能少于 2 个字符"}`)
+}
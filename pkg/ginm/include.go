@@ -0,0 +1,102 @@
+package ginm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Includes 是请求的关联展开集合（?include=posts,comments），R 通常为应用定义的字符串类型：
+//
+//	type UserInclude string
+//
+//	const (
+//	    IncludePosts    UserInclude = "posts"
+//	    IncludeComments UserInclude = "comments"
+//	)
+//
+// 零值为空集合。
+type Includes[R ~string] struct {
+	names []R
+}
+
+// NewIncludes 创建包含 names 的集合，重复项被忽略。
+func NewIncludes[R ~string](names ...R) Includes[R] {
+	var s Includes[R]
+	for _, name := range names {
+		if !s.Has(name) {
+			s.names = append(s.names, name)
+		}
+	}
+	return s
+}
+
+// Has 返回是否请求了 name。
+func (s Includes[R]) Has(name R) bool {
+	return slices.Contains(s.names, name)
+}
+
+// Names 按请求顺序返回所有展开项。
+func (s Includes[R]) Names() []R {
+	return slices.Clone(s.names)
+}
+
+// Len 返回展开项数量。
+func (s Includes[R]) Len() int {
+	return len(s.names)
+}
+
+// includesKey 用于存储 BindIncludes 的结果，值为 Includes[R]。
+var includesKey = NewContextKey[any]("ginm:includes")
+
+// BindIncludes 解析 include 查询参数（逗号分隔，可重复出现），并存入上下文供 GetIncludes 读取。
+// 不在 allowed 中的值返回 query 来源的 *BindError（包装 *ValidationErrors，以 422 输出）。
+func BindIncludes[R ~string](c *gin.Context, allowed ...R) (Includes[R], error) {
+	var names []R
+	v := &ValidationErrors{}
+	for _, param := range c.QueryArray("include") {
+		for part := range strings.SplitSeq(param, ",") {
+			name := R(strings.TrimSpace(part))
+			if name == "" {
+				continue
+			}
+			if !slices.Contains(allowed, name) {
+				v.Add("include", fmt.Sprintf("unsupported include: %s", name))
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	if v.HasErrors() {
+		return Includes[R]{}, NewBindError("query", v)
+	}
+
+	s := NewIncludes(names...)
+	Set(c, includesKey, any(s))
+	return s, nil
+}
+
+// GetIncludes 返回当前请求中由 BindIncludes 或 WithIncludes 解析的展开项，未解析时返回空集合。
+// 通常在资源的 List 和 Get 实现中调用：
+//
+//	func (r *UserResource) Get(c *gin.Context, id int64) (*User, error) {
+//	    if ginm.GetIncludes[UserInclude](c).Has(IncludePosts) { ... }
+//	}
+func GetIncludes[R ~string](c *gin.Context) Includes[R] {
+	v, _ := Get(c, includesKey)
+	s, _ := v.(Includes[R])
+	return s
+}
+
+// WithIncludes 使资源的 List 和 Get 路由在调用资源方法前解析 include 参数，
+// 值不在 allowed 中时返回 422，资源方法内通过 GetIncludes 读取。
+func WithIncludes[R ~string](allowed ...R) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		cfg.bindIncludes = func(c *gin.Context) error {
+			_, err := BindIncludes(c, allowed...)
+			return err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postInclude string
+
+const (
+	includeAuthor   postInclude = "author"
+	includeComments postInclude = "comments"
+)
+
+func TestBindIncludes(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/posts?include=author,+comments&include=author", nil, "")
+	s, err := BindIncludes(c, includeAuthor, includeComments)
+	require.NoError(t, err)
+	assert.Equal(t, []postInclude{includeAuthor, includeComments}, s.Names())
+	assert.True(t, s.Has(includeComments))
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, s, GetIncludes[postInclude](c))
+}
+
+func TestBindIncludes_RejectsUnknown(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/posts?include=author,secrets", nil, "")
+	_, err := BindIncludes(c, includeAuthor)
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	assert.Equal(t, []ValidationError{{Field: "include", Message: "unsupported include: secrets"}}, v.Errors)
+	assert.Equal(t, 0, GetIncludes[postInclude](c).Len())
+}
+
+func TestGetIncludes_EmptyWhenUnbound(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/posts", nil, "")
+	assert.False(t, GetIncludes[postInclude](c).Has(includeAuthor))
+	assert.Equal(t, 0, GetIncludes[string](c).Len())
+}
+
+type includePostResource struct {
+	BaseResource[openAPIPost, int, openAPIPost, openAPIPost, PageQuery]
+}
+
+func (r *includePostResource) Get(c *gin.Context, id int) (*openAPIPost, error) {
+	title := "post"
+	if GetIncludes[postInclude](c).Has(includeAuthor) {
+		title += "+author"
+	}
+	return &openAPIPost{Title: title}, nil
+}
+
+func (r *includePostResource) List(c *gin.Context, q *PageQuery) (PageResponse[openAPIPost], error) {
+	items := []openAPIPost{{Title: "n=" + string(rune('0'+GetIncludes[postInclude](c).Len()))}}
+	return NewPaginatorFromQuery[openAPIPost](q).Paginate(items, 1), nil
+}
+
+func TestWithIncludes_BindsForListAndGet(t *testing.T) {
+	r := newTestEngine()
+	RegisterResourceReadOnly(r.Group("/posts"), &includePostResource{}, WithIncludes(includeAuthor, includeComments))
+
+	w := serve(r, http.MethodGet, "/posts/1?include=author", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"post+author"`)
+
+	w = serve(r, http.MethodGet, "/posts?include=author,comments", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"n=2"`)
+
+	w = serve(r, http.MethodGet, "/posts?include=likes", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "unsupported include: likes")
+}
@@ -0,0 +1,389 @@
+package ginm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// MIMEJSONPatch 是 RFC 6902 JSON Patch 文档的 Content-Type。
+const MIMEJSONPatch = "application/json-patch+json"
+
+// PatchOp 是 JSON Patch 操作类型。
+type PatchOp string
+
+// JSON Patch 操作，见 RFC 6902 第 4 节。
+const (
+	PatchAdd     PatchOp = "add"
+	PatchRemove  PatchOp = "remove"
+	PatchReplace PatchOp = "replace"
+	PatchMove    PatchOp = "move"
+	PatchCopy    PatchOp = "copy"
+	PatchTest    PatchOp = "test"
+)
+
+var (
+	// ErrPatchTestFailed 表示 test 操作的值与目标不相等，ApplyPatch 以 409 返回。
+	ErrPatchTestFailed = errors.New("json patch test failed")
+	// ErrPatchPath 表示操作路径在目标文档中不存在或不可用，ApplyPatch 以 422 返回。
+	ErrPatchPath = errors.New("json patch path error")
+)
+
+// PatchOperation 是 JSON Patch 文档中的单个操作。
+// Value 保留原始 JSON，以区分未提供 value 和 value 为 null。
+type PatchOperation struct {
+	Op    PatchOp         `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch 是 RFC 6902 JSON Patch 文档，操作按顺序执行。
+type JSONPatch []PatchOperation
+
+// BindJSONPatch 绑定 JSON Patch 请求体并检查每个操作的结构：op 合法、path 和 from 是合法的 JSON Pointer、
+// 需要 value 的操作提供了 value。结构错误按验证错误（422）返回，字段名形如 "[1].path"。
+//
+//	r.PATCH("/users/:id", func(c *gin.Context) {
+//	    patch, err := ginm.BindJSONPatch(c)
+//	    ...
+//	    user, err := ginm.ApplyPatch(current, patch).GetWithError()
+//	})
+func BindJSONPatch(c *gin.Context) (JSONPatch, error) {
+	var patch JSONPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		return nil, NewBindError("json", err)
+	}
+	if patch == nil {
+		return nil, NewBindError("json", errors.New("json patch must be an array"))
+	}
+	if v := patch.validate(c); v.HasErrors() {
+		return nil, NewBindError("json", v)
+	}
+	return patch, nil
+}
+
+// validate 检查各操作的结构，不访问目标文档。
+func (p JSONPatch) validate(c *gin.Context) *ValidationErrors {
+	v := &ValidationErrors{}
+	for i, op := range p {
+		field := func(name string) string { return fmt.Sprintf("[%d].%s", i, name) }
+		switch op.Op {
+		case PatchAdd, PatchReplace, PatchTest:
+			if op.Value == nil {
+				v.Add(field("value"), Translate(c, "validation.required", "is required"))
+			}
+		case PatchMove, PatchCopy:
+			if _, err := parsePointer(op.From); err != nil {
+				v.Add(field("from"), err.Error())
+			}
+		case PatchRemove:
+		default:
+			v.Add(field("op"), fmt.Sprintf("must be one of add remove replace move copy test, got %q", op.Op))
+			continue
+		}
+		if _, err := parsePointer(op.Path); err != nil {
+			v.Add(field("path"), err.Error())
+		}
+	}
+	return v
+}
+
+// ApplyPatch 将 JSON Patch 应用到 target 的 JSON 表示，并将结果解码为新的 T，target 本身不会被修改。
+// 任一操作失败时整个补丁不生效：test 不通过返回 409（包装 ErrPatchTestFailed），
+// 路径不存在或结果无法解码为 T 时返回 422（路径错误包装 ErrPatchPath）。
+func ApplyPatch[T any](target T, patch JSONPatch) gox.Result[T] {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return gox.RErr[T](err)
+	}
+	doc, err := decodePatchValue(data)
+	if err != nil {
+		return gox.RErr[T](err)
+	}
+	for i, op := range patch {
+		if doc, err = applyPatchOp(doc, op); err != nil {
+			if errors.Is(err, ErrPatchTestFailed) {
+				return gox.RErr[T](WrapAPIError(http.StatusConflict, http.StatusConflict,
+					fmt.Sprintf("json patch operation %d: test failed at %q", i, op.Path), err))
+			}
+			return gox.RErr[T](WrapAPIError(http.StatusUnprocessableEntity, http.StatusUnprocessableEntity,
+				fmt.Sprintf("json patch operation %d: %v", i, err), err))
+		}
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return gox.RErr[T](err)
+	}
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return gox.RErr[T](WrapAPIError(http.StatusUnprocessableEntity, http.StatusUnprocessableEntity,
+			"json patch result is invalid", err))
+	}
+	return gox.ROk(result)
+}
+
+// applyPatchOp 执行单个操作，返回新的文档。
+func applyPatchOp(doc any, op PatchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		value, err := decodePatchValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case PatchAdd:
+			return patchAdd(doc, path, value)
+		case PatchReplace:
+			return patchReplace(doc, path, value)
+		}
+		current, err := patchGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !patchValuesEqual(current, value) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+	case PatchRemove:
+		doc, _, err := patchRemove(doc, path)
+		return doc, err
+	case PatchMove, PatchCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == PatchCopy {
+			value, err := patchGet(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return patchAdd(doc, path, deepCopyPatchValue(value))
+		}
+		if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+			return nil, fmt.Errorf("%w: cannot move %q into its own child %q", ErrPatchPath, op.From, op.Path)
+		}
+		doc, value, err := patchRemove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(doc, path, value)
+	}
+	return nil, fmt.Errorf("unsupported json patch op %q", op.Op)
+}
+
+// parsePointer 将 RFC 6901 JSON Pointer 解析为引用标记，空字符串表示整个文档。
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("must be a JSON pointer starting with '/', got %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// decodePatchValue 解码 JSON 值，数字保留为 json.Number 以免精度丢失。
+func decodePatchValue(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// patchIndex 解析数组下标，size 为允许的最大值（含）。
+func patchIndex(token string, size int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > size || (len(token) > 1 && token[0] == '0') || token[0] == '+' {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrPatchPath, token)
+	}
+	return i, nil
+}
+
+// patchGet 返回 path 引用的值。
+func patchGet(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q not found", ErrPatchPath, token)
+			}
+			doc = v
+		case []any:
+			i, err := patchIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: cannot traverse %q", ErrPatchPath, token)
+		}
+	}
+	return doc, nil
+}
+
+// patchUpdate 定位 path 的父容器并用 fn 的返回值替换它，path 不能为空。
+func patchUpdate(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	child, err := patchGet(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = patchUpdate(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		node[path[0]] = child
+	case []any:
+		i, _ := patchIndex(path[0], len(node)-1)
+		node[i] = child
+	}
+	return doc, nil
+}
+
+func patchAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return patchUpdate(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			i, err := patchIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(node, i, value), nil
+		}
+		return nil, fmt.Errorf("%w: cannot add %q to a scalar value", ErrPatchPath, token)
+	})
+}
+
+func patchReplace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	if _, err := patchGet(doc, path); err != nil {
+		return nil, err
+	}
+	return patchUpdate(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i, _ := patchIndex(token, len(node)-1)
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%w: cannot replace %q", ErrPatchPath, token)
+	})
+}
+
+// patchRemove 删除 path 引用的值，同时返回被删除的值。
+func patchRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrPatchPath)
+	}
+	removed, err := patchGet(doc, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err = patchUpdate(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, _ := patchIndex(token, len(node)-1)
+			return slices.Delete(node, i, i+1), nil
+		}
+		return nil, fmt.Errorf("%w: cannot remove %q", ErrPatchPath, token)
+	})
+	return doc, removed, err
+}
+
+// deepCopyPatchValue 复制解码后的 JSON 值，避免 copy 操作产生共享的容器。
+func deepCopyPatchValue(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(node))
+		for k, child := range node {
+			m[k] = deepCopyPatchValue(child)
+		}
+		return m
+	case []any:
+		s := make([]any, len(node))
+		for i, child := range node {
+			s[i] = deepCopyPatchValue(child)
+		}
+		return s
+	}
+	return v
+}
+
+// patchValuesEqual 按 RFC 6902 第 4.6 节比较两个 JSON 值，数字按数值比较。
+func patchValuesEqual(a, b any) bool {
+	if na, ok := a.(json.Number); ok {
+		nb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if na == nb {
+			return true
+		}
+		fa, errA := na.Float64()
+		fb, errB := nb.Float64()
+		return errA == nil && errB == nil && fa == fb
+	}
+	switch va := a.(type) {
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for k, child := range va {
+			other, ok := vb[k]
+			if !ok || !patchValuesEqual(child, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		vb, ok := b.([]any)
+		return ok && slices.EqualFunc(va, vb, patchValuesEqual)
+	}
+	return reflect.DeepEqual(a, b)
+}
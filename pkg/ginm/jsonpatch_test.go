@@ -0,0 +1,98 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patchDoc struct {
+	Meta  map[string]any `json:"meta,omitempty"`
+	Title string         `json:"title"`
+	Tags  []string       `json:"tags"`
+	Views int            `json:"views"`
+}
+
+func TestApplyPatch_Operations(t *testing.T) {
+	doc := patchDoc{Title: "a", Tags: []string{"x", "y"}, Views: 1, Meta: map[string]any{"a/b": "1", "k~": "2"}}
+	patch := JSONPatch{
+		{Op: PatchTest, Path: "/views", Value: []byte(`1.0`)},
+		{Op: PatchReplace, Path: "/title", Value: []byte(`"b"`)},
+		{Op: PatchAdd, Path: "/tags/1", Value: []byte(`"new"`)},
+		{Op: PatchAdd, Path: "/tags/-", Value: []byte(`"last"`)},
+		{Op: PatchRemove, Path: "/tags/0"},
+		{Op: PatchMove, From: "/meta/a~1b", Path: "/meta/moved"},
+		{Op: PatchCopy, From: "/meta/k~0", Path: "/meta/copied"},
+	}
+
+	got, err := ApplyPatch(doc, patch).GetWithError()
+	require.NoError(t, err)
+	assert.Equal(t, patchDoc{
+		Title: "b",
+		Tags:  []string{"new", "y", "last"},
+		Views: 1,
+		Meta:  map[string]any{"moved": "1", "k~": "2", "copied": "2"},
+	}, got)
+	assert.Equal(t, []string{"x", "y"}, doc.Tags, "target is not modified")
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	doc := patchDoc{Title: "a", Tags: []string{"x"}}
+	cases := []struct {
+		op     PatchOperation
+		status int
+		target error
+	}{
+		{PatchOperation{Op: PatchTest, Path: "/title", Value: []byte(`"b"`)}, http.StatusConflict, ErrPatchTestFailed},
+		{PatchOperation{Op: PatchRemove, Path: "/missing"}, http.StatusUnprocessableEntity, ErrPatchPath},
+		{PatchOperation{Op: PatchReplace, Path: "/tags/1", Value: []byte(`"z"`)}, http.StatusUnprocessableEntity, ErrPatchPath},
+		{PatchOperation{Op: PatchAdd, Path: "/tags/01", Value: []byte(`"z"`)}, http.StatusUnprocessableEntity, ErrPatchPath},
+		{PatchOperation{Op: PatchMove, From: "/meta", Path: "/meta/x"}, http.StatusUnprocessableEntity, ErrPatchPath},
+		{PatchOperation{Op: PatchReplace, Path: "/views", Value: []byte(`"many"`)}, http.StatusUnprocessableEntity, nil},
+	}
+	for _, tc := range cases {
+		err := ApplyPatch(doc, JSONPatch{tc.op}).UnwrapErr()
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr), "%+v", tc.op)
+		assert.Equal(t, tc.status, apiErr.HTTPStatus, "%+v", tc.op)
+		if tc.target != nil {
+			assert.ErrorIs(t, err, tc.target)
+		}
+	}
+}
+
+func TestBindJSONPatch(t *testing.T) {
+	r := newTestEngine()
+	r.PATCH("/posts/1", func(c *gin.Context) {
+		patch, err := BindJSONPatch(c)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		post, err := ApplyPatch(patchDoc{Title: "a"}, patch).GetWithError()
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		Success(c, post)
+	})
+
+	w := serve(r, http.MethodPatch, "/posts/1", `[{"op":"replace","path":"/title","value":"b"},{"op":"add","path":"/views","value":null}]`, "Content-Type", MIMEJSONPatch)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"title":"b"`)
+
+	w = serve(r, http.MethodPatch, "/posts/1", `[{"op":"add","path":"/title"},{"op":"copy","path":"x","from":"/a"},{"op":"drop","path":"/a"}]`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `{"field":"[0].value","message":"is required"}`)
+	assert.Contains(t, w.Body.String(), `{"field":"[1].path","message":"must be a JSON pointer starting with '/', got \"x\""}`)
+	assert.Contains(t, w.Body.String(), `{"field":"[2].op","message":"must be one of add remove replace move copy test, got \"drop\""}`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPatch, "/posts/1", `{"op":"add"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPatch, "/posts/1", `null`).Code)
+	assert.Equal(t, http.StatusConflict,
+		serve(r, http.MethodPatch, "/posts/1", `[{"op":"test","path":"/title","value":"z"}]`).Code)
+}
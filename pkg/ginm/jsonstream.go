@@ -0,0 +1,319 @@
+package ginm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// streamBufferSize 是流式编码的写缓冲大小。
+const streamBufferSize = 32 * 1024
+
+// itemsStreamer 由可流式编码 Items 的响应类型实现。
+type itemsStreamer interface {
+	streamItems(enc *streamEncoder) error
+}
+
+// streamEncoder 将 JSON 逐元素写入底层 Writer，内存占用与单个元素大小相关。
+type streamEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+	buf bytes.Buffer
+}
+
+var streamEncoders = gox.NewPool(
+	func() *streamEncoder {
+		e := &streamEncoder{w: bufio.NewWriterSize(nil, streamBufferSize)}
+		e.enc = json.NewEncoder(&e.buf)
+		return e
+	},
+	func(e *streamEncoder) {
+		e.w.Reset(nil)
+		resetPooledBuffer(&e.buf)
+	},
+)
+
+// newStreamEncoder 从对象池取出编码器，使用完毕后须调用 release 归还。
+func newStreamEncoder(w io.Writer) *streamEncoder {
+	e := streamEncoders.Get()
+	e.w.Reset(w)
+	return e
+}
+
+// release 将编码器归还到对象池。
+func (e *streamEncoder) release() {
+	streamEncoders.Put(e)
+}
+
+// raw 写入原始 JSON 片段。
+func (e *streamEncoder) raw(s string) error {
+	_, err := e.w.WriteString(s)
+	return err
+}
+
+// field 写入对象字段名（含前导逗号）。
+func (e *streamEncoder) field(name string, first bool) error {
+	if !first {
+		if err := e.raw(","); err != nil {
+			return err
+		}
+	}
+	return e.raw(strconv.Quote(name) + ":")
+}
+
+// value 编码单个值。切片和 itemsStreamer 会逐元素编码。
+func (e *streamEncoder) value(v any) error {
+	if s, ok := v.(itemsStreamer); ok {
+		return s.streamItems(e)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		if rv.IsNil() {
+			return e.raw("null")
+		}
+		return e.slice(rv)
+	}
+	return e.encode(v)
+}
+
+// slice 逐元素编码切片。
+func (e *streamEncoder) slice(rv reflect.Value) error {
+	if err := e.raw("["); err != nil {
+		return err
+	}
+	for i := range rv.Len() {
+		if i > 0 {
+			if err := e.raw(","); err != nil {
+				return err
+			}
+		}
+		if err := e.encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return e.raw("]")
+}
+
+// encode 编码单个值，复用内部缓冲并去掉 json.Encoder 追加的换行符。
+func (e *streamEncoder) encode(v any) error {
+	e.buf.Reset()
+	if err := e.enc.Encode(v); err != nil {
+		return err
+	}
+	b := e.buf.Bytes()
+	_, err := e.w.Write(b[:len(b)-1])
+	return err
+}
+
+// streamItems 流式编码 PageResponse。
+func (p PageResponse[T]) streamItems(e *streamEncoder) error {
+	return e.structFields(reflect.ValueOf(p))
+}
+
+// streamItems 流式编码 ListResponse。
+func (l ListResponse[T]) streamItems(e *streamEncoder) error {
+	return e.structFields(reflect.ValueOf(l))
+}
+
+// streamItems 流式编码 CursorResponse。
+func (p CursorResponse[T]) streamItems(e *streamEncoder) error {
+	return e.structFields(reflect.ValueOf(p))
+}
+
+// structFields 按 json 标签逐字段编码结构体，切片字段逐元素编码。
+// 字段名、"-" 和 omitempty 的处理与 encoding/json 一致；不支持嵌入字段，分页响应类型中也没有。
+func (e *streamEncoder) structFields(rv reflect.Value) error {
+	if err := e.raw("{"); err != nil {
+		return err
+	}
+	first := true
+	t := rv.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fv := rv.Field(i)
+		if slices.Contains(strings.Split(opts, ","), "omitempty") && isEmptyJSONValue(fv) {
+			continue
+		}
+		if err := e.field(name, first); err != nil {
+			return err
+		}
+		if err := e.value(fv.Interface()); err != nil {
+			return err
+		}
+		first = false
+	}
+	return e.raw("}")
+}
+
+// isEmptyJSONValue 与 encoding/json 的 omitempty 判定一致。
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return v.IsZero()
+	}
+	return false
+}
+
+// maxPooledBufferSize 是归还到对象池的缓冲上限，超过时丢弃以免长期占用内存。
+const maxPooledBufferSize = 64 * 1024
+
+// resetPooledBuffer 清空缓冲，容量过大时释放底层数组。
+func resetPooledBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBufferSize {
+		*b = bytes.Buffer{}
+		return
+	}
+	b.Reset()
+}
+
+// jsonBuffer 是非流式 JSON 响应的可复用编码缓冲。
+type jsonBuffer struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var jsonBuffers = gox.NewPool(
+	func() *jsonBuffer {
+		b := &jsonBuffer{}
+		b.enc = json.NewEncoder(&b.buf)
+		return b
+	},
+	func(b *jsonBuffer) { resetPooledBuffer(&b.buf) },
+)
+
+var jsonContentType = []string{"application/json; charset=utf-8"}
+
+// bufferedJSON 使用池化缓冲编码 Response 信封后一次性写出，行为与 c.JSON 一致。
+func bufferedJSON[T any](c *gin.Context, status int, resp Response[T]) {
+	c.Status(status)
+	header := c.Writer.Header()
+	if len(header["Content-Type"]) == 0 {
+		header["Content-Type"] = jsonContentType
+	}
+	if !bodyAllowedForStatus(status) {
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	b := jsonBuffers.Get()
+	defer jsonBuffers.Put(b)
+	if err := b.enc.Encode(resp.envelope()); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	// 去掉 json.Encoder 追加的换行符
+	out := b.buf.Bytes()
+	if _, err := c.Writer.Write(out[:len(out)-1]); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// bodyAllowedForStatus 与 gin 的判定一致：1xx、204、304 不允许响应体。
+func bodyAllowedForStatus(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// streamJSON 将 Response 信封流式写入 ResponseWriter。
+// 状态码和响应头在编码前发送，编码中途失败时通过 c.Error 记录错误。
+func streamJSON[T any](c *gin.Context, status int, resp Response[T]) {
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(status)
+	if !bodyAllowedForStatus(status) {
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	e := newStreamEncoder(c.Writer)
+	defer e.release()
+	if err := writeEnvelope(e, resp); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := e.w.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// writeEnvelope 按 Response 的字段顺序写入信封。
+func writeEnvelope[T any](e *streamEncoder, resp Response[T]) error {
+	if err := e.raw("{"); err != nil {
+		return err
+	}
+	first := true
+	if data, ok := resp.envelopeData(); ok {
+		if err := e.field("data", true); err != nil {
+			return err
+		}
+		if err := e.value(data); err != nil {
+			return err
+		}
+		first = false
+	}
+	if resp.Message != "" {
+		if err := e.field("message", first); err != nil {
+			return err
+		}
+		if err := e.encode(resp.Message); err != nil {
+			return err
+		}
+		first = false
+	}
+	if resp.Error != "" {
+		if err := e.field("error", first); err != nil {
+			return err
+		}
+		if err := e.encode(resp.Error); err != nil {
+			return err
+		}
+		first = false
+	}
+	if err := e.field("code", first); err != nil {
+		return err
+	}
+	if err := e.raw(strconv.Itoa(resp.Code)); err != nil {
+		return err
+	}
+	if len(resp.Links) > 0 {
+		if err := e.field("links", false); err != nil {
+			return err
+		}
+		if err := e.encode(resp.Links); err != nil {
+			return err
+		}
+	}
+	return e.raw("}")
+}
@@ -0,0 +1,182 @@
+package ginm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamItem struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+func renderWith[T any](opts EnvelopeOptions, status int, resp Response[T]) *httptest.ResponseRecorder {
+	SetEnvelopeOptions(opts)
+	defer SetEnvelopeOptions(EnvelopeOptions{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	JSON(c, status, resp)
+	return w
+}
+
+func TestJSON_StreamMatchesBufferedOutput(t *testing.T) {
+	items := []streamItem{{ID: 1, Name: "a<b>"}, {ID: 2, Name: "c"}}
+	cases := []struct {
+		name string
+		resp Response[any]
+	}{
+		{"slice", OK[any](items)},
+		{"page", OK[any](NewPageResponse(items, 10, 1, 2))},
+		{"list", OK[any](NewListResponse(items))},
+		{"cursor", OK[any](CursorResponse[streamItem]{Items: items, NextCursor: "n", HasMore: true})},
+		{"cursor prev", OK[any](CursorResponse[streamItem]{Items: items, PrevCursor: "p"})},
+		{"page links", OK[any](PageResponse[streamItem]{Items: items, Total: 10, Page: 2, Links: Links{LinkSelf: "/items?page=2"}})},
+		{"page nil items", OK[any](PageResponse[streamItem]{Total: 3})},
+		{"page pointer", OK[any](&PageResponse[streamItem]{Items: items, Total: 2})},
+		{"list nil items", OK[any](ListResponse[streamItem]{})},
+		{"struct", OK[any](items[0])},
+		{"empty", Fail[any](400, "bad request")},
+		{"error", FailWithError[any](500, "failed", "boom")},
+		{"message", OKWithMessage[any]("done", 1)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffered := renderWith(EnvelopeOptions{}, http.StatusOK, tc.resp)
+			streamed := renderWith(EnvelopeOptions{Stream: true}, http.StatusOK, tc.resp)
+
+			assert.Equal(t, http.StatusOK, streamed.Code)
+			assert.Equal(t, "application/json; charset=utf-8", streamed.Header().Get("Content-Type"))
+			assert.JSONEq(t, buffered.Body.String(), streamed.Body.String())
+		})
+	}
+}
+
+func TestJSON_StreamOmitsBodyForNoContent(t *testing.T) {
+	for _, status := range []int{http.StatusNoContent, http.StatusNotModified} {
+		w := renderWith(EnvelopeOptions{Stream: true}, status, OK(NewListResponse([]int{1})))
+		assert.Equal(t, status, w.Code)
+		assert.Empty(t, w.Body.String())
+	}
+}
+
+func TestJSON_StreamHonorsEmptyDataOptions(t *testing.T) {
+	w := renderWith(EnvelopeOptions{Stream: true, SliceAsArray: true}, http.StatusCreated, OK[[]int](nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":[]}`, w.Body.String())
+}
+
+func TestWrap_UsesStreamingWhenEnabled(t *testing.T) {
+	SetEnvelopeOptions(EnvelopeOptions{Stream: true})
+	defer SetEnvelopeOptions(EnvelopeOptions{})
+
+	r := newTestEngine()
+	r.GET("/items", WrapNoReq(func(c *gin.Context) ([]streamItem, error) {
+		return []streamItem{{ID: 1, Name: "a"}}, nil
+	}))
+	w := serve(r, http.MethodGet, "/items", "")
+
+	var resp Response[[]streamItem]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []streamItem{{ID: 1, Name: "a"}}, resp.Data)
+}
+
+func TestJSON_PooledBufferMatchesGinJSON(t *testing.T) {
+	resp := OK([]streamItem{{ID: 1, Name: "a<b>"}})
+
+	want := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(want)
+	c.JSON(http.StatusOK, resp)
+
+	// 连续渲染两次，确认复用的缓冲不会残留上一次的内容
+	for range 2 {
+		got := renderWith(EnvelopeOptions{}, http.StatusOK, resp)
+		assert.Equal(t, want.Body.String(), got.Body.String())
+		assert.Equal(t, want.Header().Get("Content-Type"), got.Header().Get("Content-Type"))
+	}
+}
+
+func TestJSON_PooledBufferOmitsBodyForNoContent(t *testing.T) {
+	w := renderWith(EnvelopeOptions{}, http.StatusNoContent, OK("ignored"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestJSON_PooledBufferRecordsEncodeError(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	JSON(c, http.StatusOK, OK(func() {}))
+
+	assert.Len(t, c.Errors, 1)
+	assert.True(t, c.IsAborted())
+	assert.Empty(t, w.Body.String())
+}
+
+func benchmarkItems(n int) []streamItem {
+	items := make([]streamItem, n)
+	for i := range items {
+		items[i] = streamItem{ID: i, Name: fmt.Sprintf("item-%d-with-some-padding-to-grow-the-payload", i)}
+	}
+	return items
+}
+
+// discardResponseWriter 丢弃写入的数据，使基准测试只统计编码本身的内存。
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+func benchmarkJSON(b *testing.B, opts EnvelopeOptions) {
+	SetEnvelopeOptions(opts)
+	defer SetEnvelopeOptions(EnvelopeOptions{})
+
+	resp := OK(NewListResponse(benchmarkItems(50000)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		c, _ := gin.CreateTestContext(&discardResponseWriter{header: make(http.Header)})
+		JSON(c, http.StatusOK, resp)
+	}
+}
+
+func BenchmarkJSON_Buffered(b *testing.B) {
+	benchmarkJSON(b, EnvelopeOptions{})
+}
+
+func BenchmarkJSON_Stream(b *testing.B) {
+	benchmarkJSON(b, EnvelopeOptions{Stream: true})
+}
+
+func benchmarkSmallJSON(b *testing.B, render func(c *gin.Context, resp Response[streamItem])) {
+	resp := OK(streamItem{ID: 1, Name: "item"})
+	w := &discardResponseWriter{header: make(http.Header)}
+	c, _ := gin.CreateTestContext(w)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		render(c, resp)
+	}
+}
+
+// BenchmarkJSON_SmallPooled 与 BenchmarkJSON_SmallGin 对比高 QPS 小响应下的分配。
+func BenchmarkJSON_SmallPooled(b *testing.B) {
+	benchmarkSmallJSON(b, func(c *gin.Context, resp Response[streamItem]) {
+		JSON(c, http.StatusOK, resp)
+	})
+}
+
+func BenchmarkJSON_SmallGin(b *testing.B) {
+	benchmarkSmallJSON(b, func(c *gin.Context, resp Response[streamItem]) {
+		c.JSON(http.StatusOK, resp)
+	})
+}
@@ -0,0 +1,191 @@
+package ginm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SortField 是单个排序列。
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// SortColumns 是允许排序的列白名单，键为客户端使用的字段名，值为 SQL 列名。
+type SortColumns map[string]string
+
+// Parse 将逗号分隔的排序参数解析为 SortField，例如 "name,-created_at"。
+// "-" 前缀表示降序，"+" 前缀表示升序，无前缀时按 order（"desc" 为降序，其他为升序）。
+// 字段不在白名单中时返回 query 来源的 *BindError（包装 *ValidationErrors，以 422 输出）。
+func (s SortColumns) Parse(sort, order string) ([]SortField, error) {
+	var fields []SortField
+	seen := make(map[string]bool)
+	for part := range strings.SplitSeq(sort, ",") {
+		name := strings.TrimSpace(part)
+		desc := order == "desc"
+		if rest, ok := strings.CutPrefix(name, "-"); ok {
+			name, desc = rest, true
+		} else if rest, ok := strings.CutPrefix(name, "+"); ok {
+			name, desc = rest, false
+		}
+		if name == "" {
+			continue
+		}
+		column, ok := s[name]
+		if !ok {
+			v := &ValidationErrors{}
+			v.Add("sort", fmt.Sprintf("unsupported sort field: %s", name))
+			return nil, NewBindError("query", v)
+		}
+		if !seen[column] {
+			seen[column] = true
+			fields = append(fields, SortField{Column: column, Desc: desc})
+		}
+	}
+	return fields, nil
+}
+
+// SortFields 按白名单解析 Sort 和 Order，Order 缺省为 desc。
+func (q *PageQuery) SortFields(cols SortColumns) ([]SortField, error) {
+	n := q.Normalize()
+	return cols.Parse(n.Sort, n.Order)
+}
+
+// SortFields 按白名单解析 Sort 和 Order，Order 缺省为 desc。
+// 游标中的键值必须与解析结果的列一一对应，排序参数变化后旧游标不再适用。
+func (q *CursorQuery) SortFields(cols SortColumns) ([]SortField, error) {
+	order := q.Order
+	if order == "" {
+		order = "desc"
+	}
+	return cols.Parse(q.Sort, order)
+}
+
+// ErrKeysetValues 表示传入 Keyset.Where 的键值数量与排序列不一致。
+var ErrKeysetValues = errors.New("keyset values do not match sort columns")
+
+// Keyset 根据排序列生成键集分页（seek method）的 WHERE 和 ORDER BY 片段。
+// 列名只来自代码（SortColumns 白名单或 tiebreak），键值始终作为绑定参数传递。
+type Keyset struct {
+	placeholder func(n int) string
+	fields      []SortField
+}
+
+// KeysetOption 配置 Keyset。
+type KeysetOption func(*Keyset)
+
+// WithPlaceholder 设置绑定参数占位符，n 为参数在 Where 返回的 args 中的序号（从 1 开始）。
+// 默认使用 "?"（MySQL、SQLite）；PostgreSQL 使用 DollarPlaceholder。
+func WithPlaceholder(fn func(n int) string) KeysetOption {
+	return func(k *Keyset) {
+		k.placeholder = fn
+	}
+}
+
+// DollarPlaceholder 返回 PostgreSQL 风格的占位符 $n。
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// NewKeyset 创建键集分页辅助器。tiebreak 应为唯一列（通常是主键），不在 fields 中时追加到末尾，
+// 以保证排序全序。列名不是合法的 SQL 标识符（可带一级表名前缀）时 panic。
+//
+//	fields, err := q.SortFields(ginm.SortColumns{"name": "name", "created": "created_at"})
+//	ks := ginm.NewKeyset(fields, ginm.SortField{Column: "id", Desc: true})
+//	where, args, err := ks.Where(values, cur.Backward)
+//	sql := "SELECT ... WHERE " + where + " ORDER BY " + ks.OrderBy(cur.Backward) + " LIMIT ?"
+func NewKeyset(fields []SortField, tiebreak SortField, opts ...KeysetOption) *Keyset {
+	k := &Keyset{placeholder: func(int) string { return "?" }}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	hasTiebreak := false
+	for _, f := range fields {
+		hasTiebreak = hasTiebreak || f.Column == tiebreak.Column
+	}
+	k.fields = append(k.fields, fields...)
+	if !hasTiebreak {
+		k.fields = append(k.fields, tiebreak)
+	}
+	for _, f := range k.fields {
+		if !validColumn(f.Column) {
+			panic(fmt.Sprintf("invalid sort column: %q", f.Column))
+		}
+	}
+	return k
+}
+
+// Fields 返回包含 tiebreak 的全部排序列，游标键值应按此顺序提供。
+func (k *Keyset) Fields() []SortField {
+	return append([]SortField(nil), k.fields...)
+}
+
+// OrderBy 返回 ORDER BY 片段（不含关键字）。backward 为 true 时方向全部反转，
+// 查询结果为逆序，可直接交给 NewCursorResponse 恢复顺序。
+func (k *Keyset) OrderBy(backward bool) string {
+	parts := make([]string, len(k.fields))
+	for i, f := range k.fields {
+		dir := "ASC"
+		if f.Desc != backward {
+			dir = "DESC"
+		}
+		parts[i] = f.Column + " " + dir
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Where 返回严格位于 values 之后（backward 时为之前）的 WHERE 片段及绑定参数，
+// 展开为 (a > ?) OR (a = ? AND b > ?) 的形式以支持混合排序方向。values 按 Fields 的顺序提供；
+// values 为空时返回 "1=1"，即第一页。
+func (k *Keyset) Where(values []any, backward bool) (string, []any, error) {
+	if len(values) == 0 {
+		return "1=1", nil, nil
+	}
+	if len(values) != len(k.fields) {
+		return "", nil, fmt.Errorf("%w: got %d values for %d columns", ErrKeysetValues, len(values), len(k.fields))
+	}
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return k.placeholder(len(args))
+	}
+
+	ors := make([]string, len(k.fields))
+	for i, f := range k.fields {
+		ands := make([]string, 0, i+1)
+		for j := range i {
+			ands = append(ands, k.fields[j].Column+" = "+arg(values[j]))
+		}
+		op := ">"
+		if f.Desc != backward {
+			op = "<"
+		}
+		ands = append(ands, f.Column+" "+op+" "+arg(values[i]))
+		ors[i] = "(" + strings.Join(ands, " AND ") + ")"
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args, nil
+}
+
+// validColumn 报告 s 是否为合法的列名，如 "id" 或 "users.created_at"。
+func validColumn(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" {
+			return false
+		}
+		for i, r := range p {
+			isLetter := r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keysetColumns = SortColumns{"name": "u.name", "created": "created_at", "id": "id"}
+
+func TestSortColumns_Parse(t *testing.T) {
+	fields, err := keysetColumns.Parse(" name , -created,+id,name", "desc")
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{
+		{Column: "u.name", Desc: true},
+		{Column: "created_at", Desc: true},
+		{Column: "id"},
+	}, fields)
+
+	fields, err = keysetColumns.Parse("", "asc")
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+}
+
+func TestSortColumns_ParseRejectsUnknownField(t *testing.T) {
+	_, err := keysetColumns.Parse("name,password", "asc")
+	var bindErr *BindError
+	require.ErrorAs(t, err, &bindErr)
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	assert.Equal(t, []ValidationError{{Field: "sort", Message: "unsupported sort field: password"}}, v.Errors)
+	assert.Equal(t, http.StatusUnprocessableEntity, classifyError(createTestContext(http.MethodGet, "/", nil, ""), err).status)
+}
+
+func TestQuery_SortFieldsDefaultDesc(t *testing.T) {
+	fields, err := (&PageQuery{Sort: "name"}).SortFields(keysetColumns)
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{{Column: "u.name", Desc: true}}, fields)
+
+	fields, err = (&CursorQuery{Sort: "name", Order: "asc"}).SortFields(keysetColumns)
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{{Column: "u.name"}}, fields)
+}
+
+func TestKeyset_OrderByAndWhere(t *testing.T) {
+	ks := NewKeyset([]SortField{{Column: "u.name"}, {Column: "created_at", Desc: true}}, SortField{Column: "id"})
+	assert.Equal(t, []SortField{{Column: "u.name"}, {Column: "created_at", Desc: true}, {Column: "id"}}, ks.Fields())
+	assert.Equal(t, "u.name ASC, created_at DESC, id ASC", ks.OrderBy(false))
+	assert.Equal(t, "u.name DESC, created_at ASC, id DESC", ks.OrderBy(true))
+
+	where, args, err := ks.Where([]any{"bob", "2024-01-01", 7}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "((u.name > ?) OR (u.name = ? AND created_at < ?) OR (u.name = ? AND created_at = ? AND id > ?))", where)
+	assert.Equal(t, []any{"bob", "bob", "2024-01-01", "bob", "2024-01-01", 7}, args)
+
+	where, _, err = ks.Where([]any{"bob", "2024-01-01", 7}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "((u.name < ?) OR (u.name = ? AND created_at > ?) OR (u.name = ? AND created_at = ? AND id < ?))", where)
+}
+
+func TestKeyset_WhereFirstPageAndMismatch(t *testing.T) {
+	ks := NewKeyset(nil, SortField{Column: "id", Desc: true})
+	where, args, err := ks.Where(nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1=1", where)
+	assert.Empty(t, args)
+
+	_, _, err = ks.Where([]any{1, 2}, false)
+	require.ErrorIs(t, err, ErrKeysetValues)
+}
+
+func TestKeyset_TiebreakNotDuplicated(t *testing.T) {
+	ks := NewKeyset([]SortField{{Column: "id", Desc: true}}, SortField{Column: "id"})
+	assert.Equal(t, "id DESC", ks.OrderBy(false))
+}
+
+func TestKeyset_DollarPlaceholder(t *testing.T) {
+	ks := NewKeyset([]SortField{{Column: "name"}}, SortField{Column: "id"}, WithPlaceholder(DollarPlaceholder))
+	where, _, err := ks.Where([]any{"a", 1}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "((name > $1) OR (name = $2 AND id > $3))", where)
+}
+
+func TestKeyset_PanicsOnInvalidColumn(t *testing.T) {
+	for _, col := range []string{"", "id; DROP TABLE users", "a.b.c", "1id", "name DESC"} {
+		assert.Panics(t, func() { NewKeyset(nil, SortField{Column: col}) }, col)
+	}
+	assert.NotPanics(t, func() { NewKeyset(nil, SortField{Column: "_users.id2"}) })
+}
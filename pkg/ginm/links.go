@@ -0,0 +1,122 @@
+package ginm
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 常用的链接关系名称。
+const (
+	LinkSelf    = "self"
+	LinkNext    = "next"
+	LinkPrev    = "prev"
+	LinkFirst   = "first"
+	LinkLast    = "last"
+	LinkRelated = "related"
+)
+
+// Links 是 HATEOAS 链接集合，键为链接关系，值为 URL。
+type Links map[string]string
+
+// LinkBuilder 基于当前请求构建链接，生成的 URL 为不含 scheme 和 host 的路径。
+//
+//	b := ginm.NewLinkBuilder(c)
+//	links := ginm.Links{
+//	    ginm.LinkSelf:    b.Self(),
+//	    ginm.LinkRelated: b.Route("/users/:id/orders"), // :id 取自当前请求的路径参数
+//	}
+type LinkBuilder struct {
+	c *gin.Context
+}
+
+// NewLinkBuilder 创建基于 c 的 LinkBuilder。
+func NewLinkBuilder(c *gin.Context) *LinkBuilder {
+	return &LinkBuilder{c: c}
+}
+
+// Self 返回当前请求的路径和查询参数。
+func (b *LinkBuilder) Self() string {
+	return b.c.Request.URL.RequestURI()
+}
+
+// Route 按路由模板生成路径，":name" 和 "*name" 占位符依次取自 params 中的 name、value 对，
+// 未提供时取当前请求的同名路径参数。
+//
+//	b.Route("/users/:id/orders/:orderID", "orderID", "42")
+func (b *LinkBuilder) Route(template string, params ...string) string {
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i+1 < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+	segments := strings.Split(template, "/")
+	for i, seg := range segments {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		name := seg[1:]
+		v, ok := values[name]
+		if !ok {
+			v = strings.TrimPrefix(b.c.Param(name), "/")
+		}
+		if seg[0] == '*' {
+			segments[i] = v
+		} else {
+			segments[i] = url.PathEscape(v)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Page 返回当前请求的路径，查询参数中仅替换 page 和 page_size。
+func (b *LinkBuilder) Page(page, pageSize int) string {
+	q := b.c.Request.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("page_size", strconv.Itoa(pageSize))
+	u := url.URL{Path: b.c.Request.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
+
+// PageLinks 返回分页响应的 self、first、prev、next、last 链接，prev 和 next 仅在存在时返回。
+func (b *LinkBuilder) PageLinks(page, pageSize, totalPages int) Links {
+	last := max(totalPages, 1)
+	links := Links{
+		LinkSelf:  b.Page(page, pageSize),
+		LinkFirst: b.Page(1, pageSize),
+		LinkLast:  b.Page(last, pageSize),
+	}
+	if page > 1 {
+		links[LinkPrev] = b.Page(min(page-1, last), pageSize)
+	}
+	if page < totalPages {
+		links[LinkNext] = b.Page(page+1, pageSize)
+	}
+	return links
+}
+
+// WithLinks 返回设置了链接的 Response 副本，适用于直接调用 JSON 输出的场景。
+func (r Response[T]) WithLinks(links Links) Response[T] {
+	r.Links = links
+	return r
+}
+
+// WithPageLinks 返回填充了分页链接的 PageResponse 副本：
+//
+//	return ginm.NewPageResponse(users, total, q.Page, q.PageSize).WithPageLinks(c), nil
+func (p PageResponse[T]) WithPageLinks(c *gin.Context) PageResponse[T] {
+	p.Links = NewLinkBuilder(c).PageLinks(p.Page, p.PageSize, p.TotalPages)
+	return p
+}
+
+// WithLinks 为 Wrap 系列处理器的成功响应信封添加链接，fn 在每次成功响应时调用：
+//
+//	r.GET("/users/:id", ginm.WrapURI(getUser, ginm.WithLinks(func(b *ginm.LinkBuilder) ginm.Links {
+//	    return ginm.Links{ginm.LinkSelf: b.Self(), ginm.LinkRelated: b.Route("/users/:id/orders")}
+//	})))
+func WithLinks(fn func(b *LinkBuilder) Links) WrapOption {
+	return func(cfg *wrapConfig) {
+		cfg.links = fn
+	}
+}
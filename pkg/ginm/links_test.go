@@ -0,0 +1,77 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkBuilder_Route(t *testing.T) {
+	var self, route, override, wildcard string
+	r := newHandlerEngine(http.MethodGet, "/users/:id/*path", func(c *gin.Context) {
+		b := NewLinkBuilder(c)
+		self = b.Self()
+		route = b.Route("/users/:id/orders")
+		override = b.Route("/users/:id/orders/:orderID", "orderID", "a b")
+		wildcard = b.Route("/files/*path")
+	})
+
+	serve(r, http.MethodGet, "/users/7/docs/a.txt?x=1", "")
+	assert.Equal(t, "/users/7/docs/a.txt?x=1", self)
+	assert.Equal(t, "/users/7/orders", route)
+	assert.Equal(t, "/users/7/orders/a%20b", override)
+	assert.Equal(t, "/files/docs/a.txt", wildcard)
+}
+
+func TestLinkBuilder_PageLinks(t *testing.T) {
+	var links Links
+	r := newHandlerEngine(http.MethodGet, "/items", func(c *gin.Context) {
+		links = NewLinkBuilder(c).PageLinks(2, 10, 3)
+	})
+
+	serve(r, http.MethodGet, "/items?q=a&page=2", "")
+	assert.Equal(t, Links{
+		LinkSelf:  "/items?page=2&page_size=10&q=a",
+		LinkFirst: "/items?page=1&page_size=10&q=a",
+		LinkPrev:  "/items?page=1&page_size=10&q=a",
+		LinkNext:  "/items?page=3&page_size=10&q=a",
+		LinkLast:  "/items?page=3&page_size=10&q=a",
+	}, links)
+}
+
+func TestWithLinks_AddsEnvelopeLinks(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/users/:id", WrapURI(func(c *gin.Context, req *handlerIDReq) (int, error) {
+		return req.ID, nil
+	}, WithLinks(func(b *LinkBuilder) Links {
+		return Links{LinkSelf: b.Self(), LinkRelated: b.Route("/users/:id/orders")}
+	})))
+
+	w := serve(r, http.MethodGet, "/users/3", "")
+	assert.JSONEq(t, `{"code":0,"data":3,"links":{"self":"/users/3","related":"/users/3/orders"}}`, w.Body.String())
+}
+
+func TestPageResponse_WithPageLinks(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/items", WrapNoReq(func(c *gin.Context) (PageResponse[int], error) {
+		return NewPageResponse([]int{1}, 1, 1, 10).WithPageLinks(c), nil
+	}))
+
+	want := `{"code":0,"data":{"items":[1],"total":1,"page":1,"page_size":10,"total_pages":1,"has_more":false,
+		"links":{"self":"/items?page=1&page_size=10","first":"/items?page=1&page_size=10","last":"/items?page=1&page_size=10"}}}`
+	assert.JSONEq(t, want, serve(r, http.MethodGet, "/items", "").Body.String())
+
+	SetEnvelopeOptions(EnvelopeOptions{Stream: true})
+	t.Cleanup(func() { SetEnvelopeOptions(EnvelopeOptions{}) })
+	assert.JSONEq(t, want, serve(r, http.MethodGet, "/items", "").Body.String())
+}
+
+func TestResponse_WithLinks_Stream(t *testing.T) {
+	SetEnvelopeOptions(EnvelopeOptions{Stream: true})
+	t.Cleanup(func() { SetEnvelopeOptions(EnvelopeOptions{}) })
+	r := newHandlerEngine(http.MethodGet, "/ping", func(c *gin.Context) {
+		JSON(c, http.StatusOK, OK("pong").WithLinks(Links{LinkSelf: "/ping"}))
+	})
+
+	assert.JSONEq(t, `{"code":0,"data":"pong","links":{"self":"/ping"}}`, serve(r, http.MethodGet, "/ping", "").Body.String())
+}
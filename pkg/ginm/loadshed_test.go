@@ -0,0 +1,69 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadShedder_PassesWhenNotOverloaded(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{MaxInFlight: 10})
+	r := newTestEngine(s.Middleware())
+	r.GET("/low", s.Shed(PriorityLow), okHandler)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/low", "").Code)
+}
+
+func TestLoadShedder_ShedsLowPriorityUnderPressure(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{
+		Pressure:   func() bool { return true },
+		RetryAfter: 1500 * time.Millisecond,
+	})
+	r := newTestEngine(s.Middleware())
+	r.GET("/low", s.Shed(PriorityLow), okHandler)
+	r.GET("/normal", s.Shed(PriorityNormal), okHandler)
+	r.GET("/critical", s.Shed(PriorityCritical), okHandler)
+
+	w := serve(r, http.MethodGet, "/low", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/normal", "").Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/critical", "").Code)
+}
+
+func TestLoadShedder_ShedPriorityIncludesNormal(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{
+		Pressure:     func() bool { return true },
+		ShedPriority: PriorityNormal,
+	})
+	r := newTestEngine(s.Middleware())
+	r.GET("/normal", s.Shed(PriorityNormal), okHandler)
+	r.GET("/critical", s.Shed(PriorityCritical), okHandler)
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(r, http.MethodGet, "/normal", "").Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/critical", "").Code)
+}
+
+func TestLoadShedder_Overloaded_UsesInFlight(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{MaxInFlight: 1})
+	assert.False(t, s.Overloaded())
+
+	s.inFlight.Add(2)
+	assert.True(t, s.Overloaded())
+	assert.Equal(t, int64(2), s.InFlight())
+}
+
+func TestLoadShedder_Middleware_TracksInFlight(t *testing.T) {
+	s := NewLoadShedder(LoadShedConfig{})
+	r := newTestEngine(s.Middleware())
+
+	var during int64
+	r.GET("/", func(c *gin.Context) { during = s.InFlight() })
+	serve(r, http.MethodGet, "/", "")
+
+	assert.Equal(t, int64(1), during)
+	assert.Equal(t, int64(0), s.InFlight())
+}
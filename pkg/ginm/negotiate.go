@@ -0,0 +1,121 @@
+package ginm
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+// Format 是响应的序列化格式。
+type Format string
+
+// 支持的响应格式。
+const (
+	FormatJSON    Format = "json"
+	FormatXML     Format = "xml"
+	FormatYAML    Format = "yaml"
+	FormatMsgPack Format = "msgpack"
+)
+
+// formatMIMEs 是各格式对应的 MIME 类型，第一个为首选。
+var formatMIMEs = map[Format][]string{
+	FormatJSON:    {binding.MIMEJSON},
+	FormatXML:     {binding.MIMEXML, binding.MIMEXML2},
+	FormatYAML:    {binding.MIMEYAML2, binding.MIMEYAML},
+	FormatMsgPack: {binding.MIMEMSGPACK2, binding.MIMEMSGPACK},
+}
+
+// NegotiationConfig 包含内容协商的配置。
+type NegotiationConfig struct {
+	// Formats 是启用的格式，按优先级排列。Accept 缺失、为 */* 或不匹配任何格式时使用第一个。
+	// 默认值: [FormatJSON]
+	Formats []Format
+}
+
+// negotiation 是预先计算的协商表。
+type negotiation struct {
+	byMIME  map[string]Format
+	offered []string
+}
+
+// negotiationKey 用于存储当前引擎的协商表。
+var negotiationKey = NewContextKey[*negotiation]("ginm:negotiation")
+
+// Negotiation 创建内容协商中间件，之后 JSON、Success 及所有 Wrap 系列处理器（包括错误响应）
+// 都按 Accept 头从 Formats 中选择格式输出同一个 Response[T]。通过 engine.Use 按引擎配置：
+//
+//	r.Use(ginm.Negotiation(ginm.NegotiationConfig{
+//	    Formats: []ginm.Format{ginm.FormatJSON, ginm.FormatXML, ginm.FormatYAML},
+//	}))
+//
+// EnvelopeOptions 中的 EmptyData、SliceAsArray 和 Stream 仅对 JSON 生效。Formats 包含未知格式时 panic。
+func Negotiation(cfg NegotiationConfig) gin.HandlerFunc {
+	if len(cfg.Formats) == 0 {
+		cfg.Formats = []Format{FormatJSON}
+	}
+	n := &negotiation{byMIME: make(map[string]Format)}
+	for _, f := range cfg.Formats {
+		mimes, ok := formatMIMEs[f]
+		if !ok {
+			panic(fmt.Sprintf("unknown response format: %q", f))
+		}
+		for _, m := range mimes {
+			n.byMIME[m] = f
+		}
+		n.offered = append(n.offered, mimes...)
+	}
+
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept")
+		Set(c, negotiationKey, n)
+		c.Next()
+	}
+}
+
+// negotiateFormat 返回当前请求应使用的格式，未启用协商时返回 FormatJSON。
+func negotiateFormat(c *gin.Context) Format {
+	n, ok := Get(c, negotiationKey)
+	if !ok {
+		return FormatJSON
+	}
+	if f, ok := n.byMIME[c.NegotiateFormat(n.offered...)]; ok {
+		return f
+	}
+	return n.byMIME[n.offered[0]]
+}
+
+// formatEnvelope 是非 JSON 格式使用的信封，空 data 始终省略。
+type formatEnvelope struct {
+	XMLName xml.Name `codec:"-"                 xml:"response"          yaml:"-"`
+	Data    any      `codec:"data,omitempty"    xml:"data,omitempty"    yaml:"data,omitempty"`
+	Message string   `codec:"message,omitempty" xml:"message,omitempty" yaml:"message,omitempty"`
+	Error   string   `codec:"error,omitempty"   xml:"error,omitempty"   yaml:"error,omitempty"`
+	Code    int      `codec:"code"              xml:"code"              yaml:"code"`
+	Links   Links    `codec:"links,omitempty"   xml:"-"                 yaml:"links,omitempty"`
+}
+
+// renderFormat 以非 JSON 格式输出响应。
+func renderFormat[T any](c *gin.Context, status int, f Format, resp Response[T]) {
+	c.Render(status, formatRender(f, resp))
+}
+
+// formatRender 返回以非 JSON 格式 f 输出 resp 的渲染器。
+func formatRender[T any](f Format, resp Response[T]) render.Render {
+	env := formatEnvelope{Message: resp.Message, Error: resp.Error, Code: resp.Code, Links: resp.Links}
+	if !isEmptyValue(reflect.ValueOf(&resp.Data).Elem()) {
+		env.Data = resp.Data
+	}
+
+	switch f {
+	case FormatXML:
+		return render.XML{Data: env}
+	case FormatYAML:
+		return render.YAML{Data: env}
+	default:
+		return render.MsgPack{Data: env}
+	}
+}
@@ -0,0 +1,78 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type negotiateItem struct {
+	Name string `json:"name" xml:"name" yaml:"name" codec:"name"`
+}
+
+var getWidget = WrapNoReq(func(c *gin.Context) (negotiateItem, error) { return negotiateItem{Name: "widget"}, nil })
+
+func TestNegotiation_DefaultsToFirstFormat(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatJSON, FormatXML}}))
+	r.GET("/item", getWidget)
+	w := serve(r, http.MethodGet, "/item", "")
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":{"name":"widget"},"code":0}`, w.Body.String())
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	w = serve(r, http.MethodGet, "/item", "", "Accept", "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestNegotiation_XML(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatJSON, FormatXML}}))
+	r.GET("/item", getWidget)
+	w := serve(r, http.MethodGet, "/item", "", "Accept", "application/xml")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+	assert.Equal(t, "<response><data><name>widget</name></data><code>0</code></response>", w.Body.String())
+}
+
+func TestNegotiation_YAMLError(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatJSON, FormatYAML}}))
+	r.GET("/missing", WrapNoReq(func(c *gin.Context) (negotiateItem, error) {
+		return negotiateItem{}, ErrNotFound("no such item")
+	}))
+	w := serve(r, http.MethodGet, "/missing", "", "Accept", "application/yaml")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/yaml")
+	assert.Equal(t, "message: no such item\ncode: 404\n", w.Body.String())
+}
+
+func TestNegotiation_MsgPack(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatMsgPack, FormatJSON}}))
+	r.GET("/boom", WrapNoReq(func(c *gin.Context) (negotiateItem, error) {
+		return negotiateItem{}, errors.New("boom")
+	}))
+	w := serve(r, http.MethodGet, "/boom", "", "Accept", "application/x-msgpack")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	assert.Contains(t, w.Header().Get("Content-Type"), "msgpack")
+	assert.Contains(t, w.Body.String(), "internal server error")
+}
+
+func TestNegotiation_DisabledFormatFallsBack(t *testing.T) {
+	r := newTestEngine(Negotiation(NegotiationConfig{Formats: []Format{FormatJSON}}))
+	r.GET("/item", getWidget)
+	w := serve(r, http.MethodGet, "/item", "", "Accept", "application/xml")
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestNegotiation_WithoutMiddlewareAlwaysJSON(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/item", getWidget)
+	w := serve(r, http.MethodGet, "/item", "", "Accept", "application/xml")
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.Empty(t, w.Header().Get("Vary"))
+}
+
+func TestNegotiation_PanicsOnUnknownFormat(t *testing.T) {
+	assert.Panics(t, func() { Negotiation(NegotiationConfig{Formats: []Format{"toml"}}) })
+}
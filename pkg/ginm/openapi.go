@@ -0,0 +1,517 @@
+package ginm
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIConfig 包含 OpenAPI 文档的基本信息。
+type OpenAPIConfig struct {
+	// Title 是 API 标题。默认值: "API"
+	Title string
+	// Version 是 API 版本。默认值: "1.0.0"
+	Version string
+	// Description 是 API 描述。
+	Description string
+}
+
+// OpenAPI 记录路由的请求和响应类型，并生成 OpenAPI 3 文档，并发安全。
+//
+//	api := ginm.NewOpenAPI(ginm.OpenAPIConfig{Title: "User API"})
+//	ginm.Route(api, r, http.MethodPost, "/users", CreateUser, ginm.WithSummary("创建用户"))
+//	ginm.RegisterResource(r.Group("/posts"), &PostResource{}, ginm.WithOpenAPI(api))
+//	r.GET("/openapi.json", api.Handler())
+type OpenAPI struct {
+	cfg OpenAPIConfig
+	ops []*openAPIOperation
+	mu  sync.Mutex
+}
+
+// NewOpenAPI 创建 OpenAPI 文档记录器。
+func NewOpenAPI(cfg OpenAPIConfig) *OpenAPI {
+	if cfg.Title == "" {
+		cfg.Title = "API"
+	}
+	if cfg.Version == "" {
+		cfg.Version = "1.0.0"
+	}
+	return &OpenAPI{cfg: cfg}
+}
+
+// openAPIOperation 是一条已记录的路由。
+type openAPIOperation struct {
+	method      string
+	path        string
+	params      reflect.Type // 提供 path/query/header 参数的类型，nil 表示无参数
+	body        reflect.Type // 请求体类型，nil 表示无请求体
+	resp        reflect.Type // 成功响应体类型，nil 表示无响应体
+	summary     string
+	operationID string
+	tags        []string
+	status      int
+}
+
+// OperationOption 是路由文档的函数式选项。
+type OperationOption func(*openAPIOperation)
+
+// WithSummary 设置操作摘要。
+func WithSummary(summary string) OperationOption {
+	return func(op *openAPIOperation) {
+		op.summary = summary
+	}
+}
+
+// WithTags 设置操作标签。
+func WithTags(tags ...string) OperationOption {
+	return func(op *openAPIOperation) {
+		op.tags = tags
+	}
+}
+
+// WithOperationID 设置 operationId，客户端生成器通常用它作为方法名。
+func WithOperationID(id string) OperationOption {
+	return func(op *openAPIOperation) {
+		op.operationID = id
+	}
+}
+
+// WithSuccessStatus 设置成功状态码。默认值: 200
+// 用于 Route 时同时决定实际返回的状态码。
+func WithSuccessStatus(status int) OperationOption {
+	return func(op *openAPIOperation) {
+		op.status = status
+	}
+}
+
+// Router 是可注册路由并提供基础路径的路由器，*gin.Engine 和 *gin.RouterGroup 均满足。
+type Router interface {
+	gin.IRoutes
+	BasePath() string
+}
+
+// Document 记录一条路由的请求和响应类型，不注册处理器。
+// POST、PUT、PATCH 的 Req 作为请求体（uri、header 字段除外），其他方法的 Req 作为查询参数。
+// 没有请求参数时 Req 使用 struct{}。
+func Document[Req, Resp any](api *OpenAPI, method, path string, opts ...OperationOption) {
+	method = strings.ToUpper(method)
+	op := &openAPIOperation{
+		method: method,
+		path:   path,
+		params: reflect.TypeFor[Req](),
+		resp:   reflect.TypeFor[Response[Resp]](),
+		status: http.StatusOK,
+	}
+	if methodHasBody(method) {
+		op.body = op.params
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+	api.add(op)
+}
+
+// Route 注册路由并记录其类型。POST、PUT、PATCH 绑定 URI 和请求体，其他方法绑定 URI 和查询参数。
+func Route[Req, Resp any](api *OpenAPI, r Router, method, relativePath string, handler HandlerFunc[Req, Resp], opts ...OperationOption) {
+	method = strings.ToUpper(method)
+	status := http.StatusOK
+	opts = append(opts, func(op *openAPIOperation) { status = op.status })
+	Document[Req, Resp](api, method, joinRoutePath(r.BasePath(), relativePath), opts...)
+
+	bind := BindURIAndQuery[Req]
+	if methodHasBody(method) {
+		bind = BindURIAndBody[Req]
+	}
+	w := newWrapper[Req, Resp](status, bind, nil)
+	r.Handle(method, relativePath, func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	})
+}
+
+// Handler 返回以 JSON 输出 OpenAPI 文档的处理器。
+func (a *OpenAPI) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, a.Spec())
+	}
+}
+
+// Spec 生成 OpenAPI 3 文档。
+func (a *OpenAPI) Spec() map[string]any {
+	a.mu.Lock()
+	ops := append([]*openAPIOperation(nil), a.ops...)
+	a.mu.Unlock()
+
+	b := newSchemaBuilder()
+	b.schemas["ErrorResponse"] = b.structSchema(reflect.TypeFor[Response[any]](), false)
+
+	paths := make(map[string]any)
+	for _, op := range ops {
+		p := openAPIPath(op.path)
+		item, _ := paths[p].(map[string]any)
+		if item == nil {
+			item = make(map[string]any)
+			paths[p] = item
+		}
+		item[strings.ToLower(op.method)] = b.operation(op)
+	}
+
+	info := map[string]any{"title": a.cfg.Title, "version": a.cfg.Version}
+	if a.cfg.Description != "" {
+		info["description"] = a.cfg.Description
+	}
+	return map[string]any{
+		"openapi":    "3.0.3",
+		"info":       info,
+		"paths":      paths,
+		"components": map[string]any{"schemas": b.schemas},
+	}
+}
+
+// add 记录一条路由。
+func (a *OpenAPI) add(op *openAPIOperation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ops = append(a.ops, op)
+}
+
+// documentResource 记录 RegisterResource 注册的路由。
+func documentResource[T any, ID comparable, CI any, UI any, LQ any](api *OpenAPI, basePath, idPath string, cfg *ResourceConfig) {
+	idType := reflect.TypeFor[IDParam[ID]]()
+	itemResp := reflect.TypeFor[Response[*T]]()
+	itemPath := joinRoutePath(basePath, idPath)
+
+	if cfg.enabled(ActionList) {
+		api.add(&openAPIOperation{method: http.MethodGet, path: basePath, params: reflect.TypeFor[LQ](),
+			resp: reflect.TypeFor[Response[PageResponse[T]]](), status: http.StatusOK})
+	}
+	if cfg.enabled(ActionGet) {
+		api.add(&openAPIOperation{method: http.MethodGet, path: itemPath, params: idType, resp: itemResp, status: http.StatusOK})
+	}
+	if cfg.enabled(ActionCreate) {
+		api.add(&openAPIOperation{method: http.MethodPost, path: basePath, body: reflect.TypeFor[CI](), resp: itemResp, status: http.StatusCreated})
+	}
+	if cfg.enabled(ActionUpdate) {
+		api.add(&openAPIOperation{method: http.MethodPut, path: itemPath, params: idType, body: reflect.TypeFor[UI](), resp: itemResp, status: http.StatusOK})
+	}
+	if cfg.enabled(ActionDelete) {
+		api.add(&openAPIOperation{method: http.MethodDelete, path: itemPath, params: idType, resp: reflect.TypeFor[Response[any]](), status: http.StatusOK})
+	}
+}
+
+// --- Schema 生成 ---
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	routeParamRegex = regexp.MustCompile(`/[:*]([^/]+)`)
+	typePathRegex   = regexp.MustCompile(`[\w\-./]*\.`)
+)
+
+// schemaBuilder 将 Go 类型转换为 JSON Schema，具名结构体放入 components。
+type schemaBuilder struct {
+	schemas map[string]any
+	names   map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{schemas: make(map[string]any), names: make(map[reflect.Type]string)}
+}
+
+// operation 生成单个操作对象。
+func (b *schemaBuilder) operation(op *openAPIOperation) map[string]any {
+	out := make(map[string]any)
+	if op.summary != "" {
+		out["summary"] = op.summary
+	}
+	if op.operationID != "" {
+		out["operationId"] = op.operationID
+	}
+	if len(op.tags) > 0 {
+		out["tags"] = op.tags
+	}
+
+	params := b.parameters(op.params, op.body == nil)
+	for _, m := range routeParamRegex.FindAllStringSubmatch(op.path, -1) {
+		if !hasParam(params, m[1], "path") {
+			params = append(params, map[string]any{
+				"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			})
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+
+	if body := b.requestBody(op.body); body != nil {
+		out["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": body}},
+		}
+	}
+
+	success := map[string]any{"description": http.StatusText(op.status)}
+	if op.resp != nil && op.status != http.StatusNoContent {
+		success["content"] = map[string]any{"application/json": map[string]any{"schema": b.schema(op.resp)}}
+	}
+	out["responses"] = map[string]any{
+		fmt.Sprint(op.status): success,
+		"default": map[string]any{
+			"description": "error",
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/ErrorResponse"},
+			}},
+		},
+	}
+	return out
+}
+
+// parameters 从结构体的 uri、header 标签生成参数，query 为 true 时 form 标签字段作为查询参数。
+func (b *schemaBuilder) parameters(t reflect.Type, query bool) []any {
+	var params []any
+	if t == nil {
+		return params
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return params
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("ctx"); ok {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("form") == "" && f.Tag.Get("uri") == "" {
+			params = append(params, b.parameters(f.Type, query)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		required := hasRequiredBinding(f)
+		switch {
+		case f.Tag.Get("uri") != "":
+			params = append(params, b.param(f, tagName(f.Tag.Get("uri")), "path", true))
+		case f.Tag.Get("header") != "":
+			params = append(params, b.param(f, tagName(f.Tag.Get("header")), "header", required))
+		case query:
+			name := tagName(f.Tag.Get("form"))
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			params = append(params, b.param(f, name, "query", required))
+		}
+	}
+	return params
+}
+
+// param 生成单个参数对象。
+func (b *schemaBuilder) param(f reflect.StructField, name, in string, required bool) map[string]any {
+	p := map[string]any{"name": name, "in": in, "schema": b.schema(f.Type)}
+	if required {
+		p["required"] = true
+	}
+	return p
+}
+
+// requestBody 返回请求体的 schema，类型中含 uri、header 字段时内联生成并排除这些字段。
+func (b *schemaBuilder) requestBody(t reflect.Type) map[string]any {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.NumField() == 0 {
+		return nil
+	}
+	if t.Kind() != reflect.Struct || !hasParamFields(t) {
+		return b.schema(t)
+	}
+	s := b.structSchema(t, true)
+	if props, _ := s["properties"].(map[string]any); len(props) == 0 {
+		return nil
+	}
+	return s
+}
+
+// schema 返回类型的 JSON Schema。
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t, false)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + b.component(t)}
+	default:
+		return map[string]any{}
+	}
+}
+
+// component 将具名结构体注册到 components 并返回名称。
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	base := schemaName(t)
+	name := base
+	for i := 2; b.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	// 先占位，避免递归类型无限展开
+	b.names[t] = name
+	b.schemas[name] = map[string]any{}
+	b.schemas[name] = b.structSchema(t, false)
+	return name
+}
+
+// structSchema 生成结构体的 object schema，skipParams 为 true 时排除 uri、header 字段。
+func (b *schemaBuilder) structSchema(t reflect.Type, skipParams bool) map[string]any {
+	props := make(map[string]any)
+	var required []string
+	b.collectFields(t, skipParams, props, &required)
+
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// collectFields 收集结构体字段，匿名嵌入的结构体字段被展开。
+func (b *schemaBuilder) collectFields(t reflect.Type, skipParams bool, props map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("ctx"); ok {
+			continue
+		}
+		if skipParams && (f.Tag.Get("uri") != "" || f.Tag.Get("header") != "") {
+			continue
+		}
+		name := tagName(f.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			b.collectFields(ft, skipParams, props, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = b.schema(f.Type)
+		if hasRequiredBinding(f) {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// --- 辅助函数 ---
+
+// methodHasBody 判断方法是否携带请求体。
+func methodHasBody(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// openAPIPath 将 gin 路径参数 :id、*path 转换为 {id}、{path}。
+func openAPIPath(p string) string {
+	return routeParamRegex.ReplaceAllString(p, "/{$1}")
+}
+
+// schemaName 生成 components 中使用的名称，例如 Response[pkg.User] 转换为 Response_User。
+func schemaName(t reflect.Type) string {
+	name := typePathRegex.ReplaceAllString(t.Name(), "")
+	return strings.NewReplacer("[", "_", "]", "", ",", "_", "*", "", " ", "").Replace(name)
+}
+
+// tagName 返回标签值中的名称部分。
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+// hasRequiredBinding 判断字段的 binding 标签是否包含 required。
+func hasRequiredBinding(f reflect.StructField) bool {
+	for rule := range strings.SplitSeq(f.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasParamFields 判断结构体是否包含 uri 或 header 字段。
+func hasParamFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Tag.Get("uri") != "" || f.Tag.Get("header") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasParam 判断参数列表中是否已有同名同位置的参数。
+func hasParam(params []any, name, in string) bool {
+	for _, p := range params {
+		if m := p.(map[string]any); m["name"] == name && m["in"] == in {
+			return true
+		}
+	}
+	return false
+}
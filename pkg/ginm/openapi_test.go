@@ -0,0 +1,169 @@
+package ginm
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIUser struct {
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags,omitempty"`
+	ID        int64     `json:"id"`
+}
+
+type openAPICreateReq struct {
+	OrgID  string `uri:"org" binding:"required"`
+	Name   string `json:"name"`
+	Secret string `json:"-"`
+	Age    int    `json:"age"`
+}
+
+type openAPIListReq struct {
+	PageQuery
+	Search string `form:"q" binding:"required"`
+	Client string `ctx:"ginm:client_info" form:"-" json:"-"`
+}
+
+type openAPIPost struct {
+	Title string `json:"title"`
+}
+
+type openAPIPostResource struct {
+	BaseResource[openAPIPost, int, openAPIPost, openAPIPost, PageQuery]
+}
+
+// specJSON 经过 JSON 往返后返回文档，便于按路径断言。
+func specJSON(t *testing.T, api *OpenAPI) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(api.Spec())
+	require.NoError(t, err)
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(data, &spec))
+	return spec
+}
+
+// dig 按键路径取出嵌套值。
+func dig(v any, keys ...string) any {
+	for _, k := range keys {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
+
+func TestRoute_RegistersHandlerAndDocumentsTypes(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{Title: "Test"})
+	g := r.Group("/orgs")
+	Route(api, g, http.MethodPost, "/:org/users", func(c *gin.Context, req *openAPICreateReq) (openAPIUser, error) {
+		return openAPIUser{Name: req.OrgID + "/" + req.Name}, nil
+	}, WithSummary("create user"), WithTags("users"), WithSuccessStatus(http.StatusCreated))
+
+	w := serve(r, http.MethodPost, "/orgs/acme/users", `{"name":"bob"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"acme/bob"`)
+
+	spec := specJSON(t, api)
+	assert.Equal(t, "3.0.3", spec["openapi"])
+	assert.Equal(t, "Test", dig(spec, "info", "title"))
+	assert.Equal(t, "1.0.0", dig(spec, "info", "version"))
+
+	op := dig(spec, "paths", "/orgs/{org}/users", "post")
+	require.NotNil(t, op)
+	assert.Equal(t, "create user", dig(op, "summary"))
+	assert.Equal(t, []any{"users"}, dig(op, "tags"))
+	assert.Equal(t, []any{map[string]any{
+		"name": "org", "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+	}}, dig(op, "parameters"))
+
+	body := dig(op, "requestBody", "content", "application/json", "schema")
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"age":  map[string]any{"type": "integer", "format": "int64"},
+		},
+	}, body)
+
+	assert.Equal(t, "#/components/schemas/Response_openAPIUser",
+		dig(op, "responses", "201", "content", "application/json", "schema", "$ref"))
+	assert.Equal(t, "#/components/schemas/ErrorResponse",
+		dig(op, "responses", "default", "content", "application/json", "schema", "$ref"))
+
+	user := dig(spec, "components", "schemas", "openAPIUser", "properties")
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, dig(user, "created_at"))
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, dig(user, "tags"))
+}
+
+func TestDocument_QueryParamsFlattenEmbeddedAndSkipCtx(t *testing.T) {
+	api := NewOpenAPI(OpenAPIConfig{})
+	Document[openAPIListReq, []openAPIUser](api, "get", "/users")
+
+	spec := specJSON(t, api)
+	op := dig(spec, "paths", "/users", "get")
+	require.NotNil(t, op)
+	assert.Nil(t, dig(op, "requestBody"))
+
+	var names []string
+	for _, p := range dig(op, "parameters").([]any) {
+		assert.Equal(t, "query", dig(p, "in"))
+		names = append(names, dig(p, "name").(string))
+	}
+	assert.Equal(t, []string{"sort", "order", "page", "page_size", "q"}, names)
+	assert.Equal(t, true, dig(op, "parameters").([]any)[4].(map[string]any)["required"])
+}
+
+func TestWithOpenAPI_DocumentsResourceRoutes(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{})
+	RegisterResource(r.Group("/posts"), &openAPIPostResource{}, WithOpenAPI(api))
+
+	spec := specJSON(t, api)
+	paths := dig(spec, "paths").(map[string]any)
+	assert.Len(t, paths, 2)
+	assert.NotNil(t, dig(paths, "/posts", "get"))
+	assert.NotNil(t, dig(paths, "/posts", "post", "responses", "201"))
+	assert.Equal(t, "#/components/schemas/openAPIPost",
+		dig(paths, "/posts", "post", "requestBody", "content", "application/json", "schema", "$ref"))
+	for _, method := range []string{"get", "put", "delete"} {
+		params := dig(paths, "/posts/{id}", method, "parameters").([]any)
+		require.Len(t, params, 1, method)
+		assert.Equal(t, map[string]any{"type": "integer", "format": "int64"}, dig(params[0], "schema"))
+	}
+	assert.NotNil(t, dig(spec, "components", "schemas", "Response_PageResponse_openAPIPost"))
+}
+
+func TestOpenAPI_Handler(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{Title: "Served"})
+	Document[struct{}, string](api, http.MethodGet, "/ping")
+	r.GET("/openapi.json", api.Handler())
+
+	w := serve(r, http.MethodGet, "/openapi.json", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"/ping"`)
+	assert.Contains(t, w.Body.String(), `"Served"`)
+}
+
+type openAPITree struct {
+	Children []openAPITree `json:"children"`
+}
+
+func TestSchemaBuilder_RecursiveType(t *testing.T) {
+	b := newSchemaBuilder()
+	ref := b.schema(reflect.TypeFor[openAPITree]())
+	assert.Equal(t, "#/components/schemas/openAPITree", ref["$ref"])
+	items := dig(b.schemas["openAPITree"], "properties", "children", "items")
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/openAPITree"}, items)
+}
@@ -1,5 +1,13 @@
 package ginm
 
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
 // 默认分页常量。
 const (
 	DefaultPage     = 1
@@ -22,12 +30,12 @@ func normalizePage(page, pageSize int) (int, int) {
 	return page, pageSize
 }
 
-// PageQuery 是标准的分页查询结构体。
+// PageQuery 是标准的分页查询结构体。json 标签使其也可嵌入 RegisterSearch 等 JSON 请求体。
 type PageQuery struct {
-	Sort     string `form:"sort"`
-	Order    string `binding:"omitempty,oneof=asc desc" form:"order"`
-	Page     int    `binding:"min=0"                    form:"page"`
-	PageSize int    `binding:"min=0,max=100"            form:"page_size"`
+	Sort     string `form:"sort"                        json:"sort,omitempty"`
+	Order    string `binding:"omitempty,oneof=asc desc" form:"order"             json:"order,omitempty"`
+	Page     int    `binding:"min=0"                    form:"page"              json:"page,omitempty"`
+	PageSize int    `binding:"min=0,max=100"            form:"page_size"         json:"page_size,omitempty"`
 }
 
 // Normalize 返回应用默认值后的 PageQuery。
@@ -119,3 +127,47 @@ func PaginateSlice[T any](items []T, page, pageSize int) PageResponse[T] {
 
 	return NewPageResponse(items[start:end], total, page, pageSize)
 }
+
+// PaginationOptions 控制分页响应的附加输出。
+type PaginationOptions struct {
+	// Headers 为 true 时 SuccessPage、WrapPage 和资源的 List 路由同时输出 X-Total-Count
+	// 和 RFC 5988 Link 头（first、prev、next、last），供通过响应头分页的客户端使用。
+	Headers bool
+}
+
+var paginationOptions atomic.Pointer[PaginationOptions]
+
+// SetPaginationOptions 设置全局分页选项，通常在启动时调用一次。
+func SetPaginationOptions(opts PaginationOptions) {
+	paginationOptions.Store(&opts)
+}
+
+// getPaginationOptions 返回当前的分页选项。
+func getPaginationOptions() PaginationOptions {
+	if opts := paginationOptions.Load(); opts != nil {
+		return *opts
+	}
+	return PaginationOptions{}
+}
+
+// writePageHeaders 按 PaginationOptions 输出分页响应头。
+// Link 中的 URL 基于当前请求的路径和查询参数，仅替换 page 和 page_size。
+func writePageHeaders[T any](c *gin.Context, p PageResponse[T]) {
+	if !getPaginationOptions().Headers {
+		return
+	}
+	h := c.Writer.Header()
+	h.Set("X-Total-Count", strconv.FormatInt(p.Total, 10))
+
+	pageLinks := NewLinkBuilder(c).PageLinks(p.Page, p.PageSize, p.TotalPages)
+	var links []string
+	for _, rel := range []string{LinkFirst, LinkPrev, LinkNext, LinkLast} {
+		if u, ok := pageLinks[rel]; ok {
+			links = append(links, "<"+u+`>; rel="`+rel+`"`)
+		}
+	}
+	if len(links) > 0 {
+		// 使用 Add 保留其他中间件输出的 Link（例如版本弃用的 successor-version）
+		h.Add("Link", strings.Join(links, ", "))
+	}
+}
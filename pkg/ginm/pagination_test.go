@@ -1,8 +1,10 @@
 package ginm
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -135,3 +137,58 @@ func TestPaginateSlice_EmptySlice(t *testing.T) {
 	assert.Equal(t, int64(0), resp.Total)
 	assert.False(t, resp.HasMore)
 }
+
+func TestSuccessPage_NoHeadersByDefault(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/items?page=2", nil, "")
+	SuccessPage(c, []int{1}, 10, 2, 5)
+	assert.Empty(t, c.Writer.Header().Get("Link"))
+	assert.Empty(t, c.Writer.Header().Get("X-Total-Count"))
+}
+
+func TestSuccessPage_PaginationHeaders(t *testing.T) {
+	SetPaginationOptions(PaginationOptions{Headers: true})
+	defer SetPaginationOptions(PaginationOptions{})
+
+	c := createTestContext(http.MethodGet, "/items?q=a+b&page=2&page_size=5", nil, "")
+	SuccessPage(c, []int{6, 7, 8, 9, 10}, 23, 2, 5)
+	assert.Equal(t, "23", c.Writer.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</items?page=1&page_size=5&q=a+b>; rel="first", `+
+		`</items?page=1&page_size=5&q=a+b>; rel="prev", `+
+		`</items?page=3&page_size=5&q=a+b>; rel="next", `+
+		`</items?page=5&page_size=5&q=a+b>; rel="last"`, c.Writer.Header().Get("Link"))
+}
+
+func TestWrapPage_PaginationHeadersForEmptyResult(t *testing.T) {
+	SetPaginationOptions(PaginationOptions{Headers: true})
+	defer SetPaginationOptions(PaginationOptions{})
+
+	r := newTestEngine()
+	r.GET("/items", WrapPage(func(c *gin.Context, q *PageQuery) (PageResponse[int], error) {
+		return NewPaginatorFromQuery[int](q).Paginate(nil, 0), nil
+	}))
+	w := serve(r, http.MethodGet, "/items", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</items?page=1&page_size=20>; rel="first", </items?page=1&page_size=20>; rel="last"`,
+		w.Header().Get("Link"))
+}
+
+func TestWrapPage_PaginationLinkKeepsExistingLinks(t *testing.T) {
+	SetPaginationOptions(PaginationOptions{Headers: true})
+	defer SetPaginationOptions(PaginationOptions{})
+
+	r := newTestEngine()
+	r.Use(func(c *gin.Context) {
+		c.Writer.Header().Add("Link", `</api/v2>; rel="successor-version"`)
+		c.Next()
+	})
+	r.GET("/api/v1/items", WrapPage(func(c *gin.Context, q *PageQuery) (PageResponse[int], error) {
+		return NewPaginatorFromQuery[int](q).Paginate(nil, 0), nil
+	}))
+
+	w := serve(r, http.MethodGet, "/api/v1/items", "")
+	assert.Equal(t, []string{
+		`</api/v2>; rel="successor-version"`,
+		`</api/v1/items?page=1&page_size=20>; rel="first", </api/v1/items?page=1&page_size=20>; rel="last"`,
+	}, w.Header().Values("Link"))
+}
@@ -0,0 +1,276 @@
+package ginm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// MIMEMergePatchJSON 是 RFC 7396 JSON Merge Patch 文档的 Content-Type。
+const MIMEMergePatchJSON = "application/merge-patch+json"
+
+// errPatchNotObject 表示合并补丁的请求体不是 JSON 对象。
+var errPatchNotObject = errors.New("merge patch must be a JSON object")
+
+// PatchInput 是 JSON Merge Patch（RFC 7396）请求体，除解码后的 Value 外还记录请求中实际出现的顶层字段，
+// 用于区分“未提供”和“设置为零值”：
+//
+//	func patchUser(c *gin.Context, in *ginm.PatchInput[User]) (*User, error) {
+//	    user := load(c.Param("id"))                    // *User
+//	    if err := in.Apply(user); err != nil { ... }    // 按 RFC 7396 合并到现有对象
+//	    if in.Has("email") { ... }                     // 或手动处理单个字段
+//	}
+//
+// 字段名为 JSON 名称（json 标签，未设置时为字段名）。
+type PatchInput[T any] struct {
+	Value  T
+	fields map[string]json.RawMessage
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，请求体必须是 JSON 对象。
+func (p *PatchInput[T]) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return errPatchNotObject
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	p.Value, p.fields = value, fields
+	return nil
+}
+
+// Has 判断请求中是否出现了字段，显式设置为 null 的字段同样返回 true。
+func (p *PatchInput[T]) Has(field string) bool {
+	_, ok := p.fields[field]
+	return ok
+}
+
+// IsNull 判断字段是否被显式设置为 null。
+func (p *PatchInput[T]) IsNull(field string) bool {
+	raw, ok := p.fields[field]
+	return ok && bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// Fields 返回请求中出现的字段名，按字母顺序排列。
+func (p *PatchInput[T]) Fields() []string {
+	names := make([]string, 0, len(p.fields))
+	for name := range p.fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Apply 按 RFC 7396 将补丁合并到 target：未出现的字段保持不变，null 将字段重置为零值（映射中删除对应键），
+// 对象值递归合并，其他值整体替换。合并结果解码到 target 的副本上，json:"-" 和未导出字段保持不变。
+func (p *PatchInput[T]) Apply(target *T) error {
+	doc, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	var current any
+	if err := json.Unmarshal(doc, &current); err != nil {
+		return err
+	}
+	patch := make(map[string]any, len(p.fields))
+	for name, raw := range p.fields {
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		patch[name] = v
+	}
+	merged, err := json.Marshal(mergePatch(current, patch))
+	if err != nil {
+		return err
+	}
+	result := *target
+	if err := json.Unmarshal(merged, &result); err != nil {
+		return err
+	}
+	clearNulls(reflect.ValueOf(&result).Elem(), patch)
+	*target = result
+	return nil
+}
+
+// clearNulls 将补丁中显式为 null 的字段重置为零值，映射中删除对应键，嵌套对象递归处理。
+// json.Unmarshal 对非指针字段的 null 不做处理，解码到已有映射时也不会删除键。
+func clearNulls(v reflect.Value, patch map[string]any) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range patchFields(v.Type()) {
+			pv, ok := patch[f.name]
+			if !ok {
+				continue
+			}
+			fv := v.FieldByIndex(f.index)
+			if pv == nil {
+				if fv.CanSet() {
+					fv.SetZero()
+				}
+			} else if m, ok := pv.(map[string]any); ok {
+				clearNulls(fv, m)
+			}
+		}
+	case reflect.Map:
+		// 映射的值由 json.Unmarshal 按合并结果整体替换，只需删除被置为 null 的键
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for name, pv := range patch {
+			if pv == nil {
+				v.SetMapIndex(reflect.ValueOf(name).Convert(v.Type().Key()), reflect.Value{})
+			}
+		}
+	}
+}
+
+// PatchField 返回补丁中单个字段的值：未出现时为 None，显式 null 时为 ONull（IsSet 为 true），
+// 否则为 Some。值无法解码为 V 时返回错误。
+func PatchField[V any, T any](p *PatchInput[T], field string) (gox.Optional[V], error) {
+	raw, ok := p.fields[field]
+	if !ok {
+		return gox.ONone[V](), nil
+	}
+	var v gox.Optional[V]
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return gox.ONone[V](), err
+	}
+	return v, nil
+}
+
+// mergePatch 实现 RFC 7396 的 MergePatch 算法。
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = mergePatch(t[name], value)
+	}
+	return t
+}
+
+// BindPatch 绑定 JSON Merge Patch 请求体。binding 标签只校验请求中出现的字段，
+// 因此 required 等规则约束的是“提供时”的取值，而非“必须提供”。
+func BindPatch[T any](c *gin.Context) (*PatchInput[T], error) {
+	var req PatchInput[T]
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, NewBindError("json", err)
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, NewBindError("json", err)
+	}
+	if err := validatePatch(&req); err != nil {
+		return nil, bindError[T](c, "json", err)
+	}
+	bindContext(c, &req.Value)
+	return &req, nil
+}
+
+// validatePatch 使用 gin 的校验器校验补丁中出现的字段，请求中未出现的字段被排除。
+func validatePatch[T any](p *PatchInput[T]) error {
+	if binding.Validator == nil || reflect.TypeFor[T]().Kind() != reflect.Struct {
+		return nil
+	}
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return binding.Validator.ValidateStruct(&p.Value)
+	}
+	var absent []string
+	for _, f := range patchFields(reflect.TypeFor[T]()) {
+		if !p.Has(f.name) {
+			absent = append(absent, f.path)
+		}
+	}
+	return v.StructExcept(&p.Value, absent...)
+}
+
+// patchField 是结构体中可由补丁设置的字段，path 为校验器使用的 Go 字段路径，index 用于 FieldByIndex。
+type patchField struct {
+	name  string
+	path  string
+	index []int
+}
+
+var patchFieldsCache sync.Map // reflect.Type -> []patchField
+
+// patchFields 返回结构体的 JSON 字段，匿名嵌入的结构体字段被展开。
+func patchFields(t reflect.Type) []patchField {
+	if cached, ok := patchFieldsCache.Load(t); ok {
+		return cached.([]patchField)
+	}
+	var fields []patchField
+	collectPatchFields(t, "", nil, &fields)
+	patchFieldsCache.Store(t, fields)
+	return fields
+}
+
+func collectPatchFields(t reflect.Type, prefix string, parent []int, fields *[]patchField) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := tagName(f.Tag.Get("json"))
+		index := append(append([]int(nil), parent...), i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && name == "" {
+			collectPatchFields(f.Type, prefix+f.Name+".", index, fields)
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		*fields = append(*fields, patchField{name: name, path: prefix + f.Name, index: index})
+	}
+}
+
+// WrapPatch 将 JSON Merge Patch 处理器转换为 gin.HandlerFunc，请求体通过 BindPatch 绑定。
+//
+//	r.PATCH("/users/:id", ginm.WrapPatch(func(c *gin.Context, in *ginm.PatchInput[UserPatch]) (*User, error) {
+//	    ...
+//	}))
+func WrapPatch[T, Resp any](handler func(c *gin.Context, req *PatchInput[T]) (Resp, error), opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[PatchInput[T], Resp](http.StatusOK, BindPatch[T], opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	}
+}
@@ -0,0 +1,97 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patchMeta struct {
+	Tags []string `json:"tags"`
+}
+
+type patchUser struct {
+	patchMeta
+	Profile map[string]any `json:"profile"`
+	Name    string         `json:"name" binding:"required,min=2"`
+	Email   string         `json:"email" binding:"omitempty,email"`
+	Age     int            `json:"age"`
+}
+
+func TestPatchInput_PresenceAndApply(t *testing.T) {
+	r := newTestEngine()
+	var got *PatchInput[patchUser]
+	r.PATCH("/users/1", WrapPatch(func(c *gin.Context, in *PatchInput[patchUser]) (patchUser, error) {
+		got = in
+		user := patchUser{Name: "alice", Age: 30, Email: "a@example.com", Profile: map[string]any{"city": "x", "zip": "1"}}
+		err := in.Apply(&user)
+		return user, err
+	}))
+
+	w := serve(r, http.MethodPatch, "/users/1", `{"age":0,"email":null,"profile":{"zip":null,"country":"cn"},"tags":["a"]}`, "Content-Type", MIMEMergePatchJSON)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"code":0,"data":{"name":"alice","email":"","age":0,"tags":["a"],
+		"profile":{"city":"x","country":"cn"}}}`, w.Body.String())
+
+	assert.Equal(t, []string{"age", "email", "profile", "tags"}, got.Fields())
+	assert.True(t, got.Has("age"))
+	assert.False(t, got.Has("name"))
+	assert.True(t, got.IsNull("email"))
+	assert.False(t, got.IsNull("age"))
+
+	age, err := PatchField[int](got, "age")
+	require.NoError(t, err)
+	assert.Equal(t, 0, age.MustGet())
+	email, err := PatchField[string](got, "email")
+	require.NoError(t, err)
+	assert.True(t, email.IsSet())
+	assert.True(t, email.IsNone())
+	name, err := PatchField[string](got, "name")
+	require.NoError(t, err)
+	assert.False(t, name.IsSet())
+	_, err = PatchField[int](got, "tags")
+	assert.Error(t, err)
+}
+
+type patchAccount struct {
+	Nested   *patchMeta `json:"nested"`
+	Secret   string     `json:"-"`
+	internal int
+	Name     string `json:"name"`
+	Age      int    `json:"age"`
+}
+
+func TestPatchInput_ApplyKeepsNonJSONFields(t *testing.T) {
+	var in PatchInput[patchAccount]
+	require.NoError(t, in.UnmarshalJSON([]byte(`{"name":"bob","age":null,"nested":{"tags":null}}`)))
+
+	acct := patchAccount{Secret: "s3cret", internal: 7, Name: "alice", Age: 30, Nested: &patchMeta{Tags: []string{"a"}}}
+	require.NoError(t, in.Apply(&acct))
+	assert.Equal(t, "bob", acct.Name)
+	assert.Equal(t, 0, acct.Age)
+	assert.Equal(t, "s3cret", acct.Secret)
+	assert.Equal(t, 7, acct.internal)
+	require.NotNil(t, acct.Nested)
+	assert.Nil(t, acct.Nested.Tags)
+}
+
+func TestBindPatch_ValidatesPresentFieldsOnly(t *testing.T) {
+	r := newTestEngine()
+	r.PATCH("/users/1", WrapPatch(func(c *gin.Context, in *PatchInput[patchUser]) ([]string, error) {
+		return in.Fields(), nil
+	}))
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPatch, "/users/1", `{"age":3}`).Code)
+
+	w := serve(r, http.MethodPatch, "/users/1", `{"name":"a","email":"bad"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"name"`)
+	assert.Contains(t, w.Body.String(), `"field":"email"`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPatch, "/users/1", `[1]`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPatch, "/users/1", `null`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPatch, "/users/1", `{"age":"x"}`).Code)
+}
@@ -0,0 +1,100 @@
+package ginm
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// MIMEProblemJSON 是 RFC 7807 问题详情文档的 Content-Type。
+const MIMEProblemJSON = "application/problem+json"
+
+// ProblemDetails 是 RFC 7807 问题详情文档，Code、Error、Errors 为扩展成员。
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Error 是底层错误信息，release 模式下省略。
+	Error string `json:"error,omitempty"`
+	// Errors 是字段级验证错误，仅 422 响应包含。
+	Errors []ValidationError `json:"errors,omitempty"`
+	Status int               `json:"status"`
+	// Code 是业务错误码。
+	Code int `json:"code,omitempty"`
+}
+
+// ProblemOptions 控制 RFC 7807 problem+json 错误输出。
+type ProblemOptions struct {
+	// TypeBaseURI 不为空时 type 为 TypeBaseURI 加业务错误码，例如 "https://errors.example.com/40401"；
+	// 为空时 type 为 "about:blank"。
+	TypeBaseURI string
+	// Enabled 为 true 时所有处理器的错误都以 application/problem+json 输出，不再使用 Response 信封。
+	Enabled bool
+}
+
+var problemOptions atomic.Pointer[ProblemOptions]
+
+// SetProblemOptions 设置全局 problem+json 选项，通常在启动时调用一次。
+func SetProblemOptions(opts ProblemOptions) {
+	problemOptions.Store(&opts)
+}
+
+// getProblemOptions 返回当前的 problem+json 选项。
+func getProblemOptions() ProblemOptions {
+	if opts := problemOptions.Load(); opts != nil {
+		return *opts
+	}
+	return ProblemOptions{}
+}
+
+// problemKey 标记当前请求的错误以 problem+json 输出。
+var problemKey = NewContextKey[bool]("ginm:problem")
+
+// WrapProblem 与 Wrap 相同，但错误始终以 application/problem+json 输出，不受全局选项影响。
+func WrapProblem[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	return withProblem(Wrap(handler, opts...))
+}
+
+// WrapProblemJSON 与 WrapJSON 相同，但错误始终以 application/problem+json 输出。
+func WrapProblemJSON[Req, Resp any](handler HandlerFunc[Req, Resp], opts ...WrapOption) gin.HandlerFunc {
+	return withProblem(WrapJSON(handler, opts...))
+}
+
+// withProblem 为处理器启用 problem+json 错误输出。
+func withProblem(h gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Set(c, problemKey, true)
+		h(c)
+	}
+}
+
+// problemEnabled 判断当前请求的错误是否以 problem+json 输出。
+func problemEnabled(c *gin.Context) bool {
+	return getProblemOptions().Enabled || GetOrDefault(c, problemKey, false)
+}
+
+// writeProblem 以 application/problem+json 输出错误。
+func writeProblem(c *gin.Context, info errorInfo) {
+	p := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(info.status),
+		Status:   info.status,
+		Detail:   info.message,
+		Instance: c.Request.URL.Path,
+		Error:    info.detail,
+		Code:     info.code,
+	}
+	if base := getProblemOptions().TypeBaseURI; base != "" {
+		p.Type = base + strconv.Itoa(info.code)
+	}
+	if info.validation != nil {
+		p.Errors = info.validation.Errors
+	}
+
+	c.Header("Content-Type", MIMEProblemJSON)
+	c.Render(info.status, render.JSON{Data: p})
+}
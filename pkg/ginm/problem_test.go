@@ -0,0 +1,81 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+func failWith(err error) HandlerFunc[struct{}, string] {
+	return func(c *gin.Context, _ *struct{}) (string, error) { return "", err }
+}
+
+func TestWrapProblem_APIError(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/users/1",
+		WrapProblem(failWith(NewAPIError(http.StatusNotFound, 40401, "user not found").WithHeader("X-Trace", "abc"))))
+	w := serve(r, http.MethodGet, "/users/1", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+	assert.Equal(t, "abc", w.Header().Get("X-Trace"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user not found",
+		"instance": "/users/1",
+		"code": 40401
+	}`, w.Body.String())
+}
+
+func TestWrapProblem_ValidationErrors(t *testing.T) {
+	errs := gox.NewMultiError()
+	errs.AddLabeled("email", errors.New("is required"))
+	r := newHandlerEngine(http.MethodGet, "/v", WrapProblem(failWith(errs)))
+	w := serve(r, http.MethodGet, "/v", "")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "validation failed",
+		"instance": "/v",
+		"code": 422,
+		"errors": [{"field": "email", "message": "is required"}]
+	}`, w.Body.String())
+}
+
+func TestSetProblemOptions_AppliesToAllWrappers(t *testing.T) {
+	SetProblemOptions(ProblemOptions{Enabled: true, TypeBaseURI: "https://errors.example.com/"})
+	t.Cleanup(func() { SetProblemOptions(ProblemOptions{}) })
+
+	r := newHandlerEngine(http.MethodGet, "/boom", Wrap(failWith(errors.New("db down"))))
+	w := serve(r, http.MethodGet, "/boom", "")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "https://errors.example.com/500",
+		"title": "Internal Server Error",
+		"status": 500,
+		"detail": "internal server error",
+		"instance": "/boom",
+		"error": "db down",
+		"code": 500
+	}`, w.Body.String())
+}
+
+func TestHandleError_EnvelopeByDefault(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/x", Wrap(failWith(ErrBadRequest("bad"))))
+	w := serve(r, http.MethodGet, "/x", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.JSONEq(t, `{"message": "bad", "code": 400}`, w.Body.String())
+}
@@ -0,0 +1,151 @@
+package ginm
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryCacheConfig 包含查询参数绑定缓存的配置。
+type QueryCacheConfig struct {
+	// MaxEntries 限制缓存的不同查询串数量，达到上限时清空缓存。默认值: 1024
+	MaxEntries int
+}
+
+// queryCache 以原始查询串为键缓存绑定结果。
+type queryCache[T any] struct {
+	entries map[string]T
+	max     int
+	mu      sync.RWMutex
+}
+
+func (q *queryCache[T]) get(key string) (T, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	v, ok := q.entries[key]
+	return v, ok
+}
+
+func (q *queryCache[T]) put(key string, v T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) >= q.max {
+		clear(q.entries)
+	}
+	q.entries[key] = v
+}
+
+// WrapQueryCached 与 WrapQuery 相同，但对 GET/HEAD 请求按原始查询串缓存绑定结果，
+// 相同查询串的请求跳过基于反射的绑定。绑定失败的结果不会被缓存。
+// 钩子、链接、ETag 等 WrapOption 与其他 Wrap 系列函数一致，WithBinder 会替换缓存绑定。
+//
+// 每次命中都会返回缓存值的副本，其中的切片会被复制，处理器修改请求结构体不会影响缓存。
+// Req 含有 map、指针、接口、chan 或 func 字段时无法安全复制，WrapQueryCached 会直接 panic。
+// ctx 标签字段不参与缓存，每次请求都会重新注入。
+//
+//	r.GET("/search", ginm.WrapQueryCached(search, ginm.QueryCacheConfig{}))
+func WrapQueryCached[Req, Resp any](handler HandlerFunc[Req, Resp], cfg QueryCacheConfig, opts ...WrapOption) gin.HandlerFunc {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 1024
+	}
+	t := reflect.TypeFor[Req]()
+	if err := checkQueryCacheable(t, t.String(), ctxFields(t)); err != nil {
+		panic(err)
+	}
+	cache := &queryCache[Req]{entries: make(map[string]Req), max: cfg.MaxEntries}
+
+	w := newWrapper[Req, Resp](http.StatusOK, cache.bind, opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	}
+}
+
+// bind 绑定查询参数，GET/HEAD 请求优先使用缓存的绑定结果。
+func (q *queryCache[T]) bind(c *gin.Context) (*T, error) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return BindQuery[T](c)
+	}
+	key := c.Request.URL.RawQuery
+	bound, ok := q.get(key)
+	if !ok {
+		if err := c.ShouldBindQuery(&bound); err != nil {
+			return nil, bindError[T](c, "query", err)
+		}
+		q.put(key, bound)
+	}
+	req := &bound
+	cloneSlices(reflect.ValueOf(req).Elem())
+	bindContext(c, req)
+	return req, nil
+}
+
+// checkQueryCacheable 检查类型是否只包含可安全复制的字段。
+// skip 为顶层需要跳过的 ctx 标签字段。
+func checkQueryCacheable(t reflect.Type, path string, skip []ctxField) error {
+	switch t.Kind() {
+	case reflect.Map, reflect.Pointer, reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return fmt.Errorf("ginm: query cache cannot copy %s (%s)", path, t.Kind())
+	case reflect.Slice, reflect.Array:
+		return checkQueryCacheable(t.Elem(), path+"[]", nil)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() || isCtxField(skip, i) {
+				continue
+			}
+			if err := checkQueryCacheable(f.Type, path+"."+f.Name, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isCtxField(fields []ctxField, index int) bool {
+	for _, f := range fields {
+		if f.index == index {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneSlices 将值中所有导出的切片替换为副本，使其不再与缓存共享底层数组。
+func cloneSlices(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		for i := range cp.Len() {
+			cloneSlices(cp.Index(i))
+		}
+		v.Set(cp)
+	case reflect.Array:
+		for i := range v.Len() {
+			cloneSlices(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				cloneSlices(v.Field(i))
+			}
+		}
+	}
+}
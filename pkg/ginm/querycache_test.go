@@ -0,0 +1,178 @@
+package ginm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchQuery struct {
+	Client ClientInfo `ctx:"ginm:client_info" form:"-"`
+	Q      string     `form:"q" binding:"required"`
+	Tags   []string   `form:"tag"`
+	Page   int        `form:"page"`
+}
+
+// withClientIP 以 X-Test-IP 请求头作为客户端 IP 写入 Context。
+func withClientIP(c *gin.Context) {
+	Set(c, ClientInfoKey, ClientInfo{IP: c.GetHeader("X-Test-IP")})
+}
+
+func decodeSearch(t *testing.T, w *httptest.ResponseRecorder) searchQuery {
+	t.Helper()
+	var resp Response[searchQuery]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestWrapQueryCached_ReturnsCachedBinding(t *testing.T) {
+	h := func(c *gin.Context, req *searchQuery) (searchQuery, error) {
+		return *req, nil
+	}
+	r := newTestEngine(withClientIP)
+	r.GET("/search", WrapQueryCached(h, QueryCacheConfig{}))
+
+	for range 2 {
+		w := serve(r, http.MethodGet, "/search?q=go&tag=a&tag=b&page=2", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		got := decodeSearch(t, w)
+		assert.Equal(t, "go", got.Q)
+		assert.Equal(t, []string{"a", "b"}, got.Tags)
+		assert.Equal(t, 2, got.Page)
+	}
+}
+
+func TestWrapQueryCached_HandlerMutationsDoNotLeak(t *testing.T) {
+	h := func(c *gin.Context, req *searchQuery) (searchQuery, error) {
+		out := *req
+		out.Tags = append([]string(nil), req.Tags...)
+		req.Q = "mutated"
+		req.Tags[0] = "mutated"
+		return out, nil
+	}
+	r := newTestEngine(withClientIP)
+	r.GET("/search", WrapQueryCached(h, QueryCacheConfig{}))
+
+	for range 3 {
+		got := decodeSearch(t, serve(r, http.MethodGet, "/search?q=go&tag=a", ""))
+		assert.Equal(t, "go", got.Q)
+		assert.Equal(t, []string{"a"}, got.Tags)
+	}
+}
+
+func TestWrapQueryCached_InjectsContextPerRequest(t *testing.T) {
+	h := func(c *gin.Context, req *searchQuery) (searchQuery, error) {
+		return *req, nil
+	}
+	r := newTestEngine(withClientIP)
+	r.GET("/search", WrapQueryCached(h, QueryCacheConfig{}))
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		w := serve(r, http.MethodGet, "/search?q=go", "", "X-Test-IP", ip)
+		assert.Equal(t, ip, decodeSearch(t, w).Client.IP)
+	}
+}
+
+func TestWrapQueryCached_DoesNotCacheBindErrors(t *testing.T) {
+	calls := 0
+	h := func(c *gin.Context, req *searchQuery) (searchQuery, error) {
+		calls++
+		return *req, nil
+	}
+	r := newTestEngine(withClientIP)
+	r.GET("/search", WrapQueryCached(h, QueryCacheConfig{}))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodGet, "/search?page=1", "").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodGet, "/search?page=1", "").Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestWrapQueryCached_BypassesNonGET(t *testing.T) {
+	h := func(c *gin.Context, req *searchQuery) (searchQuery, error) {
+		return *req, nil
+	}
+	r := newTestEngine(withClientIP)
+	r.POST("/search", WrapQueryCached(h, QueryCacheConfig{}))
+
+	w := serve(r, http.MethodPost, "/search?q=post", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "post", decodeSearch(t, w).Q)
+}
+
+func TestWrapQueryCached_EvictsWhenFull(t *testing.T) {
+	r := newTestEngine()
+	r.GET("/search", WrapQueryCached(func(c *gin.Context, req *searchQuery) (string, error) {
+		return req.Q, nil
+	}, QueryCacheConfig{MaxEntries: 1}))
+
+	for _, q := range []string{"a", "b", "a"} {
+		var resp Response[string]
+		require.NoError(t, json.Unmarshal(serve(r, http.MethodGet, "/search?q="+q, "").Body.Bytes(), &resp))
+		assert.Equal(t, q, resp.Data)
+	}
+}
+
+func TestWrapQueryCached_AppliesWrapOptions(t *testing.T) {
+	var bound []string
+	r := newTestEngine()
+	r.GET("/search", WrapQueryCached(func(c *gin.Context, req *searchQuery) (string, error) {
+		return req.Q, nil
+	}, QueryCacheConfig{},
+		WithSuccessMessage("found"),
+		WithOnBind(func(c *gin.Context, req *searchQuery) error {
+			bound = append(bound, req.Q)
+			return nil
+		})))
+
+	for range 2 {
+		var resp Response[string]
+		require.NoError(t, json.Unmarshal(serve(r, http.MethodGet, "/search?q=a", "").Body.Bytes(), &resp))
+		assert.Equal(t, "found", resp.Message)
+	}
+	assert.Equal(t, []string{"a", "a"}, bound)
+}
+
+func TestWrapQueryCached_PanicsForUncopyableTypes(t *testing.T) {
+	type withMap struct {
+		Filters map[string]string `form:"filters"`
+	}
+	type withPtr struct {
+		Nested struct {
+			Limit *int `form:"limit"`
+		}
+	}
+
+	assert.Panics(t, func() {
+		WrapQueryCached(func(c *gin.Context, req *withMap) (int, error) { return 0, nil }, QueryCacheConfig{})
+	})
+	assert.Panics(t, func() {
+		WrapQueryCached(func(c *gin.Context, req *withPtr) (int, error) { return 0, nil }, QueryCacheConfig{})
+	})
+}
+
+func benchmarkQueryWrap(b *testing.B, h gin.HandlerFunc) {
+	r := newTestEngine()
+	r.GET("/search", h)
+	req := httptest.NewRequest(http.MethodGet, "/search?q=go&tag=a&tag=b&page=2", nil)
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		r.ServeHTTP(w, req)
+	}
+}
+
+func noopSearch(c *gin.Context, req *searchQuery) (int, error) { return req.Page, nil }
+
+func BenchmarkWrapQuery(b *testing.B) {
+	benchmarkQueryWrap(b, WrapQuery(noopSearch))
+}
+
+func BenchmarkWrapQueryCached(b *testing.B) {
+	benchmarkQueryWrap(b, WrapQueryCached(noopSearch, QueryCacheConfig{}))
+}
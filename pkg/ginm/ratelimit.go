@@ -0,0 +1,177 @@
+package ginm
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKeyFunc 返回请求所属的限流键，返回空字符串时不限流。
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// KeyByIP 按客户端 IP 限流，使用 WithClientInfo 时取其解析结果，否则使用 c.ClientIP()。
+func KeyByIP(c *gin.Context) string {
+	if info, ok := GetClientInfo(c); ok && info.IP != "" {
+		return "ip:" + info.IP
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByHeader 按请求头（例如 X-API-Key）限流，请求头为空时回退为按 IP 限流。
+func KeyByHeader(header string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		if v := c.GetHeader(header); v != "" {
+			return "key:" + v
+		}
+		return KeyByIP(c)
+	}
+}
+
+// RateLimitResult 是一次取令牌的结果。
+type RateLimitResult struct {
+	// Allowed 表示是否取到令牌。
+	Allowed bool
+	// Remaining 是取令牌后桶中剩余的整令牌数。
+	Remaining int
+	// RetryAfter 是未取到令牌时距下一个令牌可用的时间。
+	RetryAfter time.Duration
+	// Reset 是桶恢复满额所需的时间。
+	Reset time.Duration
+}
+
+// RateLimitStore 是令牌桶存储，实现需并发安全。多实例部署时应使用共享存储（例如基于 Redis Lua 脚本的实现），
+// 以便所有实例共享同一组令牌桶。
+type RateLimitStore interface {
+	// Take 从 key 对应的令牌桶中取一个令牌。桶容量为 limit，每 per 补满 limit 个令牌，新桶为满额。
+	Take(ctx context.Context, key string, limit int, per time.Duration) (RateLimitResult, error)
+}
+
+// RateLimitConfig 包含限流中间件的配置。
+type RateLimitConfig struct {
+	// Limit 是令牌桶容量，即允许的突发请求数。
+	Limit int
+	// Per 是补满 Limit 个令牌所需的时间，例如 Limit 100、Per 1m 表示平均每分钟 100 个请求。默认值: 1s
+	Per time.Duration
+	// Key 返回限流键。默认值: KeyByIP
+	Key RateLimitKeyFunc
+	// Store 是令牌桶存储。默认值: NewMemoryRateLimitStore()
+	Store RateLimitStore
+	// KeyPrefix 是存储中所有键的前缀，多个限流器共用一个存储时用于区分。默认值: "ginm:ratelimit:"
+	KeyPrefix string
+}
+
+// RateLimit 返回令牌桶限流中间件。每个响应都带有 X-RateLimit-Limit、X-RateLimit-Remaining 和
+// X-RateLimit-Reset（桶恢复满额的秒数）响应头，超出限制时返回 429 和 Retry-After 并中止请求。
+// 存储出错时放行请求，错误通过 c.Error 记录。
+//
+//	api.Use(ginm.RateLimit(ginm.RateLimitConfig{Limit: 100, Per: time.Minute, Key: ginm.KeyByHeader("X-API-Key")}))
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	if cfg.Limit <= 0 {
+		panic("RateLimit: Limit must be positive")
+	}
+	if cfg.Per <= 0 {
+		cfg.Per = time.Second
+	}
+	if cfg.Key == nil {
+		cfg.Key = KeyByIP
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryRateLimitStore()
+	}
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = "ginm:ratelimit:"
+	}
+	limit := strconv.Itoa(cfg.Limit)
+
+	return func(c *gin.Context) {
+		key := cfg.Key(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+		res, err := cfg.Store.Take(c.Request.Context(), cfg.KeyPrefix+key, cfg.Limit, cfg.Per)
+		if err != nil {
+			_ = c.Error(err)
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("X-RateLimit-Limit", limit)
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(res.Reset.Seconds())), 10))
+		if !res.Allowed {
+			handleError(c, ErrTooManyRequests("rate limit exceeded").WithRetryAfter(res.RetryAfter))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// --- 内存存储 ---
+
+// MemoryRateLimitStore 是进程内的 RateLimitStore 实现，已补满的令牌桶每取 1024 次令牌清理一次，
+// 适用于单实例部署和测试。
+type MemoryRateLimitStore struct {
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+	takes   int
+	mu      sync.Mutex
+}
+
+type tokenBucket struct {
+	last   time.Time
+	tokens float64
+}
+
+// NewMemoryRateLimitStore 创建新的内存令牌桶存储。
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket), now: time.Now}
+}
+
+// Take 实现 RateLimitStore。
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit int, per time.Duration) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	capacity := float64(limit)
+	rate := capacity / per.Seconds() // 每秒补充的令牌数
+	refill := func(b *tokenBucket) {
+		b.tokens = min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+		b.last = now
+	}
+
+	if s.takes++; s.takes%1024 == 0 {
+		for k, b := range s.buckets {
+			if refill(b); b.tokens >= capacity {
+				delete(s.buckets, k)
+			}
+		}
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: capacity, last: now}
+		s.buckets[key] = b
+	}
+	refill(b)
+
+	res := RateLimitResult{Allowed: b.tokens >= 1}
+	if res.Allowed {
+		b.tokens--
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = seconds((capacity - b.tokens) / rate)
+	return res, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
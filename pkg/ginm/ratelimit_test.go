@@ -0,0 +1,98 @@
+package ginm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time { return f.t }
+
+func TestRateLimit_BurstAndRefill(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	store := NewMemoryRateLimitStore()
+	store.now = clock.now
+	r := newTestEngine(RateLimit(RateLimitConfig{Limit: 2, Per: 10 * time.Second, Store: store}))
+	r.GET("/ping", okHandler)
+
+	w := serve(r, http.MethodGet, "/ping", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "5", w.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/ping", "").Code)
+
+	w = serve(r, http.MethodGet, "/ping", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.JSONEq(t, `{"code":429,"message":"rate limit exceeded"}`, w.Body.String())
+
+	clock.t = clock.t.Add(5 * time.Second)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/ping", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(r, http.MethodGet, "/ping", "").Code)
+}
+
+func TestRateLimit_KeyByHeader(t *testing.T) {
+	r := newTestEngine(RateLimit(RateLimitConfig{Limit: 1, Per: time.Hour, Key: KeyByHeader("X-API-Key")}))
+	r.GET("/ping", okHandler)
+	get := func(key string) int {
+		return serve(r, http.MethodGet, "/ping", "", "X-API-Key", key).Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("a"))
+	assert.Equal(t, http.StatusTooManyRequests, get("a"))
+	assert.Equal(t, http.StatusOK, get("b"))
+	assert.Equal(t, http.StatusOK, get(""))
+	assert.Equal(t, http.StatusTooManyRequests, get(""))
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, int, time.Duration) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("store down")
+}
+
+func TestRateLimit_FailsOpenAndSkipsEmptyKey(t *testing.T) {
+	r := newTestEngine(RateLimit(RateLimitConfig{Limit: 1, Store: failingRateLimitStore{}}))
+	r.GET("/ping", okHandler)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/ping", "").Code)
+
+	r = newTestEngine(RateLimit(RateLimitConfig{Limit: 1, Key: func(c *gin.Context) string { return "" }}))
+	r.GET("/ping", okHandler)
+	for range 3 {
+		w := serve(r, http.MethodGet, "/ping", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+	}
+}
+
+func TestMemoryRateLimitStore_PrunesFullBuckets(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	s := NewMemoryRateLimitStore()
+	s.now = clock.now
+	ctx := context.Background()
+	for i := range 1023 {
+		_, err := s.Take(ctx, strconv.Itoa(i), 1, time.Second)
+		require.NoError(t, err)
+	}
+	clock.t = clock.t.Add(time.Second)
+
+	// 第 1024 次取令牌时清理已补满的桶
+	_, err := s.Take(ctx, "last", 1, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, s.buckets, 1)
+}
+
+func TestRateLimit_PanicsWithoutLimit(t *testing.T) {
+	assert.Panics(t, func() { RateLimit(RateLimitConfig{}) })
+}
@@ -0,0 +1,118 @@
+package ginm
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionChecker 判断当前请求的主体是否具有角色或权限，实现通常读取认证中间件存入上下文的用户或声明。
+// 返回错误时按错误输出（例如未认证时返回 ErrUnauthorized）。
+type PermissionChecker interface {
+	HasRole(c *gin.Context, role string) (bool, error)
+	HasPermission(c *gin.Context, permission string) (bool, error)
+}
+
+// claimsChecker 是从类型化上下文值读取角色和权限的 PermissionChecker。
+type claimsChecker[T any] struct {
+	key         ContextKey[T]
+	roles       func(T) []string
+	permissions func(T) []string
+}
+
+// NewClaimsChecker 创建从上下文键 key 读取声明的 PermissionChecker，roles 和 permissions 返回声明中的角色和权限，
+// 为 nil 时视为没有角色或权限。上下文中没有声明时返回 401。
+//
+//	var ClaimsKey = ginm.NewContextKey[*Claims]("app:claims")
+//	checker := ginm.NewClaimsChecker(ClaimsKey,
+//		func(c *Claims) []string { return c.Roles },
+//		func(c *Claims) []string { return c.Scopes })
+func NewClaimsChecker[T any](key ContextKey[T], roles, permissions func(T) []string) PermissionChecker {
+	return &claimsChecker[T]{key: key, roles: roles, permissions: permissions}
+}
+
+func (ch *claimsChecker[T]) HasRole(c *gin.Context, role string) (bool, error) {
+	return ch.has(c, ch.roles, role)
+}
+
+func (ch *claimsChecker[T]) HasPermission(c *gin.Context, permission string) (bool, error) {
+	return ch.has(c, ch.permissions, permission)
+}
+
+func (ch *claimsChecker[T]) has(c *gin.Context, list func(T) []string, name string) (bool, error) {
+	claims, ok := Get(c, ch.key)
+	if !ok {
+		return false, ErrUnauthorized("authentication required")
+	}
+	return list != nil && slices.Contains(list(claims), name), nil
+}
+
+// RequireRole 返回要求主体具有任一指定角色的中间件，不满足时返回 403 并中止请求。
+//
+//	admin := r.Group("/admin", auth, ginm.RequireRole(checker, "admin", "owner"))
+func RequireRole(checker PermissionChecker, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, role := range roles {
+			ok, err := checker.HasRole(c, role)
+			if err != nil {
+				handleError(c, err)
+				c.Abort()
+				return
+			}
+			if ok {
+				c.Next()
+				return
+			}
+		}
+		handleError(c, ErrForbidden("insufficient role"))
+		c.Abort()
+	}
+}
+
+// RequirePermission 返回要求主体具有全部指定权限的中间件，不满足时返回 403 并中止请求。
+func RequirePermission(checker PermissionChecker, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, perm := range permissions {
+			ok, err := checker.HasPermission(c, perm)
+			if err != nil {
+				handleError(c, err)
+				c.Abort()
+				return
+			}
+			if !ok {
+				handleError(c, ErrForbidden("missing permission: "+perm))
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// --- 资源权限 ---
+
+// WithResourcePermissions 要求资源的每个动作具有 "<resource>:<action>" 权限，例如 "posts:list"、"posts:delete"。
+// 附加路由使用对应动作的权限（见 ResourceAction）。
+//
+//	ginm.RegisterResource(g, posts, ginm.WithResourcePermissions(checker, "posts"))
+func WithResourcePermissions(checker PermissionChecker, resource string) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		for a := ActionList; a <= ActionDelete; a++ {
+			withActionMiddleware([]gin.HandlerFunc{RequirePermission(checker, resource+":"+a.String())}, a)(cfg)
+		}
+	}
+}
+
+// WithActionPermission 要求指定动作具有全部权限。
+//
+//	ginm.WithActionPermission(checker, ginm.ActionDelete, "posts:delete", "posts:admin")
+func WithActionPermission(checker PermissionChecker, action ResourceAction, permissions ...string) ResourceOption {
+	return withActionMiddleware([]gin.HandlerFunc{RequirePermission(checker, permissions...)}, action)
+}
+
+// WithActionRole 要求指定动作具有任一角色，例如只允许管理员删除：
+//
+//	ginm.WithActionRole(checker, ginm.ActionDelete, "admin")
+func WithActionRole(checker PermissionChecker, action ResourceAction, roles ...string) ResourceOption {
+	return withActionMiddleware([]gin.HandlerFunc{RequireRole(checker, roles...)}, action)
+}
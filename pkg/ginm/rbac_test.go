@@ -0,0 +1,75 @@
+package ginm
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type rbacClaims struct {
+	Roles  []string
+	Scopes []string
+}
+
+var rbacClaimsKey = NewContextKey[*rbacClaims]("test:claims")
+
+var rbacChecker = NewClaimsChecker(rbacClaimsKey,
+	func(c *rbacClaims) []string { return c.Roles },
+	func(c *rbacClaims) []string { return c.Scopes })
+
+// rbacAuth 从 X-Roles 和 X-Scopes 请求头构造声明，缺少 X-Roles 时视为未认证。
+func rbacAuth(c *gin.Context) {
+	if roles, ok := c.Request.Header["X-Roles"]; ok {
+		Set(c, rbacClaimsKey, &rbacClaims{
+			Roles:  strings.Split(roles[0], ","),
+			Scopes: strings.Split(c.GetHeader("X-Scopes"), ","),
+		})
+	}
+	c.Next()
+}
+
+func TestRequireRoleAndPermission(t *testing.T) {
+	r := newTestEngine(rbacAuth)
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/admin", RequireRole(rbacChecker, "admin", "owner"), ok)
+	r.GET("/report", RequirePermission(rbacChecker, "report:read", "report:export"), ok)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(r, http.MethodGet, "/admin", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(r, http.MethodGet, "/admin", "", "X-Roles", "user,owner").Code)
+	w := serve(r, http.MethodGet, "/admin", "", "X-Roles", "user")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"code":403,"message":"insufficient role"}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNoContent,
+		serve(r, http.MethodGet, "/report", "", "X-Roles", "", "X-Scopes", "report:read,report:export").Code)
+	w = serve(r, http.MethodGet, "/report", "", "X-Roles", "", "X-Scopes", "report:read")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"code":403,"message":"missing permission: report:export"}`, w.Body.String())
+}
+
+func TestWithResourcePermissions(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: map[int]*hookPost{1: {ID: 1, Title: "a"}}}
+	RegisterResource(r.Group("/posts", rbacAuth), res,
+		WithResourcePermissions(rbacChecker, "posts"),
+		WithActionRole(rbacChecker, ActionDelete, "admin"),
+	)
+	req := func(method, path, body string, headers ...string) int {
+		return serve(r, method, path, body, headers...).Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, req(http.MethodPost, "/posts", `{"title":"b"}`, "X-Roles", "", "X-Scopes", "posts:list"))
+	assert.Equal(t, http.StatusCreated, req(http.MethodPost, "/posts", `{"title":"b"}`, "X-Roles", "", "X-Scopes", "posts:create"))
+	assert.Equal(t, http.StatusForbidden, req(http.MethodDelete, "/posts/1", "", "X-Roles", "user", "X-Scopes", "posts:delete"))
+	assert.Equal(t, http.StatusOK, req(http.MethodDelete, "/posts/1", "", "X-Roles", "admin", "X-Scopes", "posts:delete"))
+	assert.NotContains(t, res.posts, 1)
+}
+
+func TestResourceAction_String(t *testing.T) {
+	assert.Equal(t, "list", ActionList.String())
+	assert.Equal(t, "delete", ActionDelete.String())
+	assert.Equal(t, "action(9)", ResourceAction(9).String())
+}
@@ -0,0 +1,78 @@
+package ginm
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecoveryConfig 包含 Recovery 中间件的配置。
+type RecoveryConfig struct {
+	// Logger 是记录 panic 和调用栈的 slog.Logger。默认值: slog.Default()
+	Logger *slog.Logger
+}
+
+// Recovery 返回使用默认配置的 RecoveryWithConfig 中间件，用于代替 gin.Recovery()。
+func Recovery() gin.HandlerFunc {
+	return RecoveryWithConfig(RecoveryConfig{})
+}
+
+// RecoveryWithConfig 返回捕获 panic 的中间件，错误响应通过错误处理器以标准信封输出：
+//   - panic 值为错误且被识别为客户端错误（如 MustBind 系列抛出的绑定和校验错误）时，按该错误输出 4xx，不记录日志；
+//   - 其他 panic 记录调用栈并输出 500，非 release 模式下 error 字段包含 panic 值和调用栈。
+//
+// 客户端已断开（broken pipe、connection reset）时只记录日志不写响应；http.ErrAbortHandler 会被重新抛出。
+func RecoveryWithConfig(cfg RecoveryConfig) gin.HandlerFunc {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			err, isErr := rec.(error)
+			if isErr && classifyError(c, err).status < http.StatusInternalServerError {
+				if !c.Writer.Written() {
+					handleError(c, err)
+				}
+				c.Abort()
+				return
+			}
+
+			if isErr && isBrokenPipe(err) {
+				cfg.Logger.LogAttrs(c.Request.Context(), slog.LevelWarn, "client connection lost",
+					slog.String("method", c.Request.Method), slog.String("path", c.Request.URL.Path), slog.Any("error", err))
+				c.Abort()
+				return
+			}
+			stack := debug.Stack()
+			cfg.Logger.LogAttrs(c.Request.Context(), slog.LevelError, "panic recovered",
+				slog.String("method", c.Request.Method), slog.String("path", c.Request.URL.Path),
+				slog.Any("panic", rec), slog.String("stack", string(stack)))
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			handleError(c, ErrInternalWrap("internal server error", fmt.Errorf("panic: %v\n%s", rec, stack)))
+			c.Abort()
+		}()
+		c.Next()
+	}
+}
+
+// isBrokenPipe 判断错误是否由客户端断开连接引起。
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
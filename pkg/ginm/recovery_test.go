@@ -0,0 +1,73 @@
+package ginm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoveryWithLogs 返回记录日志到缓冲区的恢复中间件。
+func recoveryWithLogs() (gin.HandlerFunc, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return RecoveryWithConfig(RecoveryConfig{Logger: slog.New(slog.NewTextHandler(&buf, nil))}), &buf
+}
+
+func TestRecovery_InternalError(t *testing.T) {
+	recovery, logs := recoveryWithLogs()
+	r := newTestEngine(recovery)
+	r.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	w := serve(r, http.MethodGet, "/boom", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.EqualValues(t, 500, body["code"])
+	assert.Equal(t, "internal server error", body["message"])
+	assert.Contains(t, body["error"], "panic: boom")
+	assert.Contains(t, body["error"], "recovery_test.go")
+	assert.Contains(t, logs.String(), "panic recovered")
+
+	gin.SetMode(gin.ReleaseMode)
+	t.Cleanup(func() { gin.SetMode(gin.TestMode) })
+	w = serve(r, http.MethodGet, "/boom", "")
+	assert.JSONEq(t, `{"code":500,"message":"internal server error"}`, w.Body.String())
+}
+
+func TestRecovery_MustBindReturnsClientError(t *testing.T) {
+	recovery, logs := recoveryWithLogs()
+	r := newTestEngine(recovery)
+	r.GET("/bind", func(c *gin.Context) {
+		req := MustBindQuery[handlerReq](c)
+		Success(c, req.Name)
+	})
+
+	w := serve(r, http.MethodGet, "/bind", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Empty(t, logs.String())
+	assert.JSONEq(t, `{"code":0,"data":"a"}`, serve(r, http.MethodGet, "/bind?name=a", "").Body.String())
+}
+
+func TestRecovery_BrokenPipe(t *testing.T) {
+	recovery, logs := recoveryWithLogs()
+	r := newTestEngine(recovery)
+	r.GET("/pipe", func(c *gin.Context) { panic(fmt.Errorf("write: %w", syscall.EPIPE)) })
+
+	w := serve(r, http.MethodGet, "/pipe", "")
+	assert.Empty(t, w.Body.String())
+	assert.Contains(t, logs.String(), "client connection lost")
+}
+
+func TestRecovery_RethrowsErrAbortHandler(t *testing.T) {
+	r := newTestEngine(Recovery())
+	r.GET("/abort", func(c *gin.Context) { panic(http.ErrAbortHandler) })
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { serve(r, http.MethodGet, "/abort", "") })
+}
@@ -0,0 +1,126 @@
+// Package redisstore 提供基于 Redis 的 ginm.CacheStore 实现，适用于多实例部署共享响应缓存。
+//
+// ginm 目前没有幂等键中间件，也就没有对应的存储接口，因此本包只实现 CacheStore。
+// 幂等存储需要原子的"不存在时写入"（SET NX），将来加入时 Client 需相应扩展，不能复用 Set。
+//
+// 为避免引入 Redis 客户端依赖，Store 只依赖最小的 Client 接口，使用 go-redis 时的适配约十行：
+//
+//	type goRedis struct{ rdb *redis.Client }
+//
+//	func (g goRedis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+//	    b, err := g.rdb.Get(ctx, key).Bytes()
+//	    if errors.Is(err, redis.Nil) {
+//	        return nil, false, nil
+//	    }
+//	    return b, err == nil, err
+//	}
+//
+//	func (g goRedis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+//	    return g.rdb.Set(ctx, key, value, ttl).Err()
+//	}
+//
+//	func (g goRedis) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
+//	    return g.rdb.Scan(ctx, cursor, match, count).Result()
+//	}
+//
+//	func (g goRedis) Del(ctx context.Context, keys ...string) error {
+//	    return g.rdb.Del(ctx, keys...).Err()
+//	}
+//
+//	cache := ginm.NewResponseCache(ginm.CacheConfig{Store: redisstore.New(goRedis{rdb})})
+package redisstore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+// Client 是 Store 所需的最小 Redis 客户端接口。
+type Client interface {
+	// Get 返回键对应的值，键不存在时 ok 为 false 且 err 为 nil。
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set 写入键值并设置过期时间（SET key value PX ttl）。
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Scan 执行 SCAN cursor MATCH match COUNT count，返回本批键和下一个游标。
+	Scan(ctx context.Context, cursor uint64, match string, count int64) (keys []string, next uint64, err error)
+	// Del 删除键。
+	Del(ctx context.Context, keys ...string) error
+}
+
+// Option 配置 Store。
+type Option func(*Store)
+
+// WithScanCount 设置 DeletePrefix 中每次 SCAN 的 COUNT 提示。默认值: 100
+func WithScanCount(n int64) Option {
+	return func(s *Store) {
+		s.scanCount = n
+	}
+}
+
+// Store 是基于 Redis 的 ginm.CacheStore 实现，过期由 Redis 处理。
+type Store struct {
+	client    Client
+	scanCount int64
+}
+
+var _ ginm.CacheStore = (*Store)(nil)
+
+// New 创建基于 client 的 Store。
+func New(client Client, opts ...Option) *Store {
+	s := &Store{client: client, scanCount: 100}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.scanCount <= 0 {
+		s.scanCount = 100
+	}
+	return s
+}
+
+// Get 实现 ginm.CacheStore。
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return s.client.Get(ctx, key)
+}
+
+// Set 实现 ginm.CacheStore。
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl)
+}
+
+// DeletePrefix 实现 ginm.CacheStore，使用 SCAN 分批查找并删除键，不会阻塞 Redis。
+// 删除期间写入的键可能不会被删除。
+func (s *Store) DeletePrefix(ctx context.Context, prefix string) error {
+	match := escapeGlob(prefix) + "*"
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(ctx, cursor, match, s.scanCount)
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := s.client.Del(ctx, keys...); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// escapeGlob 转义 Redis MATCH 模式中的特殊字符。
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
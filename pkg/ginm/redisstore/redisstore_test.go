@@ -0,0 +1,124 @@
+package redisstore
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+// fakeClient 是内存中的 Client，Scan 仅支持 "<转义前缀>*" 形式的模式并按 count 分页。
+type fakeClient struct {
+	data    map[string][]byte
+	ttls    map[string]time.Duration
+	matches []string
+	scans   int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeClient) Get(_ context.Context, key string) ([]byte, bool, error) {
+	v, ok := f.data[key]
+	return v, ok, nil
+}
+
+func (f *fakeClient) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	f.data[key], f.ttls[key] = value, ttl
+	return nil
+}
+
+func (f *fakeClient) Scan(_ context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
+	f.scans++
+	f.matches = append(f.matches, match)
+	prefix := strings.NewReplacer(`\*`, "*", `\?`, "?", `\[`, "[", `\]`, "]", `\\`, `\`).Replace(strings.TrimSuffix(match, "*"))
+	keys := slices.Sorted(maps.Keys(f.data))
+	end := min(int(cursor)+int(count), len(keys))
+	var out []string
+	for _, k := range keys[cursor:end] {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, k)
+		}
+	}
+	if end == len(keys) {
+		return out, 0, nil
+	}
+	// 本批删除后剩余键前移，游标只需跳过未删除的键
+	return out, uint64(end - len(out)), nil
+}
+
+func (f *fakeClient) Del(_ context.Context, keys ...string) error {
+	for _, k := range keys {
+		delete(f.data, k)
+	}
+	return nil
+}
+
+func TestStore_GetSet(t *testing.T) {
+	client := newFakeClient()
+	s := New(client)
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "a", []byte("1"), time.Minute))
+	v, ok, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(v))
+	assert.Equal(t, time.Minute, client.ttls["a"])
+
+	_, ok, err = s.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestStore_DeletePrefix(t *testing.T) {
+	client := newFakeClient()
+	s := New(client, WithScanCount(2))
+	ctx := context.Background()
+	for _, k := range []string{"c:GET /a?", "c:GET /a?x=1", "c:GET /a/1?", "c:GET /b?", "c*GET /a?"} {
+		require.NoError(t, s.Set(ctx, k, nil, time.Minute))
+	}
+
+	require.NoError(t, s.DeletePrefix(ctx, "c:GET /a"))
+	assert.Equal(t, []string{"c*GET /a?", "c:GET /b?"}, slices.Sorted(maps.Keys(client.data)))
+	assert.Greater(t, client.scans, 1)
+
+	require.NoError(t, s.DeletePrefix(ctx, "c*"))
+	assert.Equal(t, `c\**`, client.matches[len(client.matches)-1])
+	assert.Len(t, client.data, 1)
+}
+
+func TestStore_WithResponseCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cache := ginm.NewResponseCache(ginm.CacheConfig{Store: New(newFakeClient()), InvalidateOnMutation: true})
+	r := gin.New()
+	calls := 0
+	r.GET("/items", cache.Middleware(), func(c *gin.Context) {
+		calls++
+		ginm.Success(c, calls)
+	})
+	r.POST("/items", cache.Middleware(), func(c *gin.Context) { c.Status(http.StatusCreated) })
+	serve := func(method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, "/items", nil))
+		return w
+	}
+
+	serve(http.MethodGet)
+	assert.Equal(t, "HIT", serve(http.MethodGet).Header().Get("X-Cache"))
+	serve(http.MethodPost)
+	w := serve(http.MethodGet)
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.JSONEq(t, `{"code":0,"data":2}`, w.Body.String())
+}
@@ -0,0 +1,266 @@
+package ginm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordedRequest 是一条被记录的请求，JSON 格式与 NDJSON 转储文件的每一行对应。
+type RecordedRequest struct {
+	Header http.Header `json:"header,omitempty"`
+	Method string      `json:"method"`
+	// Path 包含查询字符串，例如 /users?page=2。
+	Path string `json:"path"`
+	Body []byte `json:"body,omitempty"`
+}
+
+// LoadRecordedRequests 从 NDJSON 流中读取记录的请求，空行会被忽略。
+func LoadRecordedRequests(r io.Reader) ([]RecordedRequest, error) {
+	var reqs []RecordedRequest
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req RecordedRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, scanner.Err()
+}
+
+// DumpConfig 配置 Dump 中间件。
+type DumpConfig struct {
+	// Writer 接收 NDJSON 格式的 RecordedRequest，每个请求一行。必填。
+	Writer io.Writer
+	// Skip 返回 true 的请求不记录。
+	Skip func(c *gin.Context) bool
+	// RedactHeaders 列出记录前删除的请求头。默认值: Authorization、Cookie、Proxy-Authorization
+	RedactHeaders []string
+	// MaxBodySize 是记录的请求体上限，超过时不记录该请求（截断的请求体无法正确重放）。默认值: 1MB
+	MaxBodySize int64
+}
+
+// Dump 创建将请求以 RecordedRequest 的 NDJSON 格式写入 cfg.Writer 的中间件，
+// 输出可由 LoadRecordedRequests 读取后交给 Replay 或 ReplayAndDiff。
+// 请求在进入 handler 前记录，handler 仍能读取完整的请求体；写入失败时忽略。
+func Dump(cfg DumpConfig) gin.HandlerFunc {
+	if cfg.Writer == nil {
+		panic("ginm: DumpConfig.Writer is required")
+	}
+	if cfg.RedactHeaders == nil {
+		cfg.RedactHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = 1 << 20
+	}
+
+	var mu sync.Mutex
+	enc := json.NewEncoder(cfg.Writer)
+	return func(c *gin.Context) {
+		if cfg.Skip != nil && cfg.Skip(c) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, cfg.MaxBodySize+1))
+			// 已读取的部分放回请求体，handler 看到的内容与原请求一致
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+			if err != nil || int64(len(body)) > cfg.MaxBodySize {
+				c.Next()
+				return
+			}
+		}
+
+		header := c.Request.Header.Clone()
+		for _, name := range cfg.RedactHeaders {
+			header.Del(name)
+		}
+		rec := RecordedRequest{
+			Method: c.Request.Method,
+			Path:   c.Request.URL.RequestURI(),
+			Header: header,
+			Body:   body,
+		}
+		mu.Lock()
+		_ = enc.Encode(rec)
+		mu.Unlock()
+
+		c.Next()
+	}
+}
+
+// readCloser 组合读取来源和原请求体的 Close。
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// ReplayResult 是一条请求的重放结果。
+type ReplayResult struct {
+	// Err 不为 nil 时记录的方法或路径无效（例如转储文件损坏），请求未发送，其余字段为零值。
+	Err     error
+	Header  http.Header
+	Request RecordedRequest
+	Body    []byte
+	Status  int
+}
+
+// Replay 将记录的请求依次发送到 handler 并收集响应，无法构造的请求记录在对应结果的 Err 中。
+// handler 可以是测试用的 *gin.Engine；重放到其他环境时可使用
+// httputil.NewSingleHostReverseProxy 作为 handler。
+func Replay(handler http.Handler, reqs []RecordedRequest) []ReplayResult {
+	results := make([]ReplayResult, 0, len(reqs))
+	for _, rec := range reqs {
+		req, err := http.NewRequest(rec.Method, rec.Path, bytes.NewReader(rec.Body))
+		if err != nil {
+			results = append(results, ReplayResult{Request: rec, Err: err})
+			continue
+		}
+		req.RequestURI = rec.Path
+		for k, v := range rec.Header {
+			req.Header[k] = append([]string(nil), v...)
+		}
+		w := &replayRecorder{header: make(http.Header)}
+		handler.ServeHTTP(w, req)
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		results = append(results, ReplayResult{
+			Request: rec,
+			Status:  w.status,
+			Header:  w.header,
+			Body:    w.body.Bytes(),
+		})
+	}
+	return results
+}
+
+// replayRecorder 收集重放响应的状态码、响应头和响应体。
+type replayRecorder struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func (r *replayRecorder) Header() http.Header { return r.header }
+
+func (r *replayRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *replayRecorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(p)
+}
+
+// Flush 实现 http.Flusher，gin 的流式响应要求底层 Writer 支持刷新。
+func (r *replayRecorder) Flush() {}
+
+// ReplayDiff 描述同一请求在两个目标上的响应差异。
+type ReplayDiff struct {
+	// Fields 列出不同的字段: status、code、message、error、data 或 body（非信封响应），
+	// 请求无法构造时为 request。
+	Fields  []string
+	Request RecordedRequest
+	A       ReplayResult
+	B       ReplayResult
+}
+
+// ReplayAndDiff 将请求分别重放到 a 和 b，返回响应不一致的条目。
+// 两边都是 Response 信封（包含 code 的 JSON 对象）时按字段比较，data 按 JSON 语义比较（忽略键顺序和空白）；
+// 否则比较整个响应体，JSON 响应体同样按语义比较。无法构造的请求以 request 字段报告。
+func ReplayAndDiff(a, b http.Handler, reqs []RecordedRequest) []ReplayDiff {
+	resultsA := Replay(a, reqs)
+	resultsB := Replay(b, reqs)
+
+	var diffs []ReplayDiff
+	for i := range reqs {
+		fields := diffReplayResults(resultsA[i], resultsB[i])
+		if len(fields) > 0 {
+			diffs = append(diffs, ReplayDiff{
+				Request: reqs[i],
+				Fields:  fields,
+				A:       resultsA[i],
+				B:       resultsB[i],
+			})
+		}
+	}
+	return diffs
+}
+
+// diffReplayResults 比较两个响应，返回不同的字段名。
+func diffReplayResults(a, b ReplayResult) []string {
+	if a.Err != nil || b.Err != nil {
+		return []string{"request"}
+	}
+
+	var fields []string
+	if a.Status != b.Status {
+		fields = append(fields, "status")
+	}
+
+	envA, okA := decodeEnvelope(a.Body)
+	envB, okB := decodeEnvelope(b.Body)
+	if !okA || !okB {
+		if !jsonEqual(a.Body, b.Body) {
+			fields = append(fields, "body")
+		}
+		return fields
+	}
+
+	if envA.Code != envB.Code {
+		fields = append(fields, "code")
+	}
+	if envA.Message != envB.Message {
+		fields = append(fields, "message")
+	}
+	if envA.Error != envB.Error {
+		fields = append(fields, "error")
+	}
+	if !jsonEqual(envA.Data, envB.Data) {
+		fields = append(fields, "data")
+	}
+	return fields
+}
+
+// decodeEnvelope 将响应体解析为 Response 信封，响应体不是包含 code 的 JSON 对象时返回 false。
+func decodeEnvelope(body []byte) (Response[json.RawMessage], bool) {
+	var env Response[json.RawMessage]
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(body, &keys) != nil {
+		return env, false
+	}
+	if _, ok := keys["code"]; !ok {
+		return env, false
+	}
+	return env, json.Unmarshal(body, &env) == nil
+}
+
+// jsonEqual 按 JSON 语义比较两段原始 JSON。
+func jsonEqual(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,154 @@
+package ginm
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRecordedRequests_ParsesNDJSON(t *testing.T) {
+	input := `{"method":"GET","path":"/users?page=2"}
+
+{"method":"POST","path":"/users","header":{"Content-Type":["application/json"]},"body":"eyJuYW1lIjoiYSJ9"}
+`
+	reqs, err := LoadRecordedRequests(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "/users?page=2", reqs[0].Path)
+	assert.Equal(t, `{"name":"a"}`, string(reqs[1].Body))
+	assert.Equal(t, "application/json", reqs[1].Header.Get("Content-Type"))
+}
+
+func TestLoadRecordedRequests_ReturnsErrorForInvalidLine(t *testing.T) {
+	_, err := LoadRecordedRequests(strings.NewReader("{invalid\n"))
+	assert.Error(t, err)
+}
+
+func TestReplay_SendsRequestsToHandler(t *testing.T) {
+	r := newTestEngine()
+	r.POST("/echo", func(c *gin.Context) {
+		body, _ := c.GetRawData()
+		c.Data(http.StatusOK, c.GetHeader("Content-Type"), body)
+	})
+
+	results := Replay(r, []RecordedRequest{{
+		Method: http.MethodPost,
+		Path:   "/echo",
+		Header: http.Header{"Content-Type": {"text/plain"}},
+		Body:   []byte("hello"),
+	}})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, http.StatusOK, results[0].Status)
+	assert.Equal(t, "hello", string(results[0].Body))
+}
+
+func TestReplayAndDiff_ReportsEnvelopeDifferences(t *testing.T) {
+	oldEngine := newTestEngine()
+	oldEngine.GET("/users/1", func(c *gin.Context) {
+		c.JSON(http.StatusOK, OK(map[string]any{"id": 1, "name": "a"}))
+	})
+	oldEngine.GET("/users/2", func(c *gin.Context) {
+		c.JSON(http.StatusOK, OK(map[string]any{"id": 2}))
+	})
+
+	newEngine := newTestEngine()
+	newEngine.GET("/users/1", func(c *gin.Context) {
+		c.String(http.StatusOK, `{"data":{"name":"a","id":1},"code":0}`)
+	})
+	newEngine.GET("/users/2", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, Fail[any](404, "not found"))
+	})
+
+	diffs := ReplayAndDiff(oldEngine, newEngine, []RecordedRequest{
+		{Method: http.MethodGet, Path: "/users/1"},
+		{Method: http.MethodGet, Path: "/users/2"},
+	})
+
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "/users/2", diffs[0].Request.Path)
+	assert.Equal(t, []string{"status", "code", "message", "data"}, diffs[0].Fields)
+}
+
+func TestReplayAndDiff_ComparesNonEnvelopeBodies(t *testing.T) {
+	a := newTestEngine()
+	a.GET("/", func(c *gin.Context) { c.String(http.StatusOK, "v1") })
+	b := newTestEngine()
+	b.GET("/", func(c *gin.Context) { c.String(http.StatusOK, "v2") })
+
+	diffs := ReplayAndDiff(a, b, []RecordedRequest{{Method: http.MethodGet, Path: "/"}})
+	require.Len(t, diffs, 1)
+	assert.Equal(t, []string{"body"}, diffs[0].Fields)
+}
+
+func TestReplayAndDiff_ComparesNonEnvelopeJSON(t *testing.T) {
+	handler := func(foo int) *gin.Engine {
+		r := newTestEngine()
+		r.GET("/raw", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"foo": foo, "bar": true}) })
+		return r
+	}
+	same := newTestEngine()
+	same.GET("/raw", func(c *gin.Context) { c.String(http.StatusOK, `{ "bar": true, "foo": 1 }`) })
+	reqs := []RecordedRequest{{Method: http.MethodGet, Path: "/raw"}}
+
+	diffs := ReplayAndDiff(handler(1), handler(2), reqs)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, []string{"body"}, diffs[0].Fields)
+	assert.Empty(t, ReplayAndDiff(handler(1), same, reqs))
+}
+
+func TestReplay_ReportsInvalidRecordedRequest(t *testing.T) {
+	r := newTestEngine()
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	reqs := []RecordedRequest{
+		{Method: "BAD METHOD", Path: "/ok"},
+		{Method: http.MethodGet, Path: "%zz"},
+		{Method: http.MethodGet, Path: "/ok"},
+	}
+
+	results := Replay(r, reqs)
+	require.Len(t, results, 3)
+	require.Error(t, results[0].Err)
+	require.Error(t, results[1].Err)
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, http.StatusNoContent, results[2].Status)
+
+	diffs := ReplayAndDiff(r, r, reqs)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, []string{"request"}, diffs[0].Fields)
+}
+
+func TestDump_RecordsRequestsForReplay(t *testing.T) {
+	var buf bytes.Buffer
+	var seen []string
+	r := newTestEngine(Dump(DumpConfig{Writer: &buf, MaxBodySize: 16}))
+	r.POST("/echo", func(c *gin.Context) {
+		body, _ := c.GetRawData()
+		seen = append(seen, string(body))
+		c.Data(http.StatusOK, c.GetHeader("Content-Type"), body)
+	})
+
+	send := func(body string) {
+		serve(r, http.MethodPost, "/echo?v=1", body, "Content-Type", "text/plain", "Authorization", "Bearer secret")
+	}
+	send("hello")
+	send("this body is longer than the limit")
+	assert.Equal(t, []string{"hello", "this body is longer than the limit"}, seen)
+
+	reqs, err := LoadRecordedRequests(&buf)
+	require.NoError(t, err)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "/echo?v=1", reqs[0].Path)
+	assert.Empty(t, reqs[0].Header.Get("Authorization"))
+
+	results := Replay(r, reqs)
+	require.Len(t, results, 1)
+	assert.Equal(t, http.StatusOK, results[0].Status)
+	assert.Equal(t, "hello", string(results[0].Body))
+	assert.Equal(t, "text/plain", results[0].Header.Get("Content-Type"))
+}
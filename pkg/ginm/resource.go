@@ -2,6 +2,9 @@ package ginm
 
 import (
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -53,10 +56,30 @@ func (r *BaseResource[T, ID, CI, UI, LQ]) Delete(c *gin.Context, id ID) error {
 	return ErrNotImplemented("Delete")
 }
 
+// Exister 是资源可选实现的存在性检查接口。实现后注册 HEAD /:id 路由，
+// 元素存在时返回 200，否则返回 404，均不带响应体，比 Get 更适合客户端缓存校验。
+type Exister[ID comparable] interface {
+	Exists(c *gin.Context, id ID) (bool, error)
+}
+
 // ResourceConfig 包含资源注册的配置选项。
 type ResourceConfig struct {
+	// OpenAPI 不为 nil 时记录资源路由的文档。
+	OpenAPI *OpenAPI
+	// middleware 是 WithListMiddleware 等选项注册的单个路由中间件。
+	middleware map[ResourceAction][]gin.HandlerFunc
+	// disabled 是 WithOnly、WithExcept 排除的动作。
+	disabled map[ResourceAction]bool
+	// hooks 是 WithBeforeCreate 等选项注册的生命周期钩子。
+	hooks map[hookKind][]any
+	// bindIncludes 由 WithIncludes 设置，在 List 和 Get 前解析 include 参数。
+	bindIncludes func(c *gin.Context) error
 	// IDParam 是 URI 中 ID 参数的名称。默认值: "id"
 	IDParam string
+	// BatchCreateLimit 大于 0 时注册 POST /batch 批量创建路由，值为单次最大元素数。见 WithBatchCreate。
+	BatchCreateLimit int
+	// BulkDeleteLimit 大于 0 时注册 POST /delete-batch 批量删除路由，值为单次最大 ID 数。见 WithBulkDelete。
+	BulkDeleteLimit int
 }
 
 // ResourceOption 是资源注册的函数式选项。
@@ -69,13 +92,142 @@ func WithIDParam(name string) ResourceOption {
 	}
 }
 
+// WithOpenAPI 将资源路由记录到 OpenAPI 文档。
+func WithOpenAPI(api *OpenAPI) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		cfg.OpenAPI = api
+	}
+}
+
+// ResourceAction 是资源的路由动作，用于 WithOnly 和 WithExcept。
+// 附加路由随对应动作启用：HEAD /:id 随 Get，POST /search 随 List，
+// POST /batch 随 Create，POST /delete-batch 随 Delete。
+type ResourceAction int
+
+const (
+	ActionList ResourceAction = iota
+	ActionGet
+	ActionCreate
+	ActionUpdate
+	ActionDelete
+)
+
+// String 返回动作的小写名称，例如 "list"，用于 WithResourcePermissions 生成的权限名。
+func (a ResourceAction) String() string {
+	switch a {
+	case ActionList:
+		return "list"
+	case ActionGet:
+		return "get"
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	}
+	return "action(" + strconv.Itoa(int(a)) + ")"
+}
+
+// WithOnly 只注册指定动作的路由，避免只读或部分实现的资源暴露返回 501 的路由：
+//
+//	ginm.RegisterResource(g, res, ginm.WithOnly(ginm.ActionList, ginm.ActionGet, ginm.ActionCreate))
+//
+// 多次使用时取交集。
+func WithOnly(actions ...ResourceAction) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		for a := ActionList; a <= ActionDelete; a++ {
+			if !slices.Contains(actions, a) {
+				cfg.disable(a)
+			}
+		}
+	}
+}
+
+// WithExcept 不注册指定动作的路由，例如禁止删除：ginm.WithExcept(ginm.ActionDelete)。
+func WithExcept(actions ...ResourceAction) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		for _, a := range actions {
+			cfg.disable(a)
+		}
+	}
+}
+
+func (cfg *ResourceConfig) disable(action ResourceAction) {
+	if cfg.disabled == nil {
+		cfg.disabled = make(map[ResourceAction]bool)
+	}
+	cfg.disabled[action] = true
+}
+
+// enabled 判断动作的路由是否需要注册。
+func (cfg *ResourceConfig) enabled(action ResourceAction) bool {
+	return !cfg.disabled[action]
+}
+
+// withActionMiddleware 返回为指定动作追加中间件的资源选项。
+func withActionMiddleware(middlewares []gin.HandlerFunc, actions ...ResourceAction) ResourceOption {
+	return func(cfg *ResourceConfig) {
+		if cfg.middleware == nil {
+			cfg.middleware = make(map[ResourceAction][]gin.HandlerFunc)
+		}
+		for _, a := range actions {
+			cfg.middleware[a] = append(cfg.middleware[a], middlewares...)
+		}
+	}
+}
+
+// WithListMiddleware 为 List 路由添加中间件，在分组中间件之后、处理器之前执行。
+func WithListMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionList)
+}
+
+// WithGetMiddleware 为 Get 路由添加中间件。
+func WithGetMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionGet)
+}
+
+// WithCreateMiddleware 为 Create 路由添加中间件。
+func WithCreateMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionCreate)
+}
+
+// WithUpdateMiddleware 为 Update 路由添加中间件。
+func WithUpdateMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionUpdate)
+}
+
+// WithDeleteMiddleware 为 Delete 路由添加中间件。
+func WithDeleteMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionDelete)
+}
+
+// WithWriteMiddleware 为 Create、Update 和 Delete 路由添加中间件，例如要求管理员权限而读取保持公开：
+//
+//	ginm.RegisterResource(g, res, ginm.WithWriteMiddleware(RequireAdmin()))
+func WithWriteMiddleware(middlewares ...gin.HandlerFunc) ResourceOption {
+	return withActionMiddleware(middlewares, ActionCreate, ActionUpdate, ActionDelete)
+}
+
+// handlers 返回动作的中间件和处理器。
+func (cfg *ResourceConfig) handlers(action ResourceAction, h gin.HandlerFunc) []gin.HandlerFunc {
+	mw := cfg.middleware[action]
+	return append(mw[:len(mw):len(mw)], h)
+}
+
 // RegisterResource 为资源注册所有 CRUD 路由。
 // 创建的路由:
 //   - GET    /           -> List
 //   - GET    /:id        -> Get
+//   - HEAD   /:id        -> Exists（资源实现 Exister 时注册）
+//   - POST   /search     -> Search（资源实现 Searcher 时注册）
 //   - POST   /           -> Create
 //   - PUT    /:id        -> Update
 //   - DELETE /:id        -> Delete
+//   - POST   /batch      -> Create（逐个调用，由 WithBatchCreate 启用）
+//   - POST   /delete-batch -> Delete 或 BulkDeleter.DeleteMany（由 WithBulkDelete 启用）
+//
+// 使用 WithOnly 或 WithExcept 可只注册部分路由。
 func RegisterResource[T any, ID comparable, CI any, UI any, LQ any](
 	group *gin.RouterGroup,
 	resource Resource[T, ID, CI, UI, LQ],
@@ -86,143 +238,276 @@ func RegisterResource[T any, ID comparable, CI any, UI any, LQ any](
 		opt(cfg)
 	}
 
+	hooks := resolveHooks[T, ID, CI, UI](cfg)
+
 	idPath := "/:" + cfg.IDParam
+	if cfg.OpenAPI != nil {
+		documentResource[T, ID, CI, UI, LQ](cfg.OpenAPI, group.BasePath(), idPath, cfg)
+	}
 
 	// GET / - 列表
-	group.GET("", func(c *gin.Context) {
-		query, err := BindQuery[LQ](c)
+	if cfg.enabled(ActionList) {
+		group.GET("", cfg.handlers(ActionList, resourceListHandler(resource, cfg))...)
+	}
+
+	// GET /:id - 获取
+	if cfg.enabled(ActionGet) {
+		group.GET(idPath, cfg.handlers(ActionGet, resourceGetHandler(resource, cfg))...)
+	}
+
+	// HEAD /:id - 存在性检查
+	registerExists(group, resource, cfg, idPath)
+
+	// POST /search - 搜索
+	registerSearch(group, resource, cfg)
+
+	// POST / - 创建
+	if cfg.enabled(ActionCreate) {
+		group.POST("", cfg.handlers(ActionCreate, resourceCreateHandler(resource, hooks))...)
+	}
+
+	// PUT /:id - 更新
+	if cfg.enabled(ActionUpdate) {
+		group.PUT(idPath, cfg.handlers(ActionUpdate, resourceUpdateHandler(resource, hooks))...)
+	}
+
+	// DELETE /:id - 删除
+	if cfg.enabled(ActionDelete) {
+		group.DELETE(idPath, cfg.handlers(ActionDelete, resourceDeleteHandler(resource, hooks))...)
+	}
+
+	// POST /batch - 批量创建
+	registerBatchCreate(group, resource, cfg, hooks)
+
+	// POST /delete-batch - 批量删除
+	registerBulkDelete(group, resource, cfg, hooks)
+}
+
+// RegisterResourceReadOnly 仅注册只读路由（List、Get，以及资源实现 Exister、Searcher 时的 HEAD /:id 和 POST /search），
+// 等价于附加 WithOnly(ActionList, ActionGet) 的 RegisterResource。
+func RegisterResourceReadOnly[T any, ID comparable, CI any, UI any, LQ any](
+	group *gin.RouterGroup,
+	resource Resource[T, ID, CI, UI, LQ],
+	opts ...ResourceOption,
+) {
+	RegisterResource(group, resource, append(opts[:len(opts):len(opts)], WithOnly(ActionList, ActionGet))...)
+}
+
+// withIncludes 返回先执行 bind、再解析 include 参数的绑定函数，bindIncludes 为 nil 时返回 bind。
+func withIncludes[Req any](bind func(c *gin.Context) (*Req, error), bindIncludes func(c *gin.Context) error) func(c *gin.Context) (*Req, error) {
+	if bindIncludes == nil {
+		return bind
+	}
+	return func(c *gin.Context) (*Req, error) {
+		req, err := bind(c)
 		if err != nil {
-			handleError(c, err)
+			return nil, err
+		}
+		if err := bindIncludes(c); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+}
+
+// resourceListHandler 创建资源的 List 路由处理器。
+func resourceListHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+) gin.HandlerFunc {
+	w := newWrapper[LQ, PageResponse[T]](http.StatusOK, withIncludes(BindQuery[LQ], cfg.bindIncludes), nil)
+	return func(c *gin.Context) {
+		query, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		resp, err := resource.List(c, query)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, query, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
-	})
+		writePageHeaders(c, resp)
+		w.respond(c, query, resp)
+	}
+}
 
-	// GET /:id - 获取
-	group.GET(idPath, func(c *gin.Context) {
-		idParam, err := BindURI[IDParam[ID]](c)
-		if err != nil {
-			handleError(c, err)
+// resourceGetHandler 创建资源的 Get 路由处理器。
+func resourceGetHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+) gin.HandlerFunc {
+	w := newWrapper[IDParam[ID], *T](http.StatusOK, withIncludes(BindURI[IDParam[ID]], cfg.bindIncludes), nil)
+	return func(c *gin.Context) {
+		idParam, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
 		item, err := resource.Get(c, idParam.ID)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, idParam, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(item))
-	})
+		w.respond(c, idParam, item)
+	}
+}
 
-	// POST / - 创建
-	group.POST("", func(c *gin.Context) {
-		input, err := BindJSON[CI](c)
+// registerExists 在资源实现 Exister 时注册 HEAD /:id 路由，使用 Get 的中间件。
+func registerExists[T any, ID comparable, CI any, UI any, LQ any](
+	group *gin.RouterGroup,
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+	idPath string,
+) {
+	exister, ok := resource.(Exister[ID])
+	if !ok || !cfg.enabled(ActionGet) {
+		return
+	}
+	if cfg.OpenAPI != nil {
+		cfg.OpenAPI.add(&openAPIOperation{
+			method: http.MethodHead, path: joinRoutePath(group.BasePath(), idPath),
+			params: reflect.TypeFor[IDParam[ID]](), status: http.StatusOK,
+		})
+	}
+	group.HEAD(idPath, cfg.handlers(ActionGet, resourceExistsHandler(exister))...)
+}
+
+// resourceExistsHandler 创建资源的 HEAD /:id 路由处理器。
+func resourceExistsHandler[ID comparable](exister Exister[ID]) gin.HandlerFunc {
+	w := newWrapper[IDParam[ID], bool](http.StatusOK, BindURI[IDParam[ID]], nil)
+	return func(c *gin.Context) {
+		idParam, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		exists, err := exister.Exists(c, idParam.ID)
+		if err != nil {
+			w.fail(c, idParam, err)
+			return
+		}
+
+		w.succeed(c, idParam, exists)
+		if !exists {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+// resourceCreateHandler 创建资源的 Create 路由处理器。
+func resourceCreateHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	hooks resourceHooks[T, ID, CI, UI],
+) gin.HandlerFunc {
+	w := newWrapper[CI, *T](http.StatusCreated, BindJSON[CI], nil)
+	return func(c *gin.Context) {
+		input, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		err := runHooks(hooks.beforeCreate, func(fn func(*gin.Context, *CI) error) error { return fn(c, input) })
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, input, err)
 			return
 		}
 
 		item, err := resource.Create(c, input)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, input, err)
+			return
+		}
+
+		err = runHooks(hooks.afterCreate, func(fn func(*gin.Context, *CI, *T) error) error { return fn(c, input, item) })
+		if err != nil {
+			w.fail(c, input, err)
 			return
 		}
 
-		c.JSON(http.StatusCreated, OK(item))
-	})
+		w.respond(c, input, item)
+	}
+}
 
-	// PUT /:id - 更新
-	group.PUT(idPath, func(c *gin.Context) {
+// resourceUpdateHandler 创建资源的 Update 路由处理器，处理器钩子的 req 为请求体 *UI。
+func resourceUpdateHandler[T any, ID comparable, CI any, UI any, LQ any](
+	resource Resource[T, ID, CI, UI, LQ],
+	hooks resourceHooks[T, ID, CI, UI],
+) gin.HandlerFunc {
+	w := newWrapper[UI, *T](http.StatusOK, nil, nil)
+	return func(c *gin.Context) {
 		idParam, err := BindURI[IDParam[ID]](c)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, nil, err)
 			return
 		}
 
 		input, err := BindJSON[UI](c)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, nil, err)
+			return
+		}
+		if !w.bound(c, input) {
 			return
 		}
 
-		item, err := resource.Update(c, idParam.ID, input)
+		id := idParam.ID
+		err = runHooks(hooks.beforeUpdate, func(fn func(*gin.Context, ID, *UI) error) error { return fn(c, id, input) })
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, input, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(item))
-	})
-
-	// DELETE /:id - 删除
-	group.DELETE(idPath, func(c *gin.Context) {
-		idParam, err := BindURI[IDParam[ID]](c)
+		item, err := resource.Update(c, id, input)
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, input, err)
 			return
 		}
 
-		if err := resource.Delete(c, idParam.ID); err != nil {
-			handleError(c, err)
+		err = runHooks(hooks.afterUpdate, func(fn func(*gin.Context, ID, *UI, *T) error) error { return fn(c, id, input, item) })
+		if err != nil {
+			w.fail(c, input, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK[any](nil))
-	})
+		w.respond(c, input, item)
+	}
 }
 
-// RegisterResourceReadOnly 仅注册只读路由（List 和 Get）。
-func RegisterResourceReadOnly[T any, ID comparable, CI any, UI any, LQ any](
-	group *gin.RouterGroup,
+// resourceDeleteHandler 创建资源的 Delete 路由处理器。
+func resourceDeleteHandler[T any, ID comparable, CI any, UI any, LQ any](
 	resource Resource[T, ID, CI, UI, LQ],
-	opts ...ResourceOption,
-) {
-	cfg := &ResourceConfig{IDParam: "id"}
-	for _, opt := range opts {
-		opt(cfg)
-	}
-
-	idPath := "/:" + cfg.IDParam
-
-	// GET / - 列表
-	group.GET("", func(c *gin.Context) {
-		query, err := BindQuery[LQ](c)
-		if err != nil {
-			handleError(c, err)
+	hooks resourceHooks[T, ID, CI, UI],
+) gin.HandlerFunc {
+	w := newWrapper[IDParam[ID], any](http.StatusOK, BindURI[IDParam[ID]], nil)
+	return func(c *gin.Context) {
+		idParam, ok := w.bind(c)
+		if !ok {
 			return
 		}
 
-		resp, err := resource.List(c, query)
+		id := idParam.ID
+		err := runHooks(hooks.beforeDelete, func(fn func(*gin.Context, ID) error) error { return fn(c, id) })
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, idParam, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(resp))
-	})
-
-	// GET /:id - 获取
-	group.GET(idPath, func(c *gin.Context) {
-		idParam, err := BindURI[IDParam[ID]](c)
-		if err != nil {
-			handleError(c, err)
+		if err := resource.Delete(c, id); err != nil {
+			w.fail(c, idParam, err)
 			return
 		}
 
-		item, err := resource.Get(c, idParam.ID)
+		err = runHooks(hooks.afterDelete, func(fn func(*gin.Context, ID) error) error { return fn(c, id) })
 		if err != nil {
-			handleError(c, err)
+			w.fail(c, idParam, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, OK(item))
-	})
+		w.respond(c, idParam, nil)
+	}
 }
@@ -0,0 +1,132 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// requireHeader 返回要求请求头存在的中间件，缺失时返回 403。
+func requireHeader(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(name) == "" {
+			Error(c, http.StatusForbidden, http.StatusForbidden, "forbidden")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func TestRegisterResource_PerActionMiddleware(t *testing.T) {
+	r := newTestEngine()
+	res := &hookPostResource{posts: map[int]*hookPost{1: {ID: 1, Title: "a"}}}
+	var listed int
+	RegisterResource(r.Group("/posts"), res,
+		WithWriteMiddleware(requireHeader("X-Admin")),
+		WithDeleteMiddleware(requireHeader("X-Confirm")),
+		WithListMiddleware(func(c *gin.Context) { listed++ }),
+	)
+
+	assert.Equal(t, http.StatusNotImplemented, serve(r, http.MethodGet, "/posts", "").Code)
+	assert.Equal(t, 1, listed)
+	assert.Equal(t, http.StatusNotImplemented, serve(r, http.MethodGet, "/posts/1", "").Code)
+	assert.Equal(t, 1, listed)
+
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPost, "/posts", `{"title":"b"}`).Code)
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPut, "/posts/1", `{"title":"b"}`).Code)
+	assert.Len(t, res.posts, 1)
+
+	req := func(method, path, body string, headers ...string) int {
+		return serve(r, method, path, body, headers...).Code
+	}
+	assert.Equal(t, http.StatusCreated, req(http.MethodPost, "/posts", `{"title":"b"}`, "X-Admin", "1"))
+	assert.Equal(t, http.StatusForbidden, req(http.MethodDelete, "/posts/1", "", "X-Admin", "1"))
+	assert.Equal(t, http.StatusOK, req(http.MethodDelete, "/posts/1", "", "X-Admin", "1", "X-Confirm", "1"))
+	assert.NotContains(t, res.posts, 1)
+}
+
+func TestRegisterResourceReadOnly_PerActionMiddleware(t *testing.T) {
+	r := newTestEngine()
+	RegisterResourceReadOnly(r.Group("/posts"), &hookPostResource{}, WithGetMiddleware(requireHeader("X-Token")))
+
+	assert.Equal(t, http.StatusNotImplemented, serve(r, http.MethodGet, "/posts", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/posts/1", "").Code)
+}
+
+// existsResource 实现 Exister。
+type existsResource struct {
+	hookPostResource
+}
+
+func (r *existsResource) Exists(c *gin.Context, id int) (bool, error) {
+	if id < 0 {
+		return false, ErrBadRequest("negative id")
+	}
+	_, ok := r.posts[id]
+	return ok, nil
+}
+
+func TestRegisterResource_HeadExists(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{})
+	res := &existsResource{hookPostResource{posts: map[int]*hookPost{1: {ID: 1}}}}
+	RegisterResource(r.Group("/posts"), res, WithOpenAPI(api), WithGetMiddleware(requireHeader("X-Token")))
+
+	w := serve(r, http.MethodHead, "/posts/1", "", "X-Token", "1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+	w = serve(r, http.MethodHead, "/posts/2", "", "X-Token", "1")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodHead, "/posts/-1", "", "X-Token", "1").Code)
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodHead, "/posts/1", "").Code)
+	assert.NotNil(t, dig(specJSON(t, api), "paths", "/posts/{id}", "head", "responses", "200"))
+
+	r = newTestEngine()
+	RegisterResourceReadOnly(r.Group("/posts"), res)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodHead, "/posts/1", "").Code)
+
+	r = newTestEngine()
+	RegisterResource(r.Group("/posts"), &res.hookPostResource)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodHead, "/posts/1", "").Code)
+}
+
+func TestRegisterResource_RouteSubset(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{})
+	res := &existsResource{hookPostResource{posts: map[int]*hookPost{1: {ID: 1}}}}
+	RegisterResource(r.Group("/posts"), res, WithOpenAPI(api),
+		WithOnly(ActionList, ActionGet, ActionCreate, ActionDelete), WithExcept(ActionGet),
+		WithBatchCreate(0), WithBulkDelete(0))
+
+	assert.Equal(t, http.StatusNotImplemented, serve(r, http.MethodGet, "/posts", "").Code)
+	assert.Equal(t, http.StatusCreated, serve(r, http.MethodPost, "/posts/batch", `[{"title":"a"}]`).Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPost, "/posts/delete-batch", `{"ids":[2]}`).Code)
+
+	for _, req := range [][2]string{
+		{http.MethodGet, "/posts/1"},
+		{http.MethodHead, "/posts/1"},
+		{http.MethodPut, "/posts/1"},
+	} {
+		assert.Equal(t, http.StatusNotFound, serve(r, req[0], req[1], `{}`).Code, req)
+	}
+
+	paths := dig(specJSON(t, api), "paths").(map[string]any)
+	assert.NotNil(t, dig(paths, "/posts", "get"))
+	assert.NotNil(t, dig(paths, "/posts", "post"))
+	assert.NotNil(t, dig(paths, "/posts/{id}", "delete"))
+	assert.Nil(t, dig(paths, "/posts/{id}", "get"))
+	assert.Nil(t, dig(paths, "/posts/{id}", "put"))
+}
+
+func TestRegisterResourceReadOnly_SkipsWriteRoutes(t *testing.T) {
+	r := newTestEngine()
+	RegisterResourceReadOnly(r.Group("/posts"), &hookPostResource{}, WithBatchCreate(0))
+
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/posts", `{}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/posts/batch", `[{}]`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodDelete, "/posts/1", "").Code)
+}
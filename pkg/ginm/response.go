@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +17,106 @@ type Response[T any] struct {
 	Message string `json:"message,omitempty"`
 	Error   string `json:"error,omitempty"`
 	Code    int    `json:"code"`
+	Links   Links  `json:"links,omitempty"`
+}
+
+// EmptyDataMode 控制 Response.Data 为空值时的序列化方式。
+// 空值与 encoding/json 的 omitempty 判定一致: false、0、""、nil 指针/接口、空切片/map。
+type EmptyDataMode int
+
+const (
+	// EmptyDataOmit 省略 data 字段（默认，与 omitempty 行为一致）。
+	EmptyDataOmit EmptyDataMode = iota
+	// EmptyDataNull 将空 data 序列化为 null。
+	EmptyDataNull
+	// EmptyDataObject 将空 data 序列化为 {}。
+	EmptyDataObject
+)
+
+// EnvelopeOptions 控制 Response 信封的序列化行为。
+type EnvelopeOptions struct {
+	// EmptyData 控制空 data 的序列化方式。
+	EmptyData EmptyDataMode
+	// SliceAsArray 为 true 时切片（包括 nil 切片）始终序列化为数组，
+	// 不视为空值，例如 OK[[]T](nil) 输出 "data": []。
+	SliceAsArray bool
+	// Stream 为 true 时 JSON 及所有 Wrap 系列处理器逐元素编码切片数据
+	// （包括 PageResponse 和 ListResponse 的 Items）并直接写入 ResponseWriter，
+	// 不在内存中构建完整响应体。适用于数 MB 级别的列表响应。
+	Stream bool
+}
+
+var envelopeOptions atomic.Pointer[EnvelopeOptions]
+
+// SetEnvelopeOptions 设置全局 Response 信封序列化选项，通常在启动时调用一次。
+func SetEnvelopeOptions(opts EnvelopeOptions) {
+	envelopeOptions.Store(&opts)
+}
+
+// getEnvelopeOptions 返回当前的信封选项。
+func getEnvelopeOptions() EnvelopeOptions {
+	if opts := envelopeOptions.Load(); opts != nil {
+		return *opts
+	}
+	return EnvelopeOptions{}
+}
+
+// envelope 是 Response 实际序列化的结构，data 已按 EnvelopeOptions 处理。
+type envelope struct {
+	Data    any    `json:"data,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    int    `json:"code"`
+	Links   Links  `json:"links,omitempty"`
+}
+
+// MarshalJSON 实现 json.Marshaler，按 EnvelopeOptions 处理空 data。
+func (r Response[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.envelope())
+}
+
+// envelope 返回待序列化的信封。
+func (r Response[T]) envelope() envelope {
+	env := envelope{Message: r.Message, Error: r.Error, Code: r.Code, Links: r.Links}
+	env.Data, _ = r.envelopeData()
+	return env
+}
+
+// envelopeData 按 EnvelopeOptions 返回要序列化的 data 值，false 表示省略。
+func (r Response[T]) envelopeData() (any, bool) {
+	opts := getEnvelopeOptions()
+	v := reflect.ValueOf(&r.Data).Elem()
+	switch {
+	case opts.SliceAsArray && v.Kind() == reflect.Slice:
+		if v.IsNil() {
+			return json.RawMessage("[]"), true
+		}
+		return r.Data, true
+	case !isEmptyValue(v):
+		return r.Data, true
+	case opts.EmptyData == EmptyDataNull:
+		return json.RawMessage("null"), true
+	case opts.EmptyData == EmptyDataObject:
+		return json.RawMessage("{}"), true
+	default:
+		return nil, false
+	}
+}
+
+// isEmptyValue 与 encoding/json 的 omitempty 判定一致。
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
 }
 
 // OK 创建带数据的成功响应。
@@ -59,6 +161,7 @@ type PageResponse[T any] struct {
 	PageSize   int   `json:"page_size"`
 	TotalPages int   `json:"total_pages"`
 	HasMore    bool  `json:"has_more"`
+	Links      Links `json:"links,omitempty"`
 }
 
 // NewPageResponse 创建新的分页响应。
@@ -97,34 +200,55 @@ func NewListResponse[T any](items []T) ListResponse[T] {
 	}
 }
 
-// JSON 发送带指定状态码的 JSON 响应。
+// JSON 发送带指定状态码的 JSON 响应。成功响应先经过 RegisterResponseTransformer 注册的转换器。
+// 编码缓冲从对象池复用；启用 EnvelopeOptions.Stream 时直接流式写入 ResponseWriter。
+// 启用 Negotiation 中间件时按 Accept 头选择输出格式。
 func JSON[T any](c *gin.Context, status int, resp Response[T]) {
-	c.JSON(status, resp)
+	if untyped, ok := transformResponse(c, status, &resp); ok {
+		writeJSON(c, status, untyped)
+		return
+	}
+	writeJSON(c, status, resp)
+}
+
+// writeJSON 按协商格式和 EnvelopeOptions 输出信封。
+func writeJSON[T any](c *gin.Context, status int, resp Response[T]) {
+	if f := negotiateFormat(c); f != FormatJSON {
+		renderFormat(c, status, f, resp)
+		return
+	}
+	if getEnvelopeOptions().Stream {
+		streamJSON(c, status, resp)
+		return
+	}
+	bufferedJSON(c, status, resp)
 }
 
 // Success 发送 HTTP 200 的成功 JSON 响应。
 func Success[T any](c *gin.Context, data T) {
-	c.JSON(http.StatusOK, OK(data))
+	JSON(c, http.StatusOK, OK(data))
 }
 
 // SuccessWithMessage 发送带消息的成功 JSON 响应。
 func SuccessWithMessage[T any](c *gin.Context, message string, data T) {
-	c.JSON(http.StatusOK, OKWithMessage(message, data))
+	JSON(c, http.StatusOK, OKWithMessage(message, data))
 }
 
-// SuccessPage 发送分页成功响应。
+// SuccessPage 发送分页成功响应，启用 PaginationOptions.Headers 时同时输出分页响应头。
 func SuccessPage[T any](c *gin.Context, items []T, total int64, page, pageSize int) {
-	c.JSON(http.StatusOK, OK(NewPageResponse(items, total, page, pageSize)))
+	resp := NewPageResponse(items, total, page, pageSize)
+	writePageHeaders(c, resp)
+	JSON(c, http.StatusOK, OK(resp))
 }
 
 // SuccessList 发送列表成功响应。
 func SuccessList[T any](c *gin.Context, items []T) {
-	c.JSON(http.StatusOK, OK(NewListResponse(items)))
+	JSON(c, http.StatusOK, OK(NewListResponse(items)))
 }
 
 // Error 发送错误 JSON 响应。
 func Error(c *gin.Context, httpStatus int, code int, message string) {
-	c.JSON(httpStatus, Fail[any](code, message))
+	JSON(c, httpStatus, Fail[any](code, message))
 }
 
 // ErrorWithDetail 发送带错误详情的错误 JSON 响应。
@@ -133,7 +257,7 @@ func ErrorWithDetail(c *gin.Context, httpStatus int, code int, message string, e
 	if err != nil && gin.Mode() != gin.ReleaseMode {
 		errStr = err.Error()
 	}
-	c.JSON(httpStatus, FailWithError[any](code, message, errStr))
+	JSON(c, httpStatus, FailWithError[any](code, message, errStr))
 }
 
 // --- 其他 HTTP 状态响应 ---
@@ -145,17 +269,17 @@ func NoContent(c *gin.Context) {
 
 // Created 发送带数据的 HTTP 201 Created。
 func Created[T any](c *gin.Context, data T) {
-	c.JSON(http.StatusCreated, OK(data))
+	JSON(c, http.StatusCreated, OK(data))
 }
 
 // CreatedWithMessage 发送带消息和数据的 HTTP 201 Created。
 func CreatedWithMessage[T any](c *gin.Context, message string, data T) {
-	c.JSON(http.StatusCreated, OKWithMessage(message, data))
+	JSON(c, http.StatusCreated, OKWithMessage(message, data))
 }
 
 // Accepted 发送带数据的 HTTP 202 Accepted。
 func Accepted[T any](c *gin.Context, data T) {
-	c.JSON(http.StatusAccepted, OK(data))
+	JSON(c, http.StatusAccepted, OK(data))
 }
 
 // Redirect 发送重定向响应。
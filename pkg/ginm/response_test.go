@@ -1,9 +1,11 @@
 package ginm
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOK_CreatesSuccessResponse(t *testing.T) {
@@ -83,3 +85,40 @@ func TestNewListResponse_EmptySlice(t *testing.T) {
 	assert.Empty(t, resp.Items)
 	assert.Equal(t, 0, resp.Count)
 }
+
+func marshalWithEnvelope[T any](t *testing.T, opts EnvelopeOptions, resp Response[T]) string {
+	t.Helper()
+	SetEnvelopeOptions(opts)
+	defer SetEnvelopeOptions(EnvelopeOptions{})
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestResponse_MarshalJSON_DefaultOmitsEmptyData(t *testing.T) {
+	assert.JSONEq(t, `{"code":0}`, marshalWithEnvelope(t, EnvelopeOptions{}, OK[[]int](nil)))
+	assert.JSONEq(t, `{"code":0}`, marshalWithEnvelope(t, EnvelopeOptions{}, OK[any](nil)))
+	assert.JSONEq(t, `{"code":0,"data":{"id":0}}`,
+		marshalWithEnvelope(t, EnvelopeOptions{}, OK(struct {
+			ID int `json:"id"`
+		}{})))
+	assert.JSONEq(t, `{"code":0,"data":[1]}`, marshalWithEnvelope(t, EnvelopeOptions{}, OK([]int{1})))
+}
+
+func TestResponse_MarshalJSON_EmptyDataNull(t *testing.T) {
+	opts := EnvelopeOptions{EmptyData: EmptyDataNull}
+	assert.JSONEq(t, `{"code":0,"data":null}`, marshalWithEnvelope(t, opts, OK[any](nil)))
+	assert.JSONEq(t, `{"code":400,"message":"bad","data":null}`, marshalWithEnvelope(t, opts, Fail[any](400, "bad")))
+}
+
+func TestResponse_MarshalJSON_EmptyDataObject(t *testing.T) {
+	opts := EnvelopeOptions{EmptyData: EmptyDataObject}
+	assert.JSONEq(t, `{"code":0,"data":{}}`, marshalWithEnvelope(t, opts, OK[map[string]int](nil)))
+}
+
+func TestResponse_MarshalJSON_SliceAsArray(t *testing.T) {
+	opts := EnvelopeOptions{SliceAsArray: true, EmptyData: EmptyDataNull}
+	assert.JSONEq(t, `{"code":0,"data":[]}`, marshalWithEnvelope(t, opts, OK[[]int](nil)))
+	assert.JSONEq(t, `{"code":0,"data":[]}`, marshalWithEnvelope(t, opts, OK([]string{})))
+	assert.JSONEq(t, `{"code":0,"data":null}`, marshalWithEnvelope(t, opts, OK[*int](nil)))
+}
@@ -0,0 +1,168 @@
+package ginm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteConflictKind 表示路由冲突的类型。
+type RouteConflictKind string
+
+// 路由冲突类型。
+const (
+	// RouteDuplicate 表示同一方法下路径完全相同。
+	RouteDuplicate RouteConflictKind = "duplicate"
+	// RouteParamConflict 表示同一位置使用了不同名称的路径参数，gin 会在注册时 panic。
+	RouteParamConflict RouteConflictKind = "param_conflict"
+	// RouteShadowed 表示静态路由遮蔽了参数路由，例如 /users/export 遮蔽 /users/:id。
+	RouteShadowed RouteConflictKind = "shadowed"
+)
+
+// RouteConflict 描述两个路由之间的冲突。
+type RouteConflict struct {
+	Kind   RouteConflictKind
+	Method string
+	// Path 是受影响的路由。
+	Path string
+	// Other 是与之冲突的路由。
+	Other string
+}
+
+func (c RouteConflict) String() string {
+	switch c.Kind {
+	case RouteShadowed:
+		return fmt.Sprintf("%s %s is shadowed by %s", c.Method, c.Path, c.Other)
+	case RouteParamConflict:
+		return fmt.Sprintf("%s %s uses a different wildcard name than %s", c.Method, c.Path, c.Other)
+	default:
+		return fmt.Sprintf("%s %s duplicates %s", c.Method, c.Path, c.Other)
+	}
+}
+
+// RouteConflictError 汇总启动时检测到的所有路由冲突。
+type RouteConflictError struct {
+	Conflicts []RouteConflict
+}
+
+func (e *RouteConflictError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d route conflicts:", len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		sb.WriteString("\n  - ")
+		sb.WriteString(c.String())
+	}
+	return sb.String()
+}
+
+// routeCheckConfig 包含路由检查的配置。
+type routeCheckConfig struct {
+	allowShadowing bool
+}
+
+// RouteCheckOption 是路由检查的函数式选项。
+type RouteCheckOption func(*routeCheckConfig)
+
+// AllowShadowing 忽略静态路由遮蔽参数路由的情况，仅报告重复和参数冲突。
+func AllowShadowing() RouteCheckOption {
+	return func(cfg *routeCheckConfig) {
+		cfg.allowShadowing = true
+	}
+}
+
+// CheckRoutes 检查路由表中的重复、参数冲突和遮蔽。
+// 没有冲突时返回 nil，否则返回 *RouteConflictError。
+//
+//	if err := ginm.CheckRoutes(r.Routes()); err != nil {
+//	    log.Fatal(err)
+//	}
+func CheckRoutes(routes gin.RoutesInfo, opts ...RouteCheckOption) error {
+	cfg := &routeCheckConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var conflicts []RouteConflict
+	for i, a := range routes {
+		for _, b := range routes[i+1:] {
+			if a.Method != b.Method {
+				continue
+			}
+			c, ok := compareRoutes(a.Method, a.Path, b.Path)
+			if !ok || (c.Kind == RouteShadowed && cfg.allowShadowing) {
+				continue
+			}
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return &RouteConflictError{Conflicts: conflicts}
+}
+
+// MustCheckRoutes 检查引擎上已注册的路由，存在冲突时 panic。
+func MustCheckRoutes(engine *gin.Engine, opts ...RouteCheckOption) {
+	if err := CheckRoutes(engine.Routes(), opts...); err != nil {
+		panic(err)
+	}
+}
+
+// compareRoutes 比较同一方法下的两个路径。
+func compareRoutes(method, a, b string) (RouteConflict, bool) {
+	segsA := splitRoutePath(a)
+	segsB := splitRoutePath(b)
+
+	staticA, staticB := false, false
+	for i := 0; i < len(segsA) && i < len(segsB); i++ {
+		sa, sb := segsA[i], segsB[i]
+		wildA, wildB := isWildcard(sa), isWildcard(sb)
+
+		switch {
+		case wildA && wildB:
+			if sa != sb {
+				return RouteConflict{Kind: RouteParamConflict, Method: method, Path: a, Other: b}, true
+			}
+			if sa[0] == '*' {
+				return RouteConflict{Kind: RouteDuplicate, Method: method, Path: a, Other: b}, true
+			}
+		case wildA:
+			if sa[0] == '*' {
+				return RouteConflict{Kind: RouteShadowed, Method: method, Path: a, Other: b}, true
+			}
+			staticB = true
+		case wildB:
+			if sb[0] == '*' {
+				return RouteConflict{Kind: RouteShadowed, Method: method, Path: b, Other: a}, true
+			}
+			staticA = true
+		case sa != sb:
+			return RouteConflict{}, false
+		}
+	}
+
+	if len(segsA) != len(segsB) {
+		return RouteConflict{}, false
+	}
+	switch {
+	case staticA:
+		// 两个路由互相部分遮蔽时按注册顺序报告后者
+		return RouteConflict{Kind: RouteShadowed, Method: method, Path: b, Other: a}, true
+	case staticB:
+		return RouteConflict{Kind: RouteShadowed, Method: method, Path: a, Other: b}, true
+	default:
+		return RouteConflict{Kind: RouteDuplicate, Method: method, Path: b, Other: a}, true
+	}
+}
+
+// splitRoutePath 将路径拆分为段，保留尾部斜杠产生的空段。
+func splitRoutePath(p string) []string {
+	return strings.Split(strings.TrimPrefix(p, "/"), "/")
+}
+
+// isWildcard 判断路径段是否为参数（:name）或通配（*name）。
+func isWildcard(seg string) bool {
+	return seg != "" && (seg[0] == ':' || seg[0] == '*')
+}
@@ -0,0 +1,135 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRoutes_NoConflicts(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users"},
+		{Method: http.MethodGet, Path: "/users/:id"},
+		{Method: http.MethodPost, Path: "/users"},
+		{Method: http.MethodGet, Path: "/users/:id/posts"},
+	}
+	assert.NoError(t, CheckRoutes(routes))
+}
+
+func TestCheckRoutes_DetectsShadowing(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users/:id"},
+		{Method: http.MethodGet, Path: "/users/export"},
+	}
+	err := CheckRoutes(routes)
+
+	var conflictErr *RouteConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 1)
+	c := conflictErr.Conflicts[0]
+	assert.Equal(t, RouteShadowed, c.Kind)
+	assert.Equal(t, "/users/:id", c.Path)
+	assert.Equal(t, "/users/export", c.Other)
+	assert.Contains(t, err.Error(), "GET /users/:id is shadowed by /users/export")
+}
+
+func TestCheckRoutes_DetectsCatchAllShadowing(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/files/readme"},
+		{Method: http.MethodGet, Path: "/files/*path"},
+	}
+	var conflictErr *RouteConflictError
+	require.ErrorAs(t, CheckRoutes(routes), &conflictErr)
+	assert.Equal(t, RouteShadowed, conflictErr.Conflicts[0].Kind)
+	assert.Equal(t, "/files/*path", conflictErr.Conflicts[0].Path)
+}
+
+func TestCheckRoutes_AllowShadowing(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users/:id"},
+		{Method: http.MethodGet, Path: "/users/export"},
+	}
+	assert.NoError(t, CheckRoutes(routes, AllowShadowing()))
+}
+
+func TestCheckRoutes_DetectsDuplicatesAndParamConflicts(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users/:id"},
+		{Method: http.MethodGet, Path: "/users/:id"},
+		{Method: http.MethodGet, Path: "/users/:uid/posts"},
+	}
+	var conflictErr *RouteConflictError
+	require.ErrorAs(t, CheckRoutes(routes), &conflictErr)
+
+	kinds := make([]RouteConflictKind, 0, len(conflictErr.Conflicts))
+	for _, c := range conflictErr.Conflicts {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Contains(t, kinds, RouteDuplicate)
+	assert.Contains(t, kinds, RouteParamConflict)
+}
+
+func TestCheckRoutes_TrailingSlashIsDistinct(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users"},
+		{Method: http.MethodGet, Path: "/users/"},
+	}
+	assert.NoError(t, CheckRoutes(routes))
+}
+
+func TestRouterChain_RecordsRoutesAcrossGroups(t *testing.T) {
+	r := newTestEngine()
+	rc := WithChain(r.Group("/api"))
+	noop := func(c *gin.Context) {}
+
+	rc.GET("/users", noop)
+	rc.Group("/v1").POST("/items", noop)
+
+	routes := rc.Routes()
+	require.Len(t, routes, 2)
+	assert.Equal(t, "/api/users", routes[0].Path)
+	assert.Equal(t, "/api/v1/items", routes[1].Path)
+	assert.NoError(t, rc.CheckRoutes())
+}
+
+func TestRouterChain_PanicsWithReportOnDuplicate(t *testing.T) {
+	r := newTestEngine()
+	rc := WithChain(r.Group("/api"))
+	noop := func(c *gin.Context) {}
+
+	rc.GET("/users/:id", noop)
+	var recovered any
+	assert.Panics(t, func() {
+		defer func() {
+			recovered = recover()
+			panic(recovered)
+		}()
+		rc.GET("/users/:uid", noop)
+	})
+
+	err, _ := recovered.(error)
+	var conflictErr *RouteConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, []RouteConflict{{
+		Kind:   RouteParamConflict,
+		Method: http.MethodGet,
+		Path:   "/api/users/:id",
+		Other:  "/api/users/:uid",
+	}}, conflictErr.Conflicts)
+}
+
+func TestRouterChain_CheckRoutesReportsShadowing(t *testing.T) {
+	r := newTestEngine()
+	rc := WithChain(r.Group(""))
+	noop := func(c *gin.Context) {}
+
+	rc.GET("/users/:id", noop)
+	rc.GET("/users/export", noop)
+
+	var conflictErr *RouteConflictError
+	require.ErrorAs(t, rc.CheckRoutes(), &conflictErr)
+	assert.Equal(t, RouteShadowed, conflictErr.Conflicts[0].Kind)
+}
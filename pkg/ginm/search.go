@@ -0,0 +1,75 @@
+package ginm
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Searcher 是资源可选实现的搜索接口，用于查询字符串难以表达的复杂条件。
+// 查询类型与 List 相同（LQ），但从 JSON 请求体绑定。实现后 RegisterResource 注册 POST /search 路由。
+type Searcher[T any, LQ any] interface {
+	Search(c *gin.Context, query *LQ) (PageResponse[T], error)
+}
+
+// RegisterSearch 注册 POST /search 路由，请求体按 JSON 绑定到 Req，返回分页结果。
+// 分页参数位于请求体中，因此不输出 PaginationOptions.Headers 的分页响应头。opts 与 Wrap 的选项相同。
+//
+//	type UserSearch struct {
+//	    ginm.PageQuery
+//	    Roles  []string `json:"roles"`
+//	    Status []string `json:"status"`
+//	}
+//	ginm.RegisterSearch(api.Group("/users"), func(c *gin.Context, req *UserSearch) (ginm.PageResponse[User], error) {
+//	    ...
+//	})
+func RegisterSearch[Req any, Item any](
+	group *gin.RouterGroup,
+	handler func(c *gin.Context, req *Req) (PageResponse[Item], error),
+	opts ...WrapOption,
+) {
+	group.POST("/search", searchHandler(handler, nil, opts))
+}
+
+// registerSearch 在资源实现 Searcher 时注册 POST /search 路由，使用 List 的中间件。
+func registerSearch[T any, ID comparable, CI any, UI any, LQ any](
+	group *gin.RouterGroup,
+	resource Resource[T, ID, CI, UI, LQ],
+	cfg *ResourceConfig,
+) {
+	searcher, ok := resource.(Searcher[T, LQ])
+	if !ok || !cfg.enabled(ActionList) {
+		return
+	}
+	if cfg.OpenAPI != nil {
+		cfg.OpenAPI.add(&openAPIOperation{
+			method: http.MethodPost, path: joinRoutePath(group.BasePath(), "/search"),
+			body: reflect.TypeFor[LQ](), resp: reflect.TypeFor[Response[PageResponse[T]]](), status: http.StatusOK,
+		})
+	}
+	group.POST("/search", cfg.handlers(ActionList, searchHandler(searcher.Search, cfg.bindIncludes, nil))...)
+}
+
+// searchHandler 创建搜索路由处理器，bindIncludes 不为 nil 时在搜索前解析 include 参数。
+func searchHandler[Req any, Item any](
+	handler func(c *gin.Context, req *Req) (PageResponse[Item], error),
+	bindIncludes func(c *gin.Context) error,
+	opts []WrapOption,
+) gin.HandlerFunc {
+	w := newWrapper[Req, PageResponse[Item]](http.StatusOK, withIncludes(BindJSON[Req], bindIncludes), opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+
+		w.respond(c, req, resp)
+	}
+}
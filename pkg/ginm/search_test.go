@@ -0,0 +1,51 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type searchBody struct {
+	Title string `json:"title" binding:"required"`
+	PageQuery
+}
+
+type searchResource struct {
+	BaseResource[hookPost, int, hookPostInput, hookPostInput, searchBody]
+}
+
+func (r *searchResource) Search(c *gin.Context, q *searchBody) (PageResponse[hookPost], error) {
+	return NewPageResponse([]hookPost{{ID: 1, Title: q.Title}}, 1, q.Page, q.PageSize), nil
+}
+
+func TestRegisterSearch(t *testing.T) {
+	r := newTestEngine()
+	RegisterSearch(r.Group("/users"), func(c *gin.Context, req *searchBody) (PageResponse[string], error) {
+		return NewPageResponse([]string{req.Title}, 1, req.Page, req.PageSize), nil
+	})
+
+	w := serve(r, http.MethodPost, "/users/search", `{"title":"a","page":2,"page_size":5}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"items":["a"]`)
+	assert.Contains(t, w.Body.String(), `"page":2`)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(r, http.MethodPost, "/users/search", `{}`).Code)
+}
+
+func TestRegisterResource_Searcher(t *testing.T) {
+	r := newTestEngine()
+	api := NewOpenAPI(OpenAPIConfig{})
+	RegisterResourceReadOnly(r.Group("/posts"), &searchResource{}, WithOpenAPI(api), WithListMiddleware(requireHeader("X-Token")))
+
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPost, "/posts/search", `{"title":"a"}`).Code)
+	w := serve(r, http.MethodPost, "/posts/search", `{"title":"a"}`, "X-Token", "1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"a"`)
+	assert.NotNil(t, dig(specJSON(t, api), "paths", "/posts/search", "post", "requestBody"))
+
+	r = newTestEngine()
+	RegisterResource(r.Group("/posts"), &hookPostResource{})
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/posts/search", `{}`).Code)
+}
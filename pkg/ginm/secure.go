@@ -0,0 +1,114 @@
+package ginm
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 由 SecureHeaders 管理的响应头。
+const (
+	HeaderStrictTransportSecurity = "Strict-Transport-Security"
+	HeaderContentTypeOptions      = "X-Content-Type-Options"
+	HeaderFrameOptions            = "X-Frame-Options"
+	HeaderReferrerPolicy          = "Referrer-Policy"
+	HeaderContentSecurityPolicy   = "Content-Security-Policy"
+)
+
+// secureHeaderNames 是 SkipSecureHeaders 未指定响应头时移除的全部响应头。
+var secureHeaderNames = []string{
+	HeaderStrictTransportSecurity, HeaderContentTypeOptions, HeaderFrameOptions,
+	HeaderReferrerPolicy, HeaderContentSecurityPolicy,
+}
+
+// SecureHeadersConfig 包含安全响应头中间件的配置。字符串字段为空时使用默认值，设置为 "-" 时不输出该响应头。
+type SecureHeadersConfig struct {
+	// HSTSMaxAge 是 Strict-Transport-Security 的 max-age，小于 0 时不输出。默认值: 365 天
+	HSTSMaxAge time.Duration
+	// HSTSIncludeSubdomains 为 true 时添加 includeSubDomains。
+	HSTSIncludeSubdomains bool
+	// HSTSPreload 为 true 时添加 preload。
+	HSTSPreload bool
+	// ContentTypeOptions 是 X-Content-Type-Options。默认值: "nosniff"
+	ContentTypeOptions string
+	// FrameOptions 是 X-Frame-Options。默认值: "DENY"
+	FrameOptions string
+	// ReferrerPolicy 是 Referrer-Policy。默认值: "strict-origin-when-cross-origin"
+	ReferrerPolicy string
+	// ContentSecurityPolicy 是 Content-Security-Policy。默认值: "default-src 'self'; frame-ancestors 'none'"
+	ContentSecurityPolicy string
+	// Skip 返回 true 时不输出任何安全响应头。
+	Skip func(c *gin.Context) bool
+}
+
+// SecureHeaders 返回输出安全响应头的中间件。Strict-Transport-Security 只在 HTTPS 请求
+// （TLS 连接或 X-Forwarded-Proto: https）上输出，浏览器会忽略 HTTP 响应中的该响应头。
+//
+// 响应头在调用 c.Next() 前写入，路由级中间件可以覆盖或通过 SkipSecureHeaders 移除：
+//
+//	r.Use(ginm.SecureHeaders(ginm.SecureHeadersConfig{HSTSIncludeSubdomains: true}))
+//	r.GET("/docs/*any", ginm.SkipSecureHeaders(ginm.HeaderContentSecurityPolicy), docsHandler)
+func SecureHeaders(cfg SecureHeadersConfig) gin.HandlerFunc {
+	if cfg.HSTSMaxAge == 0 {
+		cfg.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+	var hsts string
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.FormatInt(int64(cfg.HSTSMaxAge/time.Second), 10)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+	static := [][2]string{
+		{HeaderContentTypeOptions, secureHeaderValue(cfg.ContentTypeOptions, "nosniff")},
+		{HeaderFrameOptions, secureHeaderValue(cfg.FrameOptions, "DENY")},
+		{HeaderReferrerPolicy, secureHeaderValue(cfg.ReferrerPolicy, "strict-origin-when-cross-origin")},
+		{HeaderContentSecurityPolicy, secureHeaderValue(cfg.ContentSecurityPolicy, "default-src 'self'; frame-ancestors 'none'")},
+	}
+
+	return func(c *gin.Context) {
+		if cfg.Skip != nil && cfg.Skip(c) {
+			c.Next()
+			return
+		}
+		h := c.Writer.Header()
+		for _, kv := range static {
+			if kv[1] != "" {
+				h.Set(kv[0], kv[1])
+			}
+		}
+		if hsts != "" && (c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https") {
+			h.Set(HeaderStrictTransportSecurity, hsts)
+		}
+		c.Next()
+	}
+}
+
+// SkipSecureHeaders 返回移除 SecureHeaders 已设置的响应头的路由级中间件，未指定 headers 时移除全部。
+func SkipSecureHeaders(headers ...string) gin.HandlerFunc {
+	if len(headers) == 0 {
+		headers = secureHeaderNames
+	}
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		for _, name := range headers {
+			h.Del(name)
+		}
+		c.Next()
+	}
+}
+
+// secureHeaderValue 返回配置值，为空时返回默认值，为 "-" 时返回空字符串。
+func secureHeaderValue(v, def string) string {
+	switch v {
+	case "":
+		return def
+	case "-":
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,67 @@
+package ginm
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureHeaders_Defaults(t *testing.T) {
+	r := newTestEngine(SecureHeaders(SecureHeadersConfig{}))
+	r.GET("/api", okHandler)
+
+	w := serve(r, http.MethodGet, "/api", "")
+	assert.Equal(t, "nosniff", w.Header().Get(HeaderContentTypeOptions))
+	assert.Equal(t, "DENY", w.Header().Get(HeaderFrameOptions))
+	assert.Equal(t, "strict-origin-when-cross-origin", w.Header().Get(HeaderReferrerPolicy))
+	assert.Equal(t, "default-src 'self'; frame-ancestors 'none'", w.Header().Get(HeaderContentSecurityPolicy))
+	assert.Empty(t, w.Header().Get(HeaderStrictTransportSecurity))
+
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.TLS = &tls.ConnectionState{}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "max-age=31536000", w.Header().Get(HeaderStrictTransportSecurity))
+}
+
+func TestSecureHeaders_Config(t *testing.T) {
+	r := newTestEngine(SecureHeaders(SecureHeadersConfig{
+		HSTSIncludeSubdomains: true,
+		HSTSPreload:           true,
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "-",
+		Skip:                  func(c *gin.Context) bool { return strings.HasPrefix(c.Request.URL.Path, "/skip") },
+	}))
+	r.GET("/api", okHandler)
+	r.GET("/skip", okHandler)
+
+	w := serve(r, http.MethodGet, "/api", "", "X-Forwarded-Proto", "https")
+	assert.Equal(t, "max-age=31536000; includeSubDomains; preload", w.Header().Get(HeaderStrictTransportSecurity))
+	assert.Equal(t, "SAMEORIGIN", w.Header().Get(HeaderFrameOptions))
+	assert.NotContains(t, w.Header(), HeaderReferrerPolicy)
+
+	assert.Empty(t, serve(r, http.MethodGet, "/skip", "").Header().Get(HeaderContentTypeOptions))
+}
+
+func TestSkipSecureHeaders(t *testing.T) {
+	r := newTestEngine(SecureHeaders(SecureHeadersConfig{HSTSMaxAge: -1}))
+	r.GET("/api", okHandler)
+	r.GET("/docs", SkipSecureHeaders(HeaderContentSecurityPolicy, HeaderFrameOptions), okHandler)
+	r.GET("/raw", SkipSecureHeaders(), okHandler)
+
+	w := serve(r, http.MethodGet, "/docs", "")
+	assert.Empty(t, w.Header().Get(HeaderContentSecurityPolicy))
+	assert.Empty(t, w.Header().Get(HeaderFrameOptions))
+	assert.Equal(t, "nosniff", w.Header().Get(HeaderContentTypeOptions))
+
+	w = serve(r, http.MethodGet, "/raw", "", "X-Forwarded-Proto", "https")
+	for _, name := range secureHeaderNames {
+		assert.Empty(t, w.Header().Get(name))
+	}
+	assert.Empty(t, serve(r, http.MethodGet, "/api", "", "X-Forwarded-Proto", "https").Header().Get(HeaderStrictTransportSecurity))
+}
@@ -0,0 +1,219 @@
+// Package sqladapter 基于 database/sql 执行 ginm 的分页查询。
+//
+// 只依赖标准库，*sql.DB、*sql.Tx、*sql.Conn 以及嵌入 *sql.DB 的 *sqlx.DB 都可直接使用：
+//
+//	func ListUsers(c *gin.Context, q *ginm.PageQuery) (ginm.PageResponse[User], error) {
+//	    return sqladapter.Paginate[User](c.Request.Context(), db, q, sqladapter.Query{
+//	        SQL:  "SELECT id, name FROM users WHERE org_id = ? ORDER BY id DESC",
+//	        Args: []any{orgID},
+//	    })
+//	}
+package sqladapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+// Queryer 是执行查询所需的最小接口。
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Query 是带绑定参数的 SQL 语句。
+type Query struct {
+	SQL  string
+	Args []any
+}
+
+// ScanFunc 将当前行扫描为 T。
+type ScanFunc[T any] func(rows *sql.Rows) (T, error)
+
+// Option 配置分页查询。
+type Option func(*config)
+
+type config struct {
+	placeholder func(n int) string
+	count       *Query
+}
+
+// WithPlaceholder 设置 LIMIT/OFFSET 的占位符，n 为参数序号（从 1 开始，紧接 Query.Args）。
+// 默认使用 "?"；PostgreSQL 使用 ginm.DollarPlaceholder。
+func WithPlaceholder(fn func(n int) string) Option {
+	return func(c *config) {
+		c.placeholder = fn
+	}
+}
+
+// WithCountQuery 使用自定义的计数语句，默认为 SELECT COUNT(*) FROM (<基础语句>) AS ginm_count。
+// 基础语句包含大量 JOIN 或 ORDER BY 时可提供更轻量的计数语句。
+func WithCountQuery(q Query) Option {
+	return func(c *config) {
+		c.count = &q
+	}
+}
+
+// Paginate 执行计数和 LIMIT/OFFSET 两条查询，按 db 标签将结果扫描为 []T 并返回 PageResponse。
+// 基础语句应包含确定的 ORDER BY，否则分页结果不稳定。
+func Paginate[T any](ctx context.Context, db Queryer, page *ginm.PageQuery, query Query, opts ...Option) (ginm.PageResponse[T], error) {
+	return PaginateFunc(ctx, db, page, query, StructScan[T], opts...)
+}
+
+// PaginateFunc 与 Paginate 相同，但使用 scan 扫描每一行。
+func PaginateFunc[T any](ctx context.Context, db Queryer, page *ginm.PageQuery, query Query, scan ScanFunc[T], opts ...Option) (ginm.PageResponse[T], error) {
+	cfg := config{placeholder: func(int) string { return "?" }}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p := ginm.NewPaginatorFromQuery[T](page)
+
+	count := Query{SQL: "SELECT COUNT(*) FROM (" + query.SQL + ") AS ginm_count", Args: query.Args}
+	if cfg.count != nil {
+		count = *cfg.count
+	}
+	var total int64
+	if err := db.QueryRowContext(ctx, count.SQL, count.Args...).Scan(&total); err != nil {
+		return ginm.PageResponse[T]{}, fmt.Errorf("count: %w", err)
+	}
+	if total == 0 || int64(p.Offset()) >= total {
+		return p.Paginate(nil, total), nil
+	}
+
+	n := len(query.Args)
+	stmt := query.SQL + " LIMIT " + cfg.placeholder(n+1) + " OFFSET " + cfg.placeholder(n+2)
+	args := append(append(make([]any, 0, n+2), query.Args...), p.Limit(), p.Offset())
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return ginm.PageResponse[T]{}, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]T, 0, p.Limit())
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return ginm.PageResponse[T]{}, fmt.Errorf("scan: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return ginm.PageResponse[T]{}, fmt.Errorf("query: %w", err)
+	}
+	return p.Paginate(items, total), nil
+}
+
+// ErrUnknownColumn 表示结果列在目标结构体中没有对应字段。
+var ErrUnknownColumn = errors.New("no destination field for column")
+
+// StructScan 将当前行扫描为 T。T 为结构体（或其指针）时按 db 标签匹配列名，
+// 无标签时使用小写字段名，db:"-" 的字段被忽略，匿名嵌入的结构体（包括结构体指针）被展开；
+// 其他类型以及 time.Time、实现 sql.Scanner 的结构体（如 sql.NullString）要求结果只有一列。
+func StructScan[T any](rows *sql.Rows) (T, error) {
+	var item T
+	v := reflect.ValueOf(&item).Elem()
+	if v.Kind() == reflect.Pointer && isColumnStruct(v.Type().Elem()) {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if !isColumnStruct(v.Type()) {
+		return item, rows.Scan(&item)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return item, err
+	}
+	fields := structFields(v.Type())
+	dest := make([]any, len(columns))
+	for i, col := range columns {
+		index, ok := fields[strings.ToLower(col)]
+		if !ok {
+			return item, fmt.Errorf("%w: %s", ErrUnknownColumn, col)
+		}
+		dest[i] = fieldByIndexAlloc(v, index).Addr().Interface()
+	}
+	return item, rows.Scan(dest...)
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// isColumnStruct 返回 t 是否按字段映射多列：time.Time 和实现 sql.Scanner 的结构体作为单个值扫描。
+func isColumnStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// fieldByIndexAlloc 与 FieldByIndex 相同，但会为路径上为 nil 的嵌入结构体指针分配内存。
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+var fieldCache sync.Map // reflect.Type -> map[string][]int
+
+// structFields 返回结构体的列名到字段索引的映射。
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	collectFields(t, nil, fields)
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// collectFields 递归收集导出字段，嵌入结构体（包括未导出类型）中提升的导出字段同样收集。
+func collectFields(t reflect.Type, parent []int, fields map[string][]int) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		if tag == "-" {
+			continue
+		}
+		index := append(append([]int(nil), parent...), i)
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				// 未导出类型的嵌入指针无法通过反射分配，与 encoding/json 一样跳过
+				if !f.IsExported() {
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if isColumnStruct(ft) {
+				collectFields(ft, index, fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		// 与 Go 字段提升规则一致，层级较浅的字段优先
+		name := strings.ToLower(tag)
+		if existing, ok := fields[name]; !ok || len(index) < len(existing) {
+			fields[name] = index
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package sqladapter
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/ginm"
+)
+
+// fakeDriver 是内存中的 database/sql 驱动：COUNT 语句返回行数，其他语句按末尾的 LIMIT/OFFSET 参数返回切片。
+type fakeDriver struct {
+	mu      sync.Mutex
+	columns []string
+	rows    [][]driver.Value
+	queries []string
+	args    [][]any
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	d := c.d
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	args := make([]any, len(named))
+	for i, a := range named {
+		args[i] = a.Value
+	}
+	d.queries = append(d.queries, query)
+	d.args = append(d.args, args)
+
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		return &fakeRows{columns: []string{"count"}, rows: [][]driver.Value{{int64(len(d.rows))}}}, nil
+	}
+	limit, offset := int(args[len(args)-2].(int64)), int(args[len(args)-1].(int64))
+	end := min(offset+limit, len(d.rows))
+	return &fakeRows{columns: d.columns, rows: d.rows[offset:end]}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openFake(t *testing.T, columns []string, rows [][]driver.Value) (*sql.DB, *fakeDriver) {
+	t.Helper()
+	d := &fakeDriver{columns: columns, rows: rows}
+	name := "fake-" + t.Name()
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+type baseModel struct {
+	ID int64 `db:"id"`
+}
+
+type user struct {
+	baseModel
+	Name    string
+	Email   string `db:"email_address"`
+	Ignored string `db:"-"`
+}
+
+func userRows(n int) [][]driver.Value {
+	rows := make([][]driver.Value, n)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i + 1), "user" + string(rune('a'+i)), "u@example.com"}
+	}
+	return rows
+}
+
+func TestPaginate_CountsAndScansPage(t *testing.T) {
+	db, d := openFake(t, []string{"id", "name", "email_address"}, userRows(5))
+	resp, err := Paginate[user](context.Background(), db, &ginm.PageQuery{Page: 2, PageSize: 2}, Query{
+		SQL:  "SELECT id, name, email_address FROM users WHERE org = ? ORDER BY id",
+		Args: []any{"acme"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), resp.Total)
+	assert.Equal(t, 2, resp.Page)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.True(t, resp.HasMore)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, user{baseModel: baseModel{ID: 3}, Name: "userc", Email: "u@example.com"}, resp.Items[0])
+	assert.Equal(t, int64(4), resp.Items[1].ID)
+
+	assert.Equal(t, []string{
+		"SELECT COUNT(*) FROM (SELECT id, name, email_address FROM users WHERE org = ? ORDER BY id) AS ginm_count",
+		"SELECT id, name, email_address FROM users WHERE org = ? ORDER BY id LIMIT ? OFFSET ?",
+	}, d.queries)
+	assert.Equal(t, []any{"acme", int64(2), int64(2)}, d.args[1])
+}
+
+func TestPaginate_SkipsPageQueryPastEnd(t *testing.T) {
+	db, d := openFake(t, []string{"id"}, userRows(3))
+	resp, err := Paginate[int64](context.Background(), db, &ginm.PageQuery{Page: 5}, Query{SQL: "SELECT id FROM users"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{}, resp.Items)
+	assert.Equal(t, int64(3), resp.Total)
+	assert.Len(t, d.queries, 1)
+}
+
+func TestPaginate_Options(t *testing.T) {
+	db, d := openFake(t, []string{"id"}, userRows(3))
+	resp, err := Paginate[*user](context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT id FROM users WHERE a = $1", Args: []any{1}},
+		WithPlaceholder(ginm.DollarPlaceholder),
+		WithCountQuery(Query{SQL: "SELECT COUNT(*) FROM users"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 3)
+	assert.Equal(t, int64(1), resp.Items[0].ID)
+	assert.Equal(t, "SELECT COUNT(*) FROM users", d.queries[0])
+	assert.Equal(t, "SELECT id FROM users WHERE a = $1 LIMIT $2 OFFSET $3", d.queries[1])
+}
+
+func TestPaginateFunc_CustomScan(t *testing.T) {
+	db, _ := openFake(t, []string{"id", "name", "email_address"}, userRows(2))
+	resp, err := PaginateFunc(context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT * FROM users"},
+		func(rows *sql.Rows) (string, error) {
+			var id int64
+			var name, email string
+			err := rows.Scan(&id, &name, &email)
+			return name, err
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"usera", "userb"}, resp.Items)
+}
+
+func TestStructScan_UnknownColumn(t *testing.T) {
+	db, _ := openFake(t, []string{"id", "password"}, [][]driver.Value{{int64(1), "x"}})
+	_, err := Paginate[user](context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT id, password FROM users"})
+	require.ErrorIs(t, err, ErrUnknownColumn)
+	assert.Contains(t, err.Error(), "password")
+}
+
+type Audit struct {
+	CreatedAt time.Time `db:"created_at"`
+	Note      sql.NullString
+}
+
+type auditedUser struct {
+	*Audit
+	ID      int64        `db:"id"`
+	Deleted sql.NullTime `db:"deleted_at"`
+}
+
+func TestStructScan_ScannerFieldsAndEmbeddedPointer(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	db, _ := openFake(t, []string{"id", "created_at", "note", "deleted_at"},
+		[][]driver.Value{{int64(1), created, nil, created}})
+	resp, err := Paginate[auditedUser](context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT * FROM users"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+
+	u := resp.Items[0]
+	require.NotNil(t, u.Audit)
+	assert.Equal(t, created, u.CreatedAt)
+	assert.False(t, u.Note.Valid)
+	assert.Equal(t, sql.NullTime{Time: created, Valid: true}, u.Deleted)
+}
+
+func TestStructScan_ScannerAsSingleColumn(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Run("time", func(t *testing.T) {
+		db, _ := openFake(t, []string{"created_at"}, [][]driver.Value{{created}})
+		resp, err := Paginate[time.Time](context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT created_at FROM users"})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{created}, resp.Items)
+	})
+	t.Run("scanner", func(t *testing.T) {
+		db, _ := openFake(t, []string{"note"}, [][]driver.Value{{"hi"}, {nil}})
+		resp, err := Paginate[sql.NullString](context.Background(), db, &ginm.PageQuery{}, Query{SQL: "SELECT note FROM users"})
+		require.NoError(t, err)
+		assert.Equal(t, []sql.NullString{{String: "hi", Valid: true}, {}}, resp.Items)
+	})
+}
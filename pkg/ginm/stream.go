@@ -0,0 +1,125 @@
+package ginm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamFormat 是 WrapStream 的输出格式。
+type StreamFormat int
+
+const (
+	// StreamNDJSON 每个元素输出为一行 JSON，错误帧为 {"error": {...}}。
+	StreamNDJSON StreamFormat = iota
+	// StreamSSE 每个元素输出为一个 Server-Sent Event，错误帧的事件类型为 error。
+	StreamSSE
+)
+
+// streamErrorFrame 是 NDJSON 格式的错误帧。
+type streamErrorFrame struct {
+	Error *BatchItemError `json:"error"`
+}
+
+// WrapStream 绑定请求后调用处理器，并将返回的 channel 按 format 流式输出，直到 channel 关闭或客户端断开。
+// 处理器返回错误时按普通错误响应输出；开始输出后，无法序列化的元素或本身实现 error 的元素
+// 写入一个错误帧（字段与 BatchItemError 相同）并结束流。
+//
+// 客户端断开时 c.Request.Context() 被取消，WrapStream 停止读取 channel，
+// 生产者应同样监听该 Context 以免阻塞在发送上：
+//
+//	r.GET("/logs", ginm.WrapStream(func(c *gin.Context, req *LogQuery) (<-chan LogLine, error) {
+//	    ch := make(chan LogLine)
+//	    go func() {
+//	        defer close(ch)
+//	        for line := range tail(req) {
+//	            select {
+//	            case ch <- line:
+//	            case <-c.Request.Context().Done():
+//	                return
+//	            }
+//	        }
+//	    }()
+//	    return ch, nil
+//	}, ginm.StreamSSE))
+//
+// opts 中 WithStatus、WithBinder 和钩子选项生效，OnSuccess 在开始输出前以 channel 为 resp 调用；
+// 消息、业务码、链接和 ETag 选项对流式响应无效。
+func WrapStream[Req, Item any](handler func(c *gin.Context, req *Req) (<-chan Item, error), format StreamFormat, opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, <-chan Item](http.StatusOK, Bind[Req], opts)
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		ch, err := handler(c, req)
+		if err != nil {
+			w.fail(c, req, err)
+			return
+		}
+		w.succeed(c, req, ch)
+
+		writeStreamHeaders(c, format)
+		c.Status(w.cfg.status)
+		done := c.Request.Context().Done()
+		for {
+			select {
+			case <-done:
+				return
+			case item, ok := <-ch:
+				if !ok {
+					return
+				}
+				if err, isErr := any(item).(error); isErr {
+					writeStreamError(c, format, err)
+					return
+				}
+				data, err := json.Marshal(item)
+				if err != nil {
+					writeStreamError(c, format, err)
+					return
+				}
+				writeStreamFrame(c, format, "", data)
+			}
+		}
+	}
+}
+
+func writeStreamHeaders(c *gin.Context, format StreamFormat) {
+	if format == StreamSSE {
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		return
+	}
+	c.Header("Content-Type", "application/x-ndjson")
+}
+
+// writeStreamFrame 写入一帧并立即刷新，event 仅用于 SSE。
+func writeStreamFrame(c *gin.Context, format StreamFormat, event string, data []byte) {
+	if format == StreamSSE {
+		if event != "" {
+			_, _ = fmt.Fprintf(c.Writer, "event: %s\n", event)
+		}
+		_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+	} else {
+		_, _ = c.Writer.Write(data)
+		_, _ = c.Writer.Write([]byte("\n"))
+	}
+	c.Writer.Flush()
+}
+
+// writeStreamError 按错误处理的分类规则写入错误帧。
+func writeStreamError(c *gin.Context, format StreamFormat, err error) {
+	frame := batchItemError(c, err)
+	if format == StreamSSE {
+		data, _ := json.Marshal(frame)
+		writeStreamFrame(c, format, "error", data)
+		return
+	}
+	data, _ := json.Marshal(streamErrorFrame{Error: frame})
+	writeStreamFrame(c, format, "", data)
+}
@@ -0,0 +1,108 @@
+package ginm
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamReq struct {
+	N int `form:"n"`
+}
+
+func feed[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func TestWrapStream_NDJSON(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/nums", WrapStream(func(c *gin.Context, req *streamReq) (<-chan float64, error) {
+		if req.N < 0 {
+			return nil, ErrBadRequest("negative n")
+		}
+		return feed(1, float64(req.N), math.Inf(1), 4), nil
+	}, StreamNDJSON))
+
+	w := serve(r, http.MethodGet, "/nums?n=2", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "1\n2\n"+`{"error":{"message":"internal server error","code":500,"status":500}}`+"\n", w.Body.String())
+
+	w = serve(r, http.MethodGet, "/nums?n=-1", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "negative n")
+}
+
+func TestWrapStream_SSEErrorItem(t *testing.T) {
+	r := newHandlerEngine(http.MethodGet, "/events", WrapStream(func(c *gin.Context, req *streamReq) (<-chan any, error) {
+		return feed[any](map[string]int{"n": 1}, ErrConflict("state changed"), "unreachable"), nil
+	}, StreamSSE))
+
+	w := serve(r, http.MethodGet, "/events", "")
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "data: {\"n\":1}\n\n"+
+		"event: error\ndata: {\"message\":\"state changed\",\"code\":409,\"status\":409}\n\n", w.Body.String())
+}
+
+func TestWrapStream_StopsOnClientDisconnect(t *testing.T) {
+	never := make(chan int)
+	r := newHandlerEngine(http.MethodGet, "/wait", WrapStream(func(c *gin.Context, req *streamReq) (<-chan int, error) {
+		return never, nil
+	}, StreamNDJSON))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wait", nil).WithContext(ctx))
+	}()
+	cancel()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after client disconnect")
+	}
+}
+
+func TestWrapStream_AppliesWrapOptions(t *testing.T) {
+	var events []string
+	r := newHandlerEngine(http.MethodGet, "/nums", WrapStream(func(c *gin.Context, req *streamReq) (<-chan int, error) {
+		if req.N < 0 {
+			return nil, ErrBadRequest("negative n")
+		}
+		return feed(req.N), nil
+	}, StreamNDJSON,
+		WithStatus(http.StatusAccepted),
+		WithOnBind(func(c *gin.Context, req *streamReq) error {
+			if req.N == 0 {
+				return ErrForbidden("denied")
+			}
+			return nil
+		}),
+		WithOnSuccess(func(c *gin.Context, req *streamReq, ch <-chan int) {
+			events = append(events, "success")
+		}),
+		WithOnError(func(c *gin.Context, req *streamReq, err error) {
+			events = append(events, "error "+err.Error())
+		}),
+	))
+
+	w := serve(r, http.MethodGet, "/nums?n=3", "")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, w.Header().Get("Transfer-Encoding"))
+	assert.Equal(t, "3\n", w.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/nums?n=0", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodGet, "/nums?n=-1", "").Code)
+	assert.Equal(t, []string{"success", "error denied", "error negative n"}, events)
+}
@@ -0,0 +1,154 @@
+package ginm
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantSource 是租户标识的来源。
+type TenantSource string
+
+const (
+	// TenantFromHeader 从请求头读取租户，见 TenantResolverConfig.Header。
+	TenantFromHeader TenantSource = "header"
+	// TenantFromSubdomain 从 BaseDomain 的一级子域读取租户，例如 acme.example.com 中的 acme。
+	TenantFromSubdomain TenantSource = "subdomain"
+	// TenantFromPath 从路由路径参数读取租户，例如 r.Group("/t/:tenant") 中的 :tenant。
+	TenantFromPath TenantSource = "path"
+)
+
+// Tenant 是当前请求所属的租户。
+type Tenant struct {
+	// ID 是租户标识。
+	ID string `json:"id"`
+	// Name 是租户名称，由 Validate 回调填充。
+	Name string `json:"name,omitempty"`
+	// Source 是租户标识的来源。
+	Source TenantSource `json:"source"`
+	// Data 是 Validate 回调附加的应用数据，例如租户配置或套餐。
+	Data any `json:"-"`
+}
+
+// TenantKey 用于存储 TenantResolver 解析的租户。
+var TenantKey = NewContextKey[Tenant]("ginm:tenant")
+
+// GetTenant 返回 TenantResolver 解析的租户。
+func GetTenant(c *gin.Context) (Tenant, bool) {
+	return Get(c, TenantKey)
+}
+
+// TenantResolverConfig 包含租户解析中间件的配置。
+type TenantResolverConfig struct {
+	// Sources 是按顺序尝试的租户来源，取第一个非空值。默认值: [TenantFromHeader]
+	Sources []TenantSource
+	// Header 是 TenantFromHeader 读取的请求头。默认值: "X-Tenant-ID"
+	Header string
+	// BaseDomain 是 TenantFromSubdomain 的基础域名，例如 "example.com"。使用子域来源时必须设置。
+	BaseDomain string
+	// PathParam 是 TenantFromPath 读取的路径参数名。默认值: "tenant"
+	PathParam string
+	// Validate 校验租户标识并返回租户，返回的 Tenant 中 ID 和 Source 为空时自动填充。
+	// 租户不存在或无权访问时应返回 *APIError（例如 ErrNotFound），错误按标准错误信封输出。为 nil 时接受任意租户。
+	Validate func(c *gin.Context, id string) (Tenant, error)
+	// Optional 为 true 时未提供租户的请求照常处理，否则返回 400。
+	Optional bool
+}
+
+// TenantResolver 返回解析租户的中间件，校验通过后将租户标识存入 TenantIDKey，完整租户存入 TenantKey：
+//
+//	api := r.Group("/api", ginm.TenantResolver(ginm.TenantResolverConfig{
+//		Sources:    []ginm.TenantSource{ginm.TenantFromSubdomain, ginm.TenantFromHeader},
+//		BaseDomain: "example.com",
+//		Validate: func(c *gin.Context, id string) (ginm.Tenant, error) {
+//			t, err := tenants.Find(c, id)
+//			if err != nil {
+//				return ginm.Tenant{}, ginm.ErrNotFound("tenant not found")
+//			}
+//			return ginm.Tenant{Name: t.Name, Data: t}, nil
+//		},
+//	}))
+func TenantResolver(cfg TenantResolverConfig) gin.HandlerFunc {
+	if len(cfg.Sources) == 0 {
+		cfg.Sources = []TenantSource{TenantFromHeader}
+	}
+	if cfg.Header == "" {
+		cfg.Header = "X-Tenant-ID"
+	}
+	if cfg.PathParam == "" {
+		cfg.PathParam = "tenant"
+	}
+	for _, s := range cfg.Sources {
+		switch s {
+		case TenantFromHeader, TenantFromPath:
+		case TenantFromSubdomain:
+			if cfg.BaseDomain == "" {
+				panic("TenantResolver: BaseDomain is required for subdomain source")
+			}
+		default:
+			panic("TenantResolver: unknown tenant source " + string(s))
+		}
+	}
+	baseSuffix := "." + strings.ToLower(strings.Trim(cfg.BaseDomain, "."))
+
+	return func(c *gin.Context) {
+		var id string
+		var source TenantSource
+		for _, s := range cfg.Sources {
+			switch s {
+			case TenantFromHeader:
+				id = strings.TrimSpace(c.GetHeader(cfg.Header))
+			case TenantFromSubdomain:
+				id = subdomainOf(c.Request.Host, baseSuffix)
+			case TenantFromPath:
+				id = c.Param(cfg.PathParam)
+			}
+			if id != "" {
+				source = s
+				break
+			}
+		}
+		if id == "" {
+			if cfg.Optional {
+				c.Next()
+				return
+			}
+			handleError(c, ErrBadRequest("tenant required"))
+			c.Abort()
+			return
+		}
+
+		tenant := Tenant{ID: id}
+		if cfg.Validate != nil {
+			t, err := cfg.Validate(c, id)
+			if err != nil {
+				handleError(c, err)
+				c.Abort()
+				return
+			}
+			tenant = t
+			if tenant.ID == "" {
+				tenant.ID = id
+			}
+		}
+		if tenant.Source == "" {
+			tenant.Source = source
+		}
+		Set(c, TenantIDKey, tenant.ID)
+		Set(c, TenantKey, tenant)
+		c.Next()
+	}
+}
+
+// subdomainOf 返回 host 在 baseSuffix（形如 ".example.com"）下的一级子域，多级子域或不匹配时返回空字符串。
+func subdomainOf(host, baseSuffix string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	sub, ok := strings.CutSuffix(strings.ToLower(host), baseSuffix)
+	if !ok || sub == "" || strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
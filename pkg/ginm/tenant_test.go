@@ -0,0 +1,71 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoTenant 返回解析出的租户。
+func echoTenant(c *gin.Context) {
+	tenant, _ := GetTenant(c)
+	id, _ := Get(c, TenantIDKey)
+	c.JSON(http.StatusOK, gin.H{"id": id, "name": tenant.Name, "source": tenant.Source})
+}
+
+func TestTenantResolver_Sources(t *testing.T) {
+	resolver := TenantResolver(TenantResolverConfig{
+		Sources:    []TenantSource{TenantFromPath, TenantFromSubdomain, TenantFromHeader},
+		BaseDomain: "example.com",
+	})
+	r := newTestEngine()
+	r.GET("/me", resolver, echoTenant)
+	r.GET("/t/:tenant/me", resolver, echoTenant)
+
+	w := serve(r, http.MethodGet, "/me", "", "X-Tenant-ID", "acme")
+	assert.JSONEq(t, `{"id":"acme","name":"","source":"header"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/me", "", "Host", "Globex.example.com:8080")
+	assert.JSONEq(t, `{"id":"globex","name":"","source":"subdomain"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/t/initech/me", "", "Host", "globex.example.com")
+	assert.JSONEq(t, `{"id":"initech","name":"","source":"path"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/me", "", "Host", "a.b.example.com")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"code":400,"message":"tenant required"}`, w.Body.String())
+}
+
+func TestTenantResolver_Validate(t *testing.T) {
+	r := newTestEngine(TenantResolver(TenantResolverConfig{
+		Validate: func(c *gin.Context, id string) (Tenant, error) {
+			if id != "acme" {
+				return Tenant{}, ErrNotFound("tenant not found")
+			}
+			return Tenant{Name: "Acme Corp"}, nil
+		},
+	}))
+	r.GET("/me", echoTenant)
+
+	w := serve(r, http.MethodGet, "/me", "", "X-Tenant-ID", "acme")
+	assert.JSONEq(t, `{"id":"acme","name":"Acme Corp","source":"header"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/me", "", "X-Tenant-ID", "other")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"code":404,"message":"tenant not found"}`, w.Body.String())
+}
+
+func TestTenantResolver_Optional(t *testing.T) {
+	r := newTestEngine(TenantResolver(TenantResolverConfig{Optional: true}))
+	r.GET("/me", echoTenant)
+	w := serve(r, http.MethodGet, "/me", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"","name":"","source":""}`, w.Body.String())
+}
+
+func TestTenantResolver_InvalidConfig(t *testing.T) {
+	assert.Panics(t, func() { TenantResolver(TenantResolverConfig{Sources: []TenantSource{TenantFromSubdomain}}) })
+	assert.Panics(t, func() { TenantResolver(TenantResolverConfig{Sources: []TenantSource{"cookie"}}) })
+}
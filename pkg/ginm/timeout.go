@@ -0,0 +1,189 @@
+package ginm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeoutWriter 在超时后丢弃处理器的所有写入，避免迟到的处理器输出第二个响应。
+type timeoutWriter struct {
+	gin.ResponseWriter
+	mu       sync.Mutex
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return make(http.Header)
+	}
+	return w.ResponseWriter.Header()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.timedOut {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.timedOut {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *timeoutWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.timedOut {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// timeout 标记超时，返回处理器此前是否已经开始写入响应。
+func (w *timeoutWriter) timeout() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+	return w.ResponseWriter.Written()
+}
+
+// WrapWithTimeout 与 Wrap 相同，但处理器在截止时间为 d 的 Context 下运行（c.Request.Context()）。
+// 超时后立即返回 504 和标准错误信封，不再等待处理器；处理器返回 context.DeadlineExceeded 时同样返回 504。
+//
+// 处理器在独立的 goroutine 中使用 gin.Context 的副本运行，超时后对响应的写入会被丢弃，
+// 对副本的 Set 不会反映到原 Context。处理器应监听 Context 以便尽早退出。处理器中的 panic
+// 会在请求 goroutine 中重新抛出，交给 Recovery 中间件处理。
+func WrapWithTimeout[Req, Resp any](handler HandlerFunc[Req, Resp], d time.Duration, opts ...WrapOption) gin.HandlerFunc {
+	w := newWrapper[Req, Resp](http.StatusOK, Bind[Req], opts)
+	type result struct {
+		resp     Resp
+		err      error
+		panicked any
+	}
+	return func(c *gin.Context) {
+		req, ok := w.bind(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		tw := &timeoutWriter{ResponseWriter: c.Writer}
+		hc := c.Copy()
+		hc.Request = c.Request.WithContext(ctx)
+		hc.Writer = tw
+
+		done := make(chan result, 1)
+		go func() {
+			var r result
+			defer func() {
+				r.panicked = recover()
+				done <- r
+			}()
+			r.resp, r.err = handler(hc, req)
+		}()
+
+		select {
+		case r := <-done:
+			if r.panicked != nil {
+				panic(r.panicked)
+			}
+			if r.err != nil {
+				if errors.Is(r.err, context.DeadlineExceeded) && ctx.Err() != nil {
+					r.err = errHandlerTimeout()
+				}
+				w.fail(c, req, r.err)
+				return
+			}
+			w.respond(c, req, r.resp)
+		case <-ctx.Done():
+			if tw.timeout() || c.Request.Context().Err() != nil {
+				// 处理器已开始写入，或客户端已断开
+				c.Abort()
+				return
+			}
+			w.fail(c, req, errHandlerTimeout())
+		}
+	}
+}
+
+// Timeout 返回分组级超时中间件：后续处理器在截止时间为 d 的 Context 下运行（c.Request.Context()），
+// 超时后立即以 504 和标准错误信封响应客户端，处理器此后对响应的写入会被丢弃。
+// 处理器已开始写入响应时保留其输出，不再写入 504。
+//
+//	api := r.Group("/api", ginm.Timeout(5*time.Second))
+//
+// 后续处理器在独立的 goroutine 中运行，中间件在超时响应后仍会等待其返回，以免 gin.Context 被提前回收，
+// 因此处理器应监听 Context 以便尽早退出。处理器中的 panic 会在请求 goroutine 中重新抛出。
+// 单个路由可使用 WrapWithTimeout。
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := c.Request
+		ctx, cancel := context.WithTimeout(req.Context(), d)
+		defer cancel()
+
+		tw := &timeoutWriter{ResponseWriter: c.Writer}
+		c.Request = req.WithContext(ctx)
+		c.Writer = tw
+
+		done := make(chan any, 1)
+		go func() {
+			defer func() { done <- recover() }()
+			c.Next()
+		}()
+
+		var panicked any
+		select {
+		case panicked = <-done:
+		case <-ctx.Done():
+			if !tw.timeout() && req.Context().Err() == nil {
+				// 处理器 goroutine 仍在使用 c，在副本上经原始 ResponseWriter 输出 504
+				ec := c.Copy()
+				ec.Writer = tw.ResponseWriter
+				handleError(ec, errHandlerTimeout())
+				tw.ResponseWriter.Flush()
+			}
+			panicked = <-done
+			c.Abort()
+		}
+		c.Writer = tw.ResponseWriter
+		c.Request = req
+		if panicked != nil {
+			panic(panicked)
+		}
+	}
+}
+
+func errHandlerTimeout() *APIError {
+	return NewAPIError(http.StatusGatewayTimeout, http.StatusGatewayTimeout, "handler timeout")
+}
@@ -0,0 +1,102 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutReq struct {
+	Wait time.Duration `form:"wait"`
+}
+
+func TestWrapWithTimeout(t *testing.T) {
+	late := make(chan struct{})
+	r := newHandlerEngine(http.MethodGet, "/slow", WrapWithTimeout(func(c *gin.Context, req *timeoutReq) (string, error) {
+		select {
+		case <-time.After(req.Wait):
+			return "done", nil
+		case <-c.Request.Context().Done():
+			if req.Wait > time.Minute {
+				// 忽略截止时间，超时后仍尝试写入
+				<-time.After(20 * time.Millisecond)
+				c.String(http.StatusTeapot, "late")
+				close(late)
+				return "", nil
+			}
+			return "", c.Request.Context().Err()
+		}
+	}, 30*time.Millisecond))
+
+	w := serve(r, http.MethodGet, "/slow?wait=1ms", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"done"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/slow?wait=1s", "")
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"handler timeout"`)
+
+	w = serve(r, http.MethodGet, "/slow?wait=1h", "")
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	<-late
+	assert.NotContains(t, w.Body.String(), "late")
+}
+
+func TestWrapWithTimeout_RethrowsPanic(t *testing.T) {
+	r := newTestEngine()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"panic": err})
+	}))
+	r.GET("/panic", WrapWithTimeout(func(c *gin.Context, req *timeoutReq) (string, error) {
+		panic("boom")
+	}, time.Second))
+
+	w := serve(r, http.MethodGet, "/panic", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"panic":"boom"}`, w.Body.String())
+}
+
+func TestTimeout(t *testing.T) {
+	late := make(chan struct{})
+	r := newTestEngine()
+	g := r.Group("/", Timeout(30*time.Millisecond))
+	g.GET("/fast", func(c *gin.Context) { Success(c, "done") })
+	g.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		time.Sleep(10 * time.Millisecond)
+		Success(c, "late")
+	})
+	g.GET("/ignore", func(c *gin.Context) {
+		time.Sleep(60 * time.Millisecond)
+		c.String(http.StatusTeapot, "late")
+		close(late)
+	})
+
+	w := serve(r, http.MethodGet, "/fast", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"code":0,"data":"done"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/slow", "")
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.JSONEq(t, `{"code":504,"message":"handler timeout"}`, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/ignore", "")
+	<-late
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.NotContains(t, w.Body.String(), "late")
+}
+
+func TestTimeout_RethrowsPanic(t *testing.T) {
+	r := newTestEngine()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"panic": err})
+	}))
+	r.GET("/panic", Timeout(time.Second), func(c *gin.Context) { panic("boom") })
+
+	w := serve(r, http.MethodGet, "/panic", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"panic":"boom"}`, w.Body.String())
+}
@@ -0,0 +1,67 @@
+package ginm
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseTransformer 在序列化前转换成功响应的 data，返回值替换原 data。
+type ResponseTransformer func(c *gin.Context, data any) any
+
+var (
+	responseTransformers atomic.Pointer[[]ResponseTransformer]
+	transformersMu       sync.Mutex
+)
+
+// RegisterResponseTransformer 注册全局响应转换器，按注册顺序依次执行，前一个的返回值作为后一个的输入。
+// 适用于字段脱敏、ID 混淆等横切逻辑，对 JSON 以及所有 Wrap 系列和 Success 系列函数的成功响应
+// （状态码小于 400）生效，错误响应和 WrapStream 等流式输出不经过转换器。通常在启动时调用：
+//
+//	ginm.RegisterResponseTransformer(func(c *gin.Context, data any) any {
+//	    if u, ok := data.(*User); ok {
+//	        masked := *u
+//	        masked.Phone = mask(u.Phone)
+//	        return &masked
+//	    }
+//	    return data
+//	})
+//
+// 转换器不应修改传入的值，需要改动时应返回副本。
+func RegisterResponseTransformer(t ResponseTransformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	var transformers []ResponseTransformer
+	if old := responseTransformers.Load(); old != nil {
+		transformers = append(transformers, *old...)
+	}
+	transformers = append(transformers, t)
+	responseTransformers.Store(&transformers)
+}
+
+// ResetResponseTransformers 移除所有已注册的响应转换器。
+func ResetResponseTransformers() {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	responseTransformers.Store(nil)
+}
+
+// transformResponse 对成功响应依次应用转换器。转换结果仍为 T 时原地替换 resp.Data，
+// 否则返回以 Response[any] 输出的信封和 true。
+func transformResponse[T any](c *gin.Context, status int, resp *Response[T]) (Response[any], bool) {
+	transformers := responseTransformers.Load()
+	if transformers == nil || status >= http.StatusBadRequest {
+		return Response[any]{}, false
+	}
+	data := any(resp.Data)
+	for _, t := range *transformers {
+		data = t(c, data)
+	}
+	if typed, ok := data.(T); ok {
+		resp.Data = typed
+		return Response[any]{}, false
+	}
+	return Response[any]{Data: data, Message: resp.Message, Error: resp.Error, Code: resp.Code, Links: resp.Links}, true
+}
@@ -0,0 +1,74 @@
+package ginm
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type transformUser struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+func TestResponseTransformer_MasksWrapAndSuccess(t *testing.T) {
+	RegisterResponseTransformer(func(c *gin.Context, data any) any {
+		if u, ok := data.(*transformUser); ok {
+			masked := *u
+			masked.Phone = u.Phone[:3] + "****"
+			return &masked
+		}
+		return data
+	})
+	t.Cleanup(ResetResponseTransformers)
+
+	user := &transformUser{Name: "alice", Phone: "1381234"}
+	r := newTestEngine()
+	r.GET("/wrap", WrapNoReq(func(c *gin.Context) (*transformUser, error) { return user, nil }))
+	r.GET("/success", func(c *gin.Context) { Success(c, user) })
+
+	for _, path := range []string{"/wrap", "/success"} {
+		w := serve(r, http.MethodGet, path, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"code":0,"data":{"name":"alice","phone":"138****"}}`, w.Body.String())
+	}
+	assert.Equal(t, "1381234", user.Phone)
+}
+
+func TestResponseTransformer_ChainAndTypeChange(t *testing.T) {
+	RegisterResponseTransformer(func(c *gin.Context, data any) any {
+		if s, ok := data.(string); ok {
+			return strings.ToUpper(s)
+		}
+		return data
+	})
+	RegisterResponseTransformer(func(c *gin.Context, data any) any {
+		return map[string]any{"value": data, "request": c.Request.URL.Path}
+	})
+	t.Cleanup(ResetResponseTransformers)
+
+	r := newTestEngine()
+	r.GET("/greet", WrapNoReq(func(c *gin.Context) (string, error) { return "hi", nil }, WithSuccessMessage("ok")))
+
+	w := serve(r, http.MethodGet, "/greet", "")
+	assert.JSONEq(t, `{"code":0,"message":"ok","data":{"value":"HI","request":"/greet"}}`, w.Body.String())
+}
+
+func TestResponseTransformer_SkipsErrors(t *testing.T) {
+	called := false
+	RegisterResponseTransformer(func(c *gin.Context, data any) any {
+		called = true
+		return data
+	})
+	t.Cleanup(ResetResponseTransformers)
+
+	r := newTestEngine()
+	r.GET("/fail", WrapNoReq(func(c *gin.Context) (string, error) { return "", ErrNotFound("missing") }))
+
+	w := serve(r, http.MethodGet, "/fail", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.False(t, called)
+}
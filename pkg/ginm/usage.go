@@ -0,0 +1,151 @@
+package ginm
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// CallerFunc 从请求中提取调用方标识（API key、用户 ID 等），返回空字符串表示匿名。
+type CallerFunc func(c *gin.Context) string
+
+// DefaultCaller 依次使用上下文中的用户 ID 和 X-API-Key 请求头作为调用方标识。
+func DefaultCaller(c *gin.Context) string {
+	if id, ok := GetUserID(c); ok {
+		return "user:" + strconv.FormatInt(id, 10)
+	}
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return "key:" + key
+	}
+	return ""
+}
+
+// RouteUsage 是单个路由的使用统计。
+type RouteUsage struct {
+	LastUsed        time.Time `json:"last_used"`
+	Method          string    `json:"method"`
+	Path            string    `json:"path"`
+	Calls           int64     `json:"calls"`
+	DistinctCallers int       `json:"distinct_callers"`
+}
+
+// UsageConfig 包含使用统计的配置。
+type UsageConfig struct {
+	// Caller 提取调用方标识。默认值: DefaultCaller
+	Caller CallerFunc
+	// MaxCallersPerRoute 限制每个路由记录的调用方数量，避免内存无限增长。默认值: 10000
+	MaxCallersPerRoute int
+}
+
+// routeUsage 是路由统计的内部状态。
+type routeUsage struct {
+	callers  map[string]struct{}
+	lastUsed time.Time
+	calls    int64
+}
+
+// UsageCollector 按路由统计调用次数、不同调用方数量和最后使用时间，
+// 用于在删除前找出无人使用的接口。
+type UsageCollector struct {
+	routes map[string]*routeUsage
+	cfg    UsageConfig
+	mu     sync.Mutex
+}
+
+// NewUsageCollector 创建新的使用统计收集器。
+func NewUsageCollector(cfg UsageConfig) *UsageCollector {
+	if cfg.Caller == nil {
+		cfg.Caller = DefaultCaller
+	}
+	if cfg.MaxCallersPerRoute <= 0 {
+		cfg.MaxCallersPerRoute = 10000
+	}
+	return &UsageCollector{
+		cfg:    cfg,
+		routes: make(map[string]*routeUsage),
+	}
+}
+
+// Middleware 返回记录路由使用情况的中间件。未匹配的路由不会被记录。
+func (u *UsageCollector) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		path := c.FullPath()
+		if path == "" {
+			return
+		}
+		u.record(c.Request.Method+" "+path, u.cfg.Caller(c))
+	}
+}
+
+// record 记录一次调用。
+func (u *UsageCollector) record(key, caller string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage, ok := u.routes[key]
+	if !ok {
+		usage = &routeUsage{callers: make(map[string]struct{})}
+		u.routes[key] = usage
+	}
+	usage.calls++
+	usage.lastUsed = time.Now()
+	if caller != "" && len(usage.callers) < u.cfg.MaxCallersPerRoute {
+		usage.callers[caller] = struct{}{}
+	}
+}
+
+// Snapshot 返回按方法和路径排序的使用统计快照。
+func (u *UsageCollector) Snapshot() []RouteUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	result := make([]RouteUsage, 0, len(u.routes))
+	gox.RangeSorted(u.routes, func(key string, usage *routeUsage) bool {
+		method, path, _ := strings.Cut(key, " ")
+		result = append(result, RouteUsage{
+			Method:          method,
+			Path:            path,
+			Calls:           usage.calls,
+			DistinctCallers: len(usage.callers),
+			LastUsed:        usage.lastUsed,
+		})
+		return true
+	})
+	return result
+}
+
+// Unused 返回 routes 中从未被调用过的路由，通常传入 engine.Routes()。
+func (u *UsageCollector) Unused(routes gin.RoutesInfo) gin.RoutesInfo {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var unused gin.RoutesInfo
+	for _, r := range routes {
+		if _, ok := u.routes[r.Method+" "+r.Path]; !ok {
+			unused = append(unused, r)
+		}
+	}
+	return unused
+}
+
+// Reset 清空所有统计。
+func (u *UsageCollector) Reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.routes = make(map[string]*routeUsage)
+}
+
+// Handler 返回输出使用统计的处理器，应注册在内部路由上。
+//
+//	internal.GET("/usage", collector.Handler())
+func (u *UsageCollector) Handler() gin.HandlerFunc {
+	return WrapNoReq(func(c *gin.Context) (ListResponse[RouteUsage], error) {
+		return NewListResponse(u.Snapshot()), nil
+	})
+}
@@ -0,0 +1,91 @@
+package ginm
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageCollector_CountsCallsAndDistinctCallers(t *testing.T) {
+	u := NewUsageCollector(UsageConfig{})
+	r := newTestEngine(u.Middleware())
+	r.GET("/users/:id", okHandler)
+	r.POST("/users", okHandler)
+
+	serve(r, http.MethodGet, "/users/1", "", "X-API-Key", "a")
+	serve(r, http.MethodGet, "/users/2", "", "X-API-Key", "a")
+	serve(r, http.MethodGet, "/users/3", "", "X-API-Key", "b")
+	serve(r, http.MethodPost, "/users", "")
+	serve(r, http.MethodGet, "/missing", "", "X-API-Key", "a")
+
+	snapshot := u.Snapshot()
+	require.Len(t, snapshot, 2)
+
+	assert.Equal(t, http.MethodGet, snapshot[0].Method)
+	assert.Equal(t, "/users/:id", snapshot[0].Path)
+	assert.Equal(t, int64(3), snapshot[0].Calls)
+	assert.Equal(t, 2, snapshot[0].DistinctCallers)
+	assert.False(t, snapshot[0].LastUsed.IsZero())
+
+	assert.Equal(t, http.MethodPost, snapshot[1].Method)
+	assert.Equal(t, 0, snapshot[1].DistinctCallers)
+}
+
+func TestUsageCollector_UsesCustomCallerAndLimit(t *testing.T) {
+	u := NewUsageCollector(UsageConfig{
+		Caller:             func(c *gin.Context) string { return c.Query("who") },
+		MaxCallersPerRoute: 1,
+	})
+	r := newTestEngine(u.Middleware())
+	r.GET("/users/:id", okHandler)
+
+	serve(r, http.MethodGet, "/users/1?who=x", "")
+	serve(r, http.MethodGet, "/users/1?who=y", "")
+
+	assert.Equal(t, 1, u.Snapshot()[0].DistinctCallers)
+}
+
+func TestUsageCollector_Unused(t *testing.T) {
+	u := NewUsageCollector(UsageConfig{})
+	r := newTestEngine(u.Middleware())
+	r.GET("/users/:id", okHandler)
+	r.POST("/users", okHandler)
+	r.GET("/legacy", okHandler)
+	serve(r, http.MethodGet, "/users/1", "")
+	serve(r, http.MethodPost, "/users", "")
+
+	unused := u.Unused(r.Routes())
+	require.Len(t, unused, 1)
+	assert.Equal(t, "/legacy", unused[0].Path)
+
+	u.Reset()
+	assert.Len(t, u.Unused(r.Routes()), 3)
+}
+
+func TestUsageCollector_Handler(t *testing.T) {
+	u := NewUsageCollector(UsageConfig{})
+	r := newTestEngine(u.Middleware())
+	r.GET("/users/:id", okHandler)
+	r.GET("/internal/usage", u.Handler())
+	serve(r, http.MethodGet, "/users/1", "")
+
+	w := serve(r, http.MethodGet, "/internal/usage", "")
+
+	var resp Response[ListResponse[RouteUsage]]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Data.Count)
+	assert.Equal(t, "/users/:id", resp.Data.Items[0].Path)
+}
+
+func TestDefaultCaller_PrefersUserID(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/", nil, "")
+	c.Request.Header.Set("X-API-Key", "k")
+	assert.Equal(t, "key:k", DefaultCaller(c))
+
+	SetUserID(c, 7)
+	assert.Equal(t, "user:7", DefaultCaller(c))
+}
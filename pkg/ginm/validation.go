@@ -0,0 +1,170 @@
+package ginm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// validationMessages 是各校验标签的默认英文消息，%s 为标签参数。
+// 长度类标签按字段类型使用 .string（字符数）或 .items（元素数）后缀。
+var validationMessages = map[string]string{
+	"required":      "is required",
+	"email":         "must be a valid email address",
+	"url":           "must be a valid URL",
+	"uri":           "must be a valid URI",
+	"uuid":          "must be a valid UUID",
+	"numeric":       "must be numeric",
+	"alpha":         "must contain only letters",
+	"alphanum":      "must contain only letters and numbers",
+	"oneof":         "must be one of: %s",
+	"eqfield":       "must equal %s",
+	"min":           "must be at least %s",
+	"min.string":    "must be at least %s characters",
+	"min.items":     "must contain at least %s items",
+	"max":           "must be at most %s",
+	"max.string":    "must be at most %s characters",
+	"max.items":     "must contain at most %s items",
+	"len":           "must be %s",
+	"len.string":    "must be exactly %s characters",
+	"len.items":     "must contain exactly %s items",
+	"gt":            "must be greater than %s",
+	"gte":           "must be greater than or equal to %s",
+	"lt":            "must be less than %s",
+	"lte":           "must be less than or equal to %s",
+	"datetime":      "must match the format %s",
+	"ip":            "must be a valid IP address",
+	"unique":        "must contain unique values",
+	"required_if":   "is required",
+	"required_with": "is required",
+}
+
+// validationAliases 将同义标签映射到 validationMessages 中的键。
+var validationAliases = map[string]string{
+	"http_url":         "url",
+	"uuid4":            "uuid",
+	"ipv4":             "ip",
+	"ipv6":             "ip",
+	"required_unless":  "required_if",
+	"required_without": "required_with",
+}
+
+// bindError 创建绑定错误；因 validator 标签校验失败时，Err 为按 T 的字段标签转换后的 *ValidationErrors，
+// 消息按当前请求的语言翻译，由 handleError 以 422 输出。
+func bindError[T any](c *gin.Context, source string, err error) *BindError {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		err = translateValidation(c, reflect.TypeFor[T](), source, fieldErrs)
+	}
+	return NewBindError(source, err)
+}
+
+// translateValidation 将 validator 的字段错误转换为 ValidationErrors。
+func translateValidation(c *gin.Context, t reflect.Type, source string, fieldErrs validator.ValidationErrors) *ValidationErrors {
+	v := &ValidationErrors{cause: fieldErrs}
+	for _, fe := range fieldErrs {
+		v.Add(validationFieldPath(t, source, fe.StructNamespace()), validationMessage(c, fe))
+	}
+	return v
+}
+
+// validationMessage 返回字段错误的可读消息，优先使用 "validation.<键>" 的翻译。
+func validationMessage(c *gin.Context, fe validator.FieldError) string {
+	key := validationMessageKey(fe)
+	tmpl, ok := validationMessages[key]
+	if !ok {
+		return fmt.Sprintf(Translate(c, "validation.default", "failed on the '%s' rule"), fe.Tag())
+	}
+	tmpl = Translate(c, "validation."+key, tmpl)
+	if strings.Contains(tmpl, "%s") {
+		return fmt.Sprintf(tmpl, fe.Param())
+	}
+	return tmpl
+}
+
+// validationMessageKey 返回字段错误对应的消息键。
+func validationMessageKey(fe validator.FieldError) string {
+	tag := fe.Tag()
+	if alias, ok := validationAliases[tag]; ok {
+		tag = alias
+	}
+	switch tag {
+	case "min", "max", "len":
+		switch fe.Kind() {
+		case reflect.String:
+			return tag + ".string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return tag + ".items"
+		}
+	}
+	return tag
+}
+
+// validationFieldPath 将 "Req.Address.City" 形式的命名空间转换为客户端可见的字段路径，例如 "address.city"。
+// 每一段按绑定来源对应的标签（json、form、uri、header）命名，匿名嵌入的结构体不出现在路径中。
+func validationFieldPath(t reflect.Type, source, namespace string) string {
+	segments := strings.Split(namespace, ".")[1:]
+	names := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		name, index, _ := strings.Cut(seg, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		t = derefType(t)
+		var f reflect.StructField
+		ok := false
+		if t != nil && t.Kind() == reflect.Struct {
+			f, ok = t.FieldByName(name)
+		}
+		if !ok {
+			names = append(names, seg)
+			t = nil
+			continue
+		}
+
+		t = f.Type
+		if index != "" {
+			t = derefType(t).Elem()
+		}
+		tagged := validationFieldName(f, source)
+		if f.Anonymous && tagged == f.Name {
+			continue
+		}
+		names = append(names, tagged+index)
+	}
+	return strings.Join(names, ".")
+}
+
+// validationFieldName 返回字段在绑定来源中的名称，标签缺失时依次回退到 json 标签和字段名。
+func validationFieldName(f reflect.StructField, source string) string {
+	tags := []string{"json"}
+	switch source {
+	case "query", "form":
+		tags = []string{"form", "json"}
+	case "uri":
+		tags = []string{"uri", "json"}
+	case "header":
+		tags = []string{"header", "json"}
+	case "xml":
+		tags = []string{"xml", "json"}
+	}
+	for _, tag := range tags {
+		if name := tagName(f.Tag.Get(tag)); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// derefType 解除指针类型。
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
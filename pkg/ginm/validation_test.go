@@ -0,0 +1,120 @@
+package ginm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationAddress struct {
+	City string `json:"city" binding:"required"`
+}
+
+type validationItem struct {
+	SKU string `json:"sku" binding:"required,len=4"`
+}
+
+type validationReq struct {
+	PageQuery
+	Address *validationAddress `json:"address" binding:"required"`
+	Name    string             `json:"name" binding:"required,min=2"`
+	Email   string             `json:"email" binding:"omitempty,email"`
+	Role    string             `json:"role" binding:"omitempty,oneof=admin user"`
+	Items   []validationItem   `json:"items" binding:"max=2,dive"`
+	Age     int                `json:"age" binding:"gte=18"`
+}
+
+func bindValidation(t *testing.T, body string) *ValidationErrors {
+	t.Helper()
+	c := createTestContext(http.MethodPost, "/", []byte(body), "application/json")
+	_, err := BindJSON[validationReq](c)
+
+	var bindErr *BindError
+	require.ErrorAs(t, err, &bindErr)
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	return v
+}
+
+func TestBindJSON_TranslatesValidatorErrors(t *testing.T) {
+	v := bindValidation(t, `{
+		"address": {},
+		"name": "a",
+		"email": "nope",
+		"role": "root",
+		"items": [{"sku": "abcd"}, {"sku": "ab"}],
+		"age": 10
+	}`)
+
+	assert.Equal(t, []ValidationError{
+		{Field: "address.city", Message: "is required"},
+		{Field: "name", Message: "must be at least 2 characters"},
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "role", Message: "must be one of: admin user"},
+		{Field: "items[1].sku", Message: "must be exactly 4 characters"},
+		{Field: "age", Message: "must be greater than or equal to 18"},
+	}, v.Errors)
+}
+
+func TestBindJSON_KeepsValidatorCause(t *testing.T) {
+	c := createTestContext(http.MethodPost, "/", []byte(`{"age": 20}`), "application/json")
+	_, err := BindJSON[validationReq](c)
+
+	var fieldErrs validator.ValidationErrors
+	assert.True(t, errors.As(err, &fieldErrs))
+}
+
+func TestBindQuery_UsesFormTagsAndFlattensEmbedded(t *testing.T) {
+	c := createTestContext(http.MethodGet, "/?page_size=500&order=up", nil, "")
+	_, err := BindQuery[PageQuery](c)
+
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	assert.Equal(t, []ValidationError{
+		{Field: "order", Message: "must be one of: asc desc"},
+		{Field: "page_size", Message: "must be at most 100"},
+	}, v.Errors)
+}
+
+func TestValidationMessage_UnknownTag(t *testing.T) {
+	type req struct {
+		Code string `json:"code" binding:"hexadecimal"`
+	}
+	c := createTestContext(http.MethodPost, "/", []byte(`{"code": "zz"}`), "application/json")
+	_, err := BindJSON[req](c)
+
+	var v *ValidationErrors
+	require.ErrorAs(t, err, &v)
+	assert.Equal(t, "failed on the 'hexadecimal' rule", v.Errors[0].Message)
+}
+
+func TestWrapJSON_ValidationFailureReturns422(t *testing.T) {
+	r := newTestEngine()
+	r.POST("/users", WrapJSON(func(c *gin.Context, req *validationItem) (string, error) {
+		return req.SKU, nil
+	}))
+
+	w := serve(r, http.MethodPost, "/users", `{}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{
+		"code": 422,
+		"message": "validation failed",
+		"data": {"errors": [{"field": "sku", "message": "is required"}]}
+	}`, w.Body.String())
+}
+
+func TestWrapJSON_MalformedBodyStays400(t *testing.T) {
+	r := newTestEngine()
+	r.POST("/users", WrapJSON(func(c *gin.Context, req *validationItem) (string, error) {
+		return req.SKU, nil
+	}))
+
+	w := serve(r, http.MethodPost, "/users", `{bad`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,119 @@
+package ginm
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionKey 用于存储当前请求匹配的 API 版本，由 RegisterVersioned 设置。
+var APIVersionKey = NewContextKey[string]("ginm:api_version")
+
+// VersionConfig 包含 API 版本的生命周期配置。
+type VersionConfig struct {
+	// Deprecated 非零时输出 Deprecation 响应头（RFC 9745），表示该版本自此时刻起已弃用。
+	Deprecated time.Time
+	// Sunset 非零时输出 Sunset 响应头（RFC 8594），表示该版本计划停用的时刻。
+	Sunset time.Time
+	// Successor 是替代版本的路径前缀，例如 "/api/v2"。
+	// 非空时输出 rel="successor-version" 的 Link 响应头，Redirect 为 true 时作为重定向目标。
+	Successor string
+	// Retired 为 true 时不再注册版本的路由，所有请求返回 410 Gone。
+	Retired bool
+	// Redirect 为 true 且 Successor 非空时，已停用版本的请求以 308 重定向到 Successor 下的相同路径。
+	Redirect bool
+}
+
+// VersionOption 是 API 版本注册的函数式选项。
+type VersionOption func(*VersionConfig)
+
+// WithDeprecation 标记版本自 at 起弃用。
+func WithDeprecation(at time.Time) VersionOption {
+	return func(cfg *VersionConfig) {
+		cfg.Deprecated = at
+	}
+}
+
+// WithSunset 设置版本计划停用的时刻。
+func WithSunset(at time.Time) VersionOption {
+	return func(cfg *VersionConfig) {
+		cfg.Sunset = at
+	}
+}
+
+// WithSuccessor 设置替代版本的路径前缀。
+func WithSuccessor(prefix string) VersionOption {
+	return func(cfg *VersionConfig) {
+		cfg.Successor = prefix
+	}
+}
+
+// WithRetired 将版本标记为已停用，redirect 为 true 时重定向到 WithSuccessor 指定的版本，否则返回 410。
+func WithRetired(redirect bool) VersionOption {
+	return func(cfg *VersionConfig) {
+		cfg.Retired = true
+		cfg.Redirect = redirect
+	}
+}
+
+// RegisterVersioned 在 r 下创建 "/<version>" 路由组并调用 setup 注册该版本的路由，返回该路由组。
+// 组内请求可通过 APIVersionKey 取得版本，弃用信息以响应头告知客户端：
+//
+//	api := r.Group("/api")
+//	ginm.RegisterVersioned(api, "v1", setupV1,
+//	    ginm.WithDeprecation(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+//	    ginm.WithSunset(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)),
+//	    ginm.WithSuccessor("/api/v2"))
+//	ginm.RegisterVersioned(api, "v2", setupV2)
+//
+// 版本停用后改用 WithRetired，setup 不再被调用，组内所有路径返回 410 或重定向到替代版本。
+func RegisterVersioned(r gin.IRouter, version string, setup func(g *gin.RouterGroup), opts ...VersionOption) *gin.RouterGroup {
+	var cfg VersionConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	version = strings.Trim(version, "/")
+	g := r.Group("/"+version, versionMiddleware(version, cfg))
+	if cfg.Retired {
+		g.Any("/*path", retiredVersionHandler(version, g.BasePath(), cfg))
+		return g
+	}
+	setup(g)
+	return g
+}
+
+// versionMiddleware 记录版本并输出弃用相关的响应头。
+func versionMiddleware(version string, cfg VersionConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Set(c, APIVersionKey, version)
+		h := c.Writer.Header()
+		if !cfg.Deprecated.IsZero() {
+			h.Set("Deprecation", "@"+strconv.FormatInt(cfg.Deprecated.Unix(), 10))
+		}
+		if !cfg.Sunset.IsZero() {
+			h.Set("Sunset", cfg.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if cfg.Successor != "" {
+			h.Add("Link", "<"+cfg.Successor+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// retiredVersionHandler 处理已停用版本的请求。
+func retiredVersionHandler(version, basePath string, cfg VersionConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.Redirect && cfg.Successor != "" {
+			u := *c.Request.URL
+			u.Path = strings.TrimSuffix(cfg.Successor, "/") + strings.TrimPrefix(u.Path, basePath)
+			u.RawPath = ""
+			c.Redirect(http.StatusPermanentRedirect, u.RequestURI())
+			return
+		}
+		handleError(c, NewAPIError(http.StatusGone, http.StatusGone, "api version "+version+" has been retired"))
+	}
+}
@@ -0,0 +1,53 @@
+package ginm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// versionedUsers 注册返回当前 API 版本的 /users 路由。
+func versionedUsers(g *gin.RouterGroup) {
+	g.GET("/users", func(c *gin.Context) {
+		v, _ := Get(c, APIVersionKey)
+		Success(c, v)
+	})
+}
+
+func TestRegisterVersioned_DeprecationHeaders(t *testing.T) {
+	deprecated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 7, 1, 0, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	r := newTestEngine()
+	api := r.Group("/api")
+	RegisterVersioned(api, "v1", versionedUsers, WithDeprecation(deprecated), WithSunset(sunset), WithSuccessor("/api/v2"))
+	RegisterVersioned(api, "/v2/", versionedUsers)
+
+	w := serve(r, http.MethodGet, "/api/v1/users", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"data":"v1"`)
+	assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Tue, 30 Jun 2026 16:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, w.Header().Get("Link"))
+
+	w = serve(r, http.MethodGet, "/api/v2/users", "")
+	assert.Contains(t, w.Body.String(), `"data":"v2"`)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+}
+
+func TestRegisterVersioned_Retired(t *testing.T) {
+	r := newTestEngine()
+	RegisterVersioned(r.Group("/api"), "v1", versionedUsers, WithRetired(false))
+	w := serve(r, http.MethodGet, "/api/v1/users", "")
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Contains(t, w.Body.String(), "api version v1 has been retired")
+	assert.Equal(t, http.StatusGone, serve(r, http.MethodDelete, "/api/v1/anything/else", "").Code)
+
+	r = newTestEngine()
+	RegisterVersioned(r.Group("/api"), "v1", versionedUsers, WithRetired(true), WithSuccessor("/api/v2"))
+	w = serve(r, http.MethodPost, "/api/v1/users?page=2", "")
+	assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+	assert.Equal(t, "/api/v2/users?page=2", w.Header().Get("Location"))
+}
@@ -0,0 +1,139 @@
+package gox
+
+import "math/bits"
+
+// Bitset 是基于 []uint64 的稠密位集合，适用于特性开关掩码、权限集合等场景。
+// 零值是可用的空集合，非并发安全。
+//
+//	perms := gox.NewBitset(PermRead, PermWrite)
+//	if perms.Test(PermWrite) { ... }
+type Bitset struct {
+	words []uint64
+}
+
+// NewBitset 创建包含给定位的 Bitset。
+func NewBitset(positions ...int) *Bitset {
+	b := &Bitset{}
+	for _, i := range positions {
+		b.Set(i)
+	}
+	return b
+}
+
+// Set 设置第 i 位，按需扩容。i 为负数时 panic。
+func (b *Bitset) Set(i int) *Bitset {
+	w := wordIndex(i)
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w-len(b.words)+1)...)
+	}
+	b.words[w] |= 1 << (uint(i) % 64)
+	return b
+}
+
+// Clear 清除第 i 位。i 为负数时 panic。
+func (b *Bitset) Clear(i int) *Bitset {
+	if w := wordIndex(i); w < len(b.words) {
+		b.words[w] &^= 1 << (uint(i) % 64)
+	}
+	return b
+}
+
+// Test 检查第 i 位是否被设置，负数返回 false。
+func (b *Bitset) Test(i int) bool {
+	if i < 0 {
+		return false
+	}
+	w := i / 64
+	return w < len(b.words) && b.words[w]&(1<<(uint(i)%64)) != 0
+}
+
+// Count 返回被设置的位数。
+func (b *Bitset) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty 检查是否没有任何位被设置。
+func (b *Bitset) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// And 返回两个集合的交集，不修改原集合。
+func (b *Bitset) And(o *Bitset) *Bitset {
+	n := min(len(b.words), len(o.words))
+	result := &Bitset{words: make([]uint64, n)}
+	for i := range n {
+		result.words[i] = b.words[i] & o.words[i]
+	}
+	return result
+}
+
+// Or 返回两个集合的并集，不修改原集合。
+func (b *Bitset) Or(o *Bitset) *Bitset {
+	long, short := b.words, o.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	result := &Bitset{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// Equal 检查两个集合是否包含相同的位，忽略容量差异。
+func (b *Bitset) Equal(o *Bitset) bool {
+	long, short := b.words, o.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	for i, w := range long {
+		if i < len(short) {
+			if w != short[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Range 按升序遍历被设置的位，fn 返回 false 时停止。
+func (b *Bitset) Range(fn func(i int) bool) {
+	for w, word := range b.words {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			if !fn(w*64 + bit) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// Slice 返回按升序排列的所有被设置的位。
+func (b *Bitset) Slice() []int {
+	result := make([]int, 0, b.Count())
+	b.Range(func(i int) bool {
+		result = append(result, i)
+		return true
+	})
+	return result
+}
+
+// wordIndex 返回第 i 位所在的字下标，i 为负数时 panic。
+func wordIndex(i int) int {
+	if i < 0 {
+		panic("Bitset index must be non-negative")
+	}
+	return i / 64
+}
@@ -0,0 +1,59 @@
+package gox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitset_ZeroValueUsable(t *testing.T) {
+	var b Bitset
+	assert.False(t, b.Test(3))
+	assert.True(t, b.IsEmpty())
+	b.Set(3)
+	assert.True(t, b.Test(3))
+}
+
+func TestBitset_SetClearTest(t *testing.T) {
+	b := NewBitset(1, 64, 130)
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(130))
+	assert.False(t, b.Test(2))
+	assert.False(t, b.Test(-1))
+
+	b.Clear(64).Clear(1000)
+	assert.False(t, b.Test(64))
+	assert.Equal(t, 2, b.Count())
+}
+
+func TestBitset_NegativeIndexPanics(t *testing.T) {
+	assert.Panics(t, func() { NewBitset(-1) })
+	assert.Panics(t, func() { NewBitset().Clear(-1) })
+}
+
+func TestBitset_AndOr(t *testing.T) {
+	a := NewBitset(1, 2, 100)
+	b := NewBitset(2, 3)
+
+	assert.Equal(t, []int{2}, a.And(b).Slice())
+	assert.Equal(t, []int{1, 2, 3, 100}, a.Or(b).Slice())
+	assert.Equal(t, []int{1, 2, 3, 100}, b.Or(a).Slice())
+	assert.Equal(t, []int{1, 2, 100}, a.Slice(), "And/Or must not modify the receiver")
+}
+
+func TestBitset_Equal(t *testing.T) {
+	a := NewBitset(1, 200).Clear(200)
+	assert.True(t, a.Equal(NewBitset(1)))
+	assert.True(t, NewBitset(1).Equal(a))
+	assert.False(t, a.Equal(NewBitset(2)))
+}
+
+func TestBitset_RangeStopsEarly(t *testing.T) {
+	var got []int
+	NewBitset(5, 70, 71).Range(func(i int) bool {
+		got = append(got, i)
+		return i < 70
+	})
+	assert.Equal(t, []int{5, 70}, got)
+}
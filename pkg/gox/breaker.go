@@ -0,0 +1,180 @@
+package gox
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen 表示熔断器处于打开状态，调用被拒绝。
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// BreakerState 是熔断器状态。
+type BreakerState int
+
+const (
+	// BreakerClosed 正常放行调用。
+	BreakerClosed BreakerState = iota
+	// BreakerOpen 拒绝所有调用，直到 OpenTimeout 过后进入半开状态。
+	BreakerOpen
+	// BreakerHalfOpen 放行少量试探调用，成功则关闭，失败则重新打开。
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig 包含熔断器的配置。
+type BreakerConfig struct {
+	// OnStateChange 在状态变化时调用（不持有内部锁）。
+	OnStateChange func(from, to BreakerState)
+	// IsFailure 判断非 nil 错误是否计为失败，例如忽略 context.Canceled，成功（err 为 nil）时不调用。
+	// 默认值: 所有错误均计为失败
+	IsFailure func(err error) bool
+	// FailureThreshold 是触发熔断的连续失败次数。默认值: 5
+	FailureThreshold int
+	// OpenTimeout 是打开状态持续的时间。默认值: 30s
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls 是半开状态下允许同时进行的试探调用数。默认值: 1
+	HalfOpenMaxCalls int
+}
+
+// Breaker 是包装 func() (T, error) 的熔断器，并发安全。
+//
+//	getUser := gox.NewBreaker(func() (*User, error) { return client.GetUser(id) }, gox.BreakerConfig{})
+//	user, err := getUser.Call().GetWithError()
+type Breaker[T any] struct {
+	openedAt time.Time
+	now      func() time.Time
+	fn       func() (T, error)
+	cfg      BreakerConfig
+	state    BreakerState
+	failures int
+	inFlight int
+	mu       sync.Mutex
+}
+
+// NewBreaker 创建包装 fn 的熔断器，初始为关闭状态。
+func NewBreaker[T any](fn func() (T, error), cfg BreakerConfig) *Breaker[T] {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = func(error) bool { return true }
+	}
+	return &Breaker[T]{fn: fn, cfg: cfg, now: time.Now}
+}
+
+// State 返回当前状态。打开状态超时后即视为半开。
+func (b *Breaker[T]) State() BreakerState {
+	b.mu.Lock()
+	notify := b.advance()
+	state := b.state
+	b.mu.Unlock()
+	notify()
+	return state
+}
+
+// Call 在熔断器允许时调用 fn，否则返回 RErr(ErrBreakerOpen)。
+// fn panic 时计为一次失败（不经过 IsFailure），panic 继续向上传播。
+func (b *Breaker[T]) Call() Result[T] {
+	b.mu.Lock()
+	notify := b.advance()
+	if b.state == BreakerOpen || (b.state == BreakerHalfOpen && b.inFlight >= b.cfg.HalfOpenMaxCalls) {
+		b.mu.Unlock()
+		notify()
+		return RErr[T](ErrBreakerOpen)
+	}
+	b.inFlight++
+	b.mu.Unlock()
+	notify()
+
+	var r Result[T]
+	panicked := true
+	defer func() {
+		b.mu.Lock()
+		b.inFlight--
+		var notify func()
+		if panicked {
+			notify = b.recordFailure()
+		} else {
+			notify = b.record(r.Error())
+		}
+		b.mu.Unlock()
+		notify()
+	}()
+	r = Try(b.fn)
+	panicked = false
+	return r
+}
+
+// Reset 将熔断器强制恢复为关闭状态。
+func (b *Breaker[T]) Reset() {
+	b.mu.Lock()
+	b.failures = 0
+	notify := b.transition(BreakerClosed)
+	b.mu.Unlock()
+	notify()
+}
+
+// advance 在打开状态超时后转为半开，调用方须持有锁。
+func (b *Breaker[T]) advance() func() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return b.transition(BreakerHalfOpen)
+	}
+	return func() {}
+}
+
+// record 根据调用结果更新状态，调用方须持有锁。
+func (b *Breaker[T]) record(err error) func() {
+	if err == nil || !b.cfg.IsFailure(err) {
+		b.failures = 0
+		if b.state == BreakerHalfOpen {
+			return b.transition(BreakerClosed)
+		}
+		return func() {}
+	}
+	return b.recordFailure()
+}
+
+// recordFailure 记录一次失败，调用方须持有锁。
+func (b *Breaker[T]) recordFailure() func() {
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.cfg.FailureThreshold) {
+		b.openedAt = b.now()
+		return b.transition(BreakerOpen)
+	}
+	return func() {}
+}
+
+// transition 切换状态并返回在释放锁之后调用的通知函数。
+func (b *Breaker[T]) transition(to BreakerState) func() {
+	from := b.state
+	if from == to {
+		return func() {}
+	}
+	b.state = to
+	if to == BreakerClosed {
+		b.failures = 0
+	}
+	if b.cfg.OnStateChange == nil {
+		return func() {}
+	}
+	return func() { b.cfg.OnStateChange(from, to) }
+}
@@ -0,0 +1,137 @@
+package gox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type breakerFixture struct {
+	breaker     *Breaker[int]
+	transitions []string
+	err         error
+	now         time.Time
+}
+
+func newBreakerFixture(cfg BreakerConfig) *breakerFixture {
+	f := &breakerFixture{now: time.Unix(0, 0)}
+	cfg.OnStateChange = func(from, to BreakerState) {
+		f.transitions = append(f.transitions, from.String()+"->"+to.String())
+	}
+	f.breaker = NewBreaker(func() (int, error) { return 1, f.err }, cfg)
+	f.breaker.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 2})
+	f.err = errors.New("down")
+
+	assert.Error(t, f.breaker.Call().Error())
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+	assert.Error(t, f.breaker.Call().Error())
+	assert.Equal(t, BreakerOpen, f.breaker.State())
+
+	assert.ErrorIs(t, f.breaker.Call().Error(), ErrBreakerOpen)
+	assert.Equal(t, []string{"closed->open"}, f.transitions)
+}
+
+func TestBreaker_SuccessResetsFailureCount(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 2})
+	f.err = errors.New("down")
+	f.breaker.Call()
+	f.err = nil
+	f.breaker.Call()
+	f.err = errors.New("down")
+	f.breaker.Call()
+
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+}
+
+func TestBreaker_HalfOpenClosesOnSuccess(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	f.err = errors.New("down")
+	f.breaker.Call()
+
+	f.now = f.now.Add(time.Second)
+	assert.Equal(t, BreakerHalfOpen, f.breaker.State())
+
+	f.err = nil
+	assert.Equal(t, 1, f.breaker.Call().Unwrap())
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, f.transitions)
+}
+
+func TestBreaker_HalfOpenReopensOnFailure(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Second})
+	f.err = errors.New("down")
+	for range 3 {
+		f.breaker.Call()
+	}
+
+	f.now = f.now.Add(time.Second)
+	assert.Error(t, f.breaker.Call().Error())
+	assert.Equal(t, BreakerOpen, f.breaker.State())
+}
+
+func TestBreaker_IsFailureIgnoresErrors(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{
+		FailureThreshold: 1,
+		IsFailure:        func(err error) bool { return err != nil && !errors.Is(err, context.Canceled) },
+	})
+	f.err = context.Canceled
+	f.breaker.Call()
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+}
+
+func TestBreaker_IsFailureNotCalledOnSuccess(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{
+		FailureThreshold: 1,
+		IsFailure:        func(err error) bool { return !errors.Is(err, context.Canceled) },
+	})
+	assert.Equal(t, 1, f.breaker.Call().Unwrap())
+	assert.Equal(t, 1, f.breaker.Call().Unwrap())
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+
+	f.err = context.Canceled
+	f.breaker.Call()
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+	f.err = errors.New("down")
+	f.breaker.Call()
+	assert.Equal(t, BreakerOpen, f.breaker.State())
+}
+
+func TestBreaker_PanicCountsAsFailureAndReleasesSlot(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second, IsFailure: func(error) bool { return false }})
+	panicking := true
+	f.breaker.fn = func() (int, error) {
+		if panicking {
+			panic("boom")
+		}
+		return 1, nil
+	}
+
+	assert.PanicsWithValue(t, "boom", func() { f.breaker.Call() })
+	assert.Equal(t, BreakerOpen, f.breaker.State())
+
+	f.now = f.now.Add(time.Second)
+	assert.Panics(t, func() { f.breaker.Call() })
+	assert.Equal(t, BreakerOpen, f.breaker.State())
+
+	// 半开状态的调用名额在 panic 后被归还
+	f.now = f.now.Add(time.Second)
+	panicking = false
+	assert.Equal(t, 1, f.breaker.Call().Unwrap())
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+}
+
+func TestBreaker_Reset(t *testing.T) {
+	f := newBreakerFixture(BreakerConfig{FailureThreshold: 1})
+	f.err = errors.New("down")
+	f.breaker.Call()
+	f.breaker.Reset()
+	assert.Equal(t, BreakerClosed, f.breaker.State())
+}
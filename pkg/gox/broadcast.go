@@ -0,0 +1,195 @@
+package gox
+
+import "sync"
+
+// SlowSubscriberPolicy 控制订阅者通道写满时 Publish 的行为。
+type SlowSubscriberPolicy int
+
+const (
+	// SlowDrop 丢弃发给该订阅者的消息（默认），Publish 从不阻塞。
+	SlowDrop SlowSubscriberPolicy = iota
+	// SlowBlock 阻塞 Publish 直到订阅者接收或取消订阅。
+	SlowBlock
+	// SlowBuffer 将消息放入该订阅者的无界队列，Publish 从不阻塞也不丢消息，但内存可能无限增长。
+	SlowBuffer
+)
+
+// BroadcastConfig 包含 Broadcast 的配置。
+type BroadcastConfig struct {
+	// BufferSize 是每个订阅者通道的容量。默认值: 16
+	BufferSize int
+	// Policy 是慢订阅者策略。默认值: SlowDrop
+	Policy SlowSubscriberPolicy
+}
+
+// broadcastSub 是单个订阅者。
+type broadcastSub[T any] struct {
+	ch     chan T
+	done   chan struct{}
+	notify chan struct{} // 仅 SlowBuffer 使用
+	queue  []T           // 仅 SlowBuffer 使用
+	mu     sync.Mutex    // 保护 queue
+	stop   sync.Once     // 关闭 done
+}
+
+// end 标记订阅结束，可重复调用。
+func (s *broadcastSub[T]) end() {
+	s.stop.Do(func() { close(s.done) })
+}
+
+// Broadcast 将每条消息发送给所有订阅者，可直接为 ginm.SSE 提供数据：
+//
+//	ch, cancel := events.Subscribe()
+//	defer cancel()
+//	ginm.SSE(c, ch)
+type Broadcast[T any] struct {
+	subs   map[*broadcastSub[T]]struct{}
+	cfg    BroadcastConfig
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBroadcast 创建新的广播器。
+func NewBroadcast[T any](cfg BroadcastConfig) *Broadcast[T] {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 16
+	}
+	return &Broadcast[T]{cfg: cfg, subs: make(map[*broadcastSub[T]]struct{})}
+}
+
+// Subscribe 返回接收消息的通道和取消函数。取消或 Close 后通道被关闭。
+// 取消函数可重复调用。
+func (b *Broadcast[T]) Subscribe() (<-chan T, func()) {
+	s := &broadcastSub[T]{
+		ch:   make(chan T, b.cfg.BufferSize),
+		done: make(chan struct{}),
+	}
+	if b.cfg.Policy == SlowBuffer {
+		s.notify = make(chan struct{}, 1)
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(s.ch)
+		return s.ch, func() {}
+	}
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	if s.notify != nil {
+		go s.pump()
+	}
+
+	var once sync.Once
+	return s.ch, func() { once.Do(func() { b.remove(s) }) }
+}
+
+// Publish 将 v 发送给所有订阅者，慢订阅者按 Policy 处理。Close 之后调用无效果。
+func (b *Broadcast[T]) Publish(v T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		switch b.cfg.Policy {
+		case SlowBlock:
+			select {
+			case s.ch <- v:
+			case <-s.done:
+			}
+		case SlowBuffer:
+			s.mu.Lock()
+			s.queue = append(s.queue, v)
+			s.mu.Unlock()
+			select {
+			case s.notify <- struct{}{}:
+			default:
+			}
+		default:
+			select {
+			case s.ch <- v:
+			default:
+			}
+		}
+	}
+}
+
+// Len 返回当前订阅者数量。
+func (b *Broadcast[T]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// Close 关闭所有订阅者通道，之后的 Subscribe 返回已关闭的通道。
+func (b *Broadcast[T]) Close() {
+	// 先结束所有订阅，使阻塞中的 Publish 释放读锁
+	b.mu.RLock()
+	for s := range b.subs {
+		s.end()
+	}
+	b.mu.RUnlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for s := range b.subs {
+		b.closeSub(s)
+	}
+	clear(b.subs)
+}
+
+// remove 取消单个订阅。
+func (b *Broadcast[T]) remove(s *broadcastSub[T]) {
+	// 先通知订阅已结束，使阻塞中的 Publish 释放读锁
+	s.end()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		if s.notify == nil {
+			close(s.ch)
+		}
+	}
+}
+
+// closeSub 结束订阅，调用方须持有写锁。
+func (b *Broadcast[T]) closeSub(s *broadcastSub[T]) {
+	s.end()
+	if s.notify == nil {
+		close(s.ch)
+	}
+}
+
+// pump 将 SlowBuffer 队列中的消息转发到订阅者通道，订阅结束后关闭通道。
+func (s *broadcastSub[T]) pump() {
+	defer close(s.ch)
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.notify:
+		}
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			v := s.queue[0]
+			var zero T
+			s.queue[0] = zero
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+
+			select {
+			case s.ch <- v:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package gox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func drain[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestBroadcast_DeliversToAllSubscribers(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{})
+	a, _ := b.Subscribe()
+	c, _ := b.Subscribe()
+	assert.Equal(t, 2, b.Len())
+
+	b.Publish(1)
+	b.Publish(2)
+	b.Close()
+
+	assert.Equal(t, []int{1, 2}, drain(a))
+	assert.Equal(t, []int{1, 2}, drain(c))
+}
+
+func TestBroadcast_CancelClosesChannel(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{})
+	ch, cancel := b.Subscribe()
+	cancel()
+	cancel()
+
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Equal(t, 0, b.Len())
+	b.Publish(1)
+}
+
+func TestBroadcast_DropPolicyDropsForSlowSubscriber(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{BufferSize: 2})
+	ch, _ := b.Subscribe()
+	for i := range 5 {
+		b.Publish(i)
+	}
+	b.Close()
+	assert.Equal(t, []int{0, 1}, drain(ch))
+}
+
+func TestBroadcast_BlockPolicyWaitsForSubscriber(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{BufferSize: 1, Policy: SlowBlock})
+	ch, cancel := b.Subscribe()
+
+	published := make(chan struct{})
+	go func() {
+		for i := range 3 {
+			b.Publish(i)
+		}
+		close(published)
+	}()
+
+	assert.Equal(t, 0, <-ch)
+	assert.Equal(t, 1, <-ch)
+	assert.Equal(t, 2, <-ch)
+	<-published
+
+	// 取消订阅会释放阻塞中的 Publish
+	b.Publish(3)
+	done := make(chan struct{})
+	go func() { b.Publish(4); close(done) }()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish still blocked after cancel")
+	}
+}
+
+func TestBroadcast_BufferPolicyKeepsAllMessages(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{BufferSize: 1, Policy: SlowBuffer})
+	ch, cancel := b.Subscribe()
+	defer cancel()
+	for i := range 100 {
+		b.Publish(i)
+	}
+
+	for i := range 100 {
+		select {
+		case v := <-ch:
+			require.Equal(t, i, v)
+		case <-time.After(time.Second):
+			t.Fatalf("message %d not delivered", i)
+		}
+	}
+}
+
+func TestBroadcast_SubscribeAfterClose(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{Policy: SlowBuffer})
+	live, _ := b.Subscribe()
+	b.Close()
+	b.Close()
+
+	ch, cancel := b.Subscribe()
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Empty(t, drain(live))
+}
+
+func TestBroadcast_CloseReleasesBlockedPublish(t *testing.T) {
+	b := NewBroadcast[int](BroadcastConfig{BufferSize: 1, Policy: SlowBlock})
+	b.Subscribe()
+	b.Publish(0)
+
+	done := make(chan struct{})
+	go func() { b.Publish(1); close(done) }()
+	time.Sleep(10 * time.Millisecond)
+	b.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish still blocked after Close")
+	}
+}
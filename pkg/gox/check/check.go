@@ -0,0 +1,52 @@
+// Package check 提供不依赖测试框架的断言辅助函数。
+//
+// 与 testify 不同，这里的函数返回 error 而非操作 *testing.T，
+// 因此既可用于示例和内部测试，也可用于运行时的健康检查和不变量守卫：
+//
+//	if err := check.Equal(cfg.Version, 2); err != nil {
+//	    return fmt.Errorf("invalid config: %w", err)
+//	}
+package check
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrFailed 是所有断言失败错误的哨兵值，可用 errors.Is 判断。
+var ErrFailed = errors.New("check failed")
+
+// Equal 检查 got 与 want 是否深度相等（reflect.DeepEqual）。
+func Equal[T any](got, want T) error {
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("%w: got %#v, want %#v", ErrFailed, got, want)
+	}
+	return nil
+}
+
+// NoError 检查 err 是否为 nil。
+// 失败时返回的错误同时包装 ErrFailed 和原始错误。
+func NoError(err error) error {
+	if err != nil {
+		return fmt.Errorf("%w: unexpected error: %w", ErrFailed, err)
+	}
+	return nil
+}
+
+// PanicsWith 检查 fn 是否以 want 为值发生 panic。
+// fn 未 panic 或 panic 值与 want 不深度相等时返回错误。
+func PanicsWith(fn func(), want any) (err error) {
+	defer func() {
+		got := recover()
+		if got == nil {
+			err = fmt.Errorf("%w: expected panic with %#v, got none", ErrFailed, want)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			err = fmt.Errorf("%w: panic value %#v, want %#v", ErrFailed, got, want)
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,44 @@
+package check
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_ReturnsNilWhenEqual(t *testing.T) {
+	assert.NoError(t, Equal(42, 42))
+	assert.NoError(t, Equal([]string{"a", "b"}, []string{"a", "b"}))
+}
+
+func TestEqual_ReturnsErrorWhenDifferent(t *testing.T) {
+	err := Equal(1, 2)
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.Equal(t, "check failed: got 1, want 2", err.Error())
+}
+
+func TestNoError_ReturnsNilForNil(t *testing.T) {
+	assert.NoError(t, NoError(nil))
+}
+
+func TestNoError_WrapsOriginalError(t *testing.T) {
+	inner := errors.New("boom")
+	err := NoError(inner)
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.ErrorIs(t, err, inner)
+}
+
+func TestPanicsWith_ReturnsNilOnMatchingPanic(t *testing.T) {
+	assert.NoError(t, PanicsWith(func() { panic("boom") }, "boom"))
+}
+
+func TestPanicsWith_ReturnsErrorWhenNoPanic(t *testing.T) {
+	err := PanicsWith(func() {}, "boom")
+	assert.ErrorIs(t, err, ErrFailed)
+}
+
+func TestPanicsWith_ReturnsErrorOnDifferentValue(t *testing.T) {
+	err := PanicsWith(func() { panic("other") }, "boom")
+	assert.ErrorIs(t, err, ErrFailed)
+}
@@ -0,0 +1,53 @@
+package gox
+
+import "cmp"
+
+// Comparator 是三路比较函数：a < b 返回负数，a == b 返回 0，a > b 返回正数。
+// 可直接传给 slices.SortFunc、slices.BinarySearchFunc 等。
+//
+//	slices.SortFunc(users, gox.OrderBy(func(u User) int { return u.Age }).Desc().
+//	    Then(gox.OrderBy(func(u User) string { return u.Name })))
+type Comparator[T any] func(a, b T) int
+
+// OrderBy 返回按 key 升序比较的 Comparator。
+func OrderBy[T any, K Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Desc 返回顺序相反的 Comparator。作用于整个比较链，只反转单个键时应在 Then 之前调用。
+func (c Comparator[T]) Desc() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Then 返回先按 c 比较、相等时再按 next 比较的 Comparator。
+func (c Comparator[T]) Then(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// ThenBy 返回先按 c 比较、相等时依次按 next 中各 Comparator 比较的 Comparator。
+// 方法不能声明自己的类型参数，次级键由 OrderBy 构造：
+//
+//	gox.OrderBy(func(u User) int { return u.Age }).Desc().
+//	    ThenBy(gox.OrderBy(func(u User) string { return u.Name }), gox.OrderBy(func(u User) int64 { return u.ID }))
+func (c Comparator[T]) ThenBy(next ...Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		for _, n := range next {
+			if r := n(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
@@ -0,0 +1,50 @@
+package gox
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortUser struct {
+	Name string
+	Age  int
+}
+
+func TestOrderBy_Ascending(t *testing.T) {
+	users := []sortUser{{"b", 30}, {"a", 20}, {"c", 25}}
+	slices.SortFunc(users, OrderBy(func(u sortUser) int { return u.Age }))
+	assert.Equal(t, []string{"a", "c", "b"}, Map(users, func(u sortUser) string { return u.Name }))
+}
+
+func TestComparator_DescThenAscending(t *testing.T) {
+	users := []sortUser{{"b", 20}, {"c", 30}, {"a", 20}, {"d", 30}}
+	byAgeDesc := OrderBy(func(u sortUser) int { return u.Age }).Desc()
+	slices.SortFunc(users, byAgeDesc.Then(OrderBy(func(u sortUser) string { return u.Name })))
+	assert.Equal(t, []string{"c", "d", "a", "b"}, Map(users, func(u sortUser) string { return u.Name }))
+}
+
+func TestComparator_ThenBy_BreaksTies(t *testing.T) {
+	users := []sortUser{{"b", 20}, {"a", 20}, {"c", 10}}
+	c := OrderBy(func(u sortUser) int { return u.Age }).ThenBy(OrderBy(func(u sortUser) string { return u.Name }))
+	slices.SortFunc(users, c)
+	assert.Equal(t, []string{"c", "a", "b"}, Map(users, func(u sortUser) string { return u.Name }))
+}
+
+func TestComparator_ThenBy_TriesEachInOrder(t *testing.T) {
+	type row struct{ a, b, c int }
+	cmpRow := OrderBy(func(r row) int { return r.a }).ThenBy(
+		OrderBy(func(r row) int { return r.b }),
+		OrderBy(func(r row) int { return r.c }).Desc(),
+	)
+	assert.Negative(t, cmpRow(row{1, 1, 2}, row{1, 1, 1}))
+	assert.Negative(t, cmpRow(row{1, 0, 0}, row{1, 1, 9}))
+	assert.Equal(t, 0, cmpRow(row{1, 1, 1}, row{1, 1, 1}))
+}
+
+func TestComparator_DescAppliesToWholeChain(t *testing.T) {
+	c := OrderBy(func(u sortUser) int { return u.Age }).ThenBy(OrderBy(func(u sortUser) string { return u.Name })).Desc()
+	assert.Greater(t, c(sortUser{"a", 20}, sortUser{"b", 20}), 0)
+	assert.Equal(t, 0, c(sortUser{"a", 20}, sortUser{"a", 20}))
+}
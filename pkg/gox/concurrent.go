@@ -0,0 +1,131 @@
+package gox
+
+import (
+	"context"
+	"sync"
+)
+
+// --- 并发工具 ---
+
+// GroupConfig 包含 Group 的配置。
+type GroupConfig struct {
+	// Limit 限制同时运行的任务数，达到上限时 Go 阻塞。默认值: 0（不限制）
+	Limit int
+	// FailFast 为 true 时第一个错误会取消 Context，尚未开始的任务被跳过并记为 context 错误，Wait 只返回第一个错误。
+	// 为 false 时运行所有任务，Wait 返回包含全部错误的 *MultiError。
+	FailFast bool
+}
+
+// Group 是保留返回值的 errgroup，结果按 Go 的调用顺序返回。
+//
+//	g := gox.NewGroup[*User](ctx, gox.GroupConfig{Limit: 4, FailFast: true})
+//	for _, id := range ids {
+//	    g.Go(func() (*User, error) { return repo.Get(g.Context(), id) })
+//	}
+//	users, err := g.Wait()
+type Group[T any] struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	sem      chan struct{}
+	results  []T
+	errs     []error
+	firstErr error
+	cfg      GroupConfig
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+}
+
+// NewGroup 创建新的任务组。
+func NewGroup[T any](ctx context.Context, cfg GroupConfig) *Group[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group[T]{ctx: ctx, cancel: cancel, cfg: cfg}
+	if cfg.Limit > 0 {
+		g.sem = make(chan struct{}, cfg.Limit)
+	}
+	return g
+}
+
+// Context 返回任务组的 context，FailFast 模式下第一个错误发生后被取消，Wait 返回后也会被取消。
+func (g *Group[T]) Context() context.Context {
+	return g.ctx
+}
+
+// Go 在新的 goroutine 中运行 fn。
+func (g *Group[T]) Go(fn func() (T, error)) {
+	g.mu.Lock()
+	i := len(g.results)
+	var zero T
+	g.results = append(g.results, zero)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			var zero T
+			g.set(i, zero, g.ctx.Err())
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		if g.cfg.FailFast && g.ctx.Err() != nil {
+			var zero T
+			g.set(i, zero, g.ctx.Err())
+			return
+		}
+		v, err := fn()
+		g.set(i, v, err)
+	}()
+}
+
+// set 记录第 i 个任务的结果，FailFast 模式下第一个错误会取消 context。
+func (g *Group[T]) set(i int, v T, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.results[i], g.errs[i] = v, err
+	if err != nil && g.firstErr == nil {
+		g.firstErr = err
+		if g.cfg.FailFast {
+			g.cancel()
+		}
+	}
+}
+
+// Wait 等待所有任务完成，按 Go 的调用顺序返回结果，失败或被跳过的任务对应零值。
+func (g *Group[T]) Wait() ([]T, error) {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cfg.FailFast {
+		return g.results, g.firstErr
+	}
+	multi := NewMultiError()
+	multi.AddAll(g.errs...)
+	return g.results, multi.ErrorOrNil()
+}
+
+// ForEachConcurrent 对每个元素调用 fn，最多同时运行 limit 个（limit <= 0 表示不限制）。
+// 所有元素都会被处理，返回按元素顺序汇总错误的 *MultiError，全部成功时返回 nil。
+// ctx 取消后尚未开始的元素不再执行，并记为 ctx.Err()。
+func ForEachConcurrent[T any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) error) error {
+	g := NewGroup[struct{}](ctx, GroupConfig{Limit: limit})
+	for _, item := range items {
+		g.Go(func() (struct{}, error) {
+			if err := g.Context().Err(); err != nil {
+				return struct{}{}, err
+			}
+			return struct{}{}, fn(g.Context(), item)
+		})
+	}
+	_, err := g.Wait()
+	return err
+}
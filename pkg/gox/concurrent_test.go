@@ -0,0 +1,120 @@
+package gox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_ReturnsResultsInOrder(t *testing.T) {
+	g := NewGroup[int](context.Background(), GroupConfig{})
+	for i := range 5 {
+		g.Go(func() (int, error) {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i * 10, nil
+		})
+	}
+
+	results, err := g.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 10, 20, 30, 40}, results)
+}
+
+func TestGroup_CollectAllReturnsMultiError(t *testing.T) {
+	g := NewGroup[string](context.Background(), GroupConfig{})
+	g.Go(func() (string, error) { return "a", nil })
+	g.Go(func() (string, error) { return "", errors.New("b failed") })
+	g.Go(func() (string, error) { return "", errors.New("c failed") })
+
+	results, err := g.Wait()
+	assert.Equal(t, []string{"a", "", ""}, results)
+	var multi *MultiError
+	require.ErrorAs(t, err, &multi)
+	assert.Equal(t, 2, multi.Len())
+}
+
+func TestGroup_FailFastCancelsContext(t *testing.T) {
+	errBoom := errors.New("boom")
+	g := NewGroup[int](context.Background(), GroupConfig{FailFast: true})
+	g.Go(func() (int, error) { return 0, errBoom })
+	g.Go(func() (int, error) {
+		<-g.Context().Done()
+		return 0, g.Context().Err()
+	})
+
+	_, err := g.Wait()
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestGroup_LimitBoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	g := NewGroup[struct{}](context.Background(), GroupConfig{Limit: 2})
+	for range 10 {
+		g.Go(func() (struct{}, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return struct{}{}, nil
+		})
+	}
+
+	_, err := g.Wait()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestGroup_ParentCancelRecordsSkippedTasks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := NewGroup[int](ctx, GroupConfig{Limit: 1, FailFast: true})
+	g.Go(func() (int, error) { return 1, nil })
+
+	_, err := g.Wait()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestForEachConcurrent_CollectsErrorsInOrder(t *testing.T) {
+	var processed atomic.Int32
+	err := ForEachConcurrent(context.Background(), []int{1, 2, 3, 4}, 2, func(ctx context.Context, n int) error {
+		processed.Add(1)
+		if n%2 == 0 {
+			return fmt.Errorf("item %d failed", n)
+		}
+		return nil
+	})
+
+	assert.Equal(t, int32(4), processed.Load())
+	assert.EqualError(t, err, "2 errors: item 2 failed; item 4 failed")
+}
+
+func TestForEachConcurrent_ReturnsNilOnSuccess(t *testing.T) {
+	err := ForEachConcurrent(context.Background(), []string{"a", "b"}, 0, func(ctx context.Context, s string) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestForEachConcurrent_StopsStartingAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var processed atomic.Int32
+	err := ForEachConcurrent(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, n int) error {
+		processed.Add(1)
+		return nil
+	})
+
+	assert.Equal(t, int32(0), processed.Load())
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,6 +1,7 @@
 package gox
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 )
@@ -142,6 +143,53 @@ func BoolToString(v bool) string {
 
 // --- 安全类型转换 ---
 
+// ErrNumericOverflow 表示数值转换发生溢出或精度丢失。
+var ErrNumericOverflow = errors.New("numeric conversion overflow")
+
+// ConvertNumeric 在数值类型之间进行检查转换。
+// 当目标类型无法精确表示源值（溢出、符号变化、小数截断或 NaN）时返回 Err。
+//
+//	gox.ConvertNumeric[int32](int64(42))       // Ok(42)
+//	gox.ConvertNumeric[int32](int64(1 << 40))  // Err
+//	gox.ConvertNumeric[int](3.5)               // Err
+func ConvertNumeric[To, From Numeric](v From) Result[To] {
+	to := To(v)
+	if From(to) != v || (v < 0) != (to < 0) {
+		return RErr[To](fmt.Errorf("%w: %v (%T) to %T", ErrNumericOverflow, v, v, to))
+	}
+	return ROk(to)
+}
+
+// ConvertSlice 将数值切片逐元素转换为另一种数值类型。
+// 使用 Go 的类型转换语义，不检查溢出；需要检查时使用 ConvertSliceChecked。
+func ConvertSlice[T, R Numeric](items []T) []R {
+	if items == nil {
+		return nil
+	}
+	result := make([]R, len(items))
+	for i, item := range items {
+		result[i] = R(item)
+	}
+	return result
+}
+
+// ConvertSliceChecked 将数值切片逐元素转换为另一种数值类型。
+// 任一元素无法精确表示时返回 Err，错误中包含元素索引。
+func ConvertSliceChecked[T, R Numeric](items []T) Result[[]R] {
+	if items == nil {
+		return ROk[[]R](nil)
+	}
+	result := make([]R, len(items))
+	for i, item := range items {
+		v, err := ConvertNumeric[R](item).GetWithError()
+		if err != nil {
+			return RErr[[]R](fmt.Errorf("index %d: %w", i, err))
+		}
+		result[i] = v
+	}
+	return ROk(result)
+}
+
 // IntToInt64 安全地将任意整数类型转换为 int64。
 func IntToInt64[T Integer](v T) int64 {
 	return int64(v)
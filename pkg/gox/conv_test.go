@@ -0,0 +1,56 @@
+package gox
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertNumeric_WidensWithoutLoss(t *testing.T) {
+	assert.Equal(t, int64(42), ConvertNumeric[int64](int32(42)).Unwrap())
+	assert.InDelta(t, 1.5, ConvertNumeric[float64](float32(1.5)).Unwrap(), 0)
+}
+
+func TestConvertNumeric_NarrowsInRange(t *testing.T) {
+	assert.Equal(t, int32(-7), ConvertNumeric[int32](int64(-7)).Unwrap())
+	assert.Equal(t, uint8(255), ConvertNumeric[uint8](255).Unwrap())
+	assert.Equal(t, 3, ConvertNumeric[int](3.0).Unwrap())
+}
+
+func TestConvertNumeric_FailsOnOverflow(t *testing.T) {
+	r := ConvertNumeric[int32](int64(math.MaxInt32) + 1)
+	assert.ErrorIs(t, r.Error(), ErrNumericOverflow)
+	assert.True(t, ConvertNumeric[uint8](256).IsErr())
+}
+
+func TestConvertNumeric_FailsOnSignChange(t *testing.T) {
+	assert.True(t, ConvertNumeric[uint64](-1).IsErr())
+	assert.True(t, ConvertNumeric[int64](uint64(math.MaxUint64)).IsErr())
+}
+
+func TestConvertNumeric_FailsOnTruncation(t *testing.T) {
+	assert.True(t, ConvertNumeric[int](3.5).IsErr())
+	assert.True(t, ConvertNumeric[int64](math.NaN()).IsErr())
+	assert.True(t, ConvertNumeric[float64](int64(1<<53+1)).IsErr())
+}
+
+func TestConvertSlice_ConvertsElements(t *testing.T) {
+	assert.Equal(t, []int64{1, 2, 3}, ConvertSlice[int, int64]([]int{1, 2, 3}))
+	assert.Equal(t, []float64{1, 2}, ConvertSlice[int32, float64]([]int32{1, 2}))
+}
+
+func TestConvertSlice_PreservesNil(t *testing.T) {
+	assert.Nil(t, ConvertSlice[int, int64](nil))
+}
+
+func TestConvertSliceChecked_ReturnsOk(t *testing.T) {
+	r := ConvertSliceChecked[int64, int32]([]int64{1, -2, 3})
+	assert.Equal(t, []int32{1, -2, 3}, r.Unwrap())
+}
+
+func TestConvertSliceChecked_FailsWithIndex(t *testing.T) {
+	r := ConvertSliceChecked[int, uint8]([]int{1, 2, 300})
+	assert.ErrorIs(t, r.Error(), ErrNumericOverflow)
+	assert.Contains(t, r.Error().Error(), "index 2")
+}
@@ -0,0 +1,137 @@
+package gox
+
+import "slices"
+
+// Counter 是元素计数的多重集合，零值可用，非并发安全。
+// 计数归零或为负的元素会被移除。
+//
+//	c := gox.NewCounter(strings.Fields(text)...)
+//	top := c.MostCommon(10)
+type Counter[T comparable] struct {
+	counts map[T]int
+	order  []T // 元素首次出现的顺序，用于 MostCommon 中计数相同时的排序
+}
+
+// NewCounter 创建计数器并加入 items。
+func NewCounter[T comparable](items ...T) *Counter[T] {
+	c := &Counter[T]{}
+	c.Add(items...)
+	return c
+}
+
+// Add 将每个元素的计数加 1。
+func (c *Counter[T]) Add(items ...T) {
+	for _, item := range items {
+		c.AddN(item, 1)
+	}
+}
+
+// AddN 将元素的计数加 n，n 可为负数。
+func (c *Counter[T]) AddN(item T, n int) {
+	if c.counts == nil {
+		c.counts = make(map[T]int)
+	}
+	old, exists := c.counts[item]
+	total := old + n
+	switch {
+	case total > 0 && !exists:
+		c.counts[item] = total
+		c.order = append(c.order, item)
+	case total > 0:
+		c.counts[item] = total
+	case exists:
+		delete(c.counts, item)
+		c.order = slices.DeleteFunc(c.order, func(v T) bool { return v == item })
+	}
+}
+
+// Count 返回元素的计数，不存在时返回 0。
+func (c *Counter[T]) Count(item T) int {
+	return c.counts[item]
+}
+
+// Len 返回不同元素的数量。
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// Total 返回所有计数之和。
+func (c *Counter[T]) Total() int {
+	total := 0
+	for _, n := range c.counts {
+		total += n
+	}
+	return total
+}
+
+// Merge 将 o 的计数加到 c 上。
+func (c *Counter[T]) Merge(o *Counter[T]) {
+	for _, item := range o.order {
+		c.AddN(item, o.counts[item])
+	}
+}
+
+// Subtract 从 c 中减去 o 的计数，结果不大于 0 的元素被移除。o 可以是 c 本身。
+func (c *Counter[T]) Subtract(o *Counter[T]) {
+	// 先取快照：o 为 c 时逐个删除会改变正在遍历的 order 和 counts
+	items := slices.Clone(o.order)
+	counts := make([]int, len(items))
+	for i, item := range items {
+		counts[i] = o.counts[item]
+	}
+
+	removed := false
+	for i, item := range items {
+		old, exists := c.counts[item]
+		if !exists {
+			continue
+		}
+		if total := old - counts[i]; total > 0 {
+			c.counts[item] = total
+		} else {
+			delete(c.counts, item)
+			removed = true
+		}
+	}
+	if removed {
+		// 一次性压缩 order，避免逐个删除的 O(n²)
+		c.order = slices.DeleteFunc(c.order, func(v T) bool {
+			_, ok := c.counts[v]
+			return !ok
+		})
+	}
+}
+
+// MostCommon 返回计数最高的 n 个元素（n <= 0 表示全部），按计数降序，计数相同时按首次出现顺序。
+func (c *Counter[T]) MostCommon(n int) []struct {
+	Key   T
+	Value int
+} {
+	result := make([]struct {
+		Key   T
+		Value int
+	}, len(c.order))
+	for i, item := range c.order {
+		result[i].Key = item
+		result[i].Value = c.counts[item]
+	}
+	slices.SortStableFunc(result, func(a, b struct {
+		Key   T
+		Value int
+	}) int {
+		return b.Value - a.Value
+	})
+	if n > 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// Map 返回计数的副本。
+func (c *Counter[T]) Map() map[T]int {
+	result := make(map[T]int, len(c.counts))
+	for k, v := range c.counts {
+		result[k] = v
+	}
+	return result
+}
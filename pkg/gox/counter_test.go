@@ -0,0 +1,67 @@
+package gox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter_ZeroValueUsable(t *testing.T) {
+	var c Counter[string]
+	assert.Equal(t, 0, c.Count("a"))
+	c.Add("a", "a")
+	assert.Equal(t, 2, c.Count("a"))
+}
+
+func TestCounter_AddAndTotal(t *testing.T) {
+	c := NewCounter("a", "b", "a", "c", "a")
+	assert.Equal(t, 3, c.Count("a"))
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, 5, c.Total())
+}
+
+func TestCounter_AddNRemovesNonPositive(t *testing.T) {
+	c := NewCounter("a", "b")
+	c.AddN("a", -5)
+	assert.Equal(t, 0, c.Count("a"))
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, map[string]int{"b": 1}, c.Map())
+}
+
+func TestCounter_MostCommon(t *testing.T) {
+	c := NewCounter("x", "b", "a", "b", "a", "c")
+	top := c.MostCommon(2)
+	assert.Len(t, top, 2)
+	assert.Equal(t, "b", top[0].Key)
+	assert.Equal(t, 2, top[0].Value)
+	assert.Equal(t, "a", top[1].Key)
+
+	assert.Len(t, c.MostCommon(0), 4)
+	assert.Equal(t, "x", c.MostCommon(0)[2].Key, "ties keep first-seen order")
+}
+
+func TestCounter_MergeAndSubtract(t *testing.T) {
+	c := NewCounter("a", "b")
+	c.Merge(NewCounter("a", "c"))
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 1}, c.Map())
+
+	c.Subtract(NewCounter("a", "b", "b"))
+	assert.Equal(t, map[string]int{"a": 1, "c": 1}, c.Map())
+}
+
+func TestCounter_SubtractSelf(t *testing.T) {
+	c := NewCounter("a", "b", "b", "c", "d", "d", "d")
+	c.Subtract(c)
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, c.MostCommon(0))
+
+	c.Add("e")
+	assert.Equal(t, "e", c.MostCommon(1)[0].Key)
+}
+
+func TestCounter_SubtractKeepsOrderOfRemaining(t *testing.T) {
+	c := NewCounter("a", "b", "c", "d", "e")
+	c.Subtract(NewCounter("d", "b"))
+	top := c.MostCommon(0)
+	assert.Equal(t, []string{"a", "c", "e"}, []string{top[0].Key, top[1].Key, top[2].Key})
+}
@@ -0,0 +1,193 @@
+package gox
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrEmitterClosed 表示向已关闭的 Emitter 发送事件。
+var ErrEmitterClosed = errors.New("emitter closed")
+
+// ErrEmitterFull 表示异步模式下 TryEmit 时队列已满。
+var ErrEmitterFull = errors.New("emitter queue full")
+
+// Subscription 标识一个订阅，用于 Unsubscribe。
+type Subscription uint64
+
+// EmitterConfig 包含 Emitter 的配置。
+type EmitterConfig struct {
+	// OnPanic 在订阅者 panic 时调用。panic 不会影响其他订阅者。默认值: 忽略
+	OnPanic func(err *PanicError)
+	// BufferSize 大于 0 时启用异步模式：Emit 将事件放入容量为 BufferSize 的缓冲队列，
+	// 由后台 goroutine 按顺序分发；队列已满时 Emit 阻塞，直到有空位或 Emitter 关闭。
+	// 为 0 时 Emit 同步调用订阅者。
+	BufferSize int
+}
+
+type subscriber[E any] struct {
+	fn func(E)
+	id Subscription
+}
+
+// Emitter 是类型化的进程内事件发布/订阅器。
+//
+//	orders := gox.NewEmitter[OrderPlaced](gox.EmitterConfig{BufferSize: 64})
+//	defer orders.Close()
+//	orders.Subscribe(func(e OrderPlaced) { sendReceipt(e) })
+//	_ = orders.Emit(OrderPlaced{ID: 42})
+type Emitter[E any] struct {
+	queue chan E
+	// stop 在 Close 时关闭，唤醒阻塞在满队列上的 Emit
+	stop   chan struct{}
+	done   chan struct{}
+	cfg    EmitterConfig
+	subs   []subscriber[E]
+	nextID Subscription
+	mu     sync.RWMutex // 保护 subs 和 nextID
+	// closeMu 保护 closed，sending 记录已通过检查、尚未完成发送的 Emit，
+	// Close 等待它们结束后才关闭 queue；发送期间不持锁，订阅者内部调用 Emit 不会阻塞 Close
+	closeMu sync.RWMutex
+	closed  bool
+	sending sync.WaitGroup
+}
+
+// NewEmitter 创建新的事件发布器。异步模式下须调用 Close 停止后台 goroutine。
+func NewEmitter[E any](cfg EmitterConfig) *Emitter[E] {
+	e := &Emitter[E]{cfg: cfg}
+	if cfg.BufferSize > 0 {
+		e.queue = make(chan E, cfg.BufferSize)
+		e.stop = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.run()
+	}
+	return e
+}
+
+// Subscribe 注册订阅者，返回用于取消订阅的标识。
+func (e *Emitter[E]) Subscribe(fn func(E)) Subscription {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	subs := make([]subscriber[E], len(e.subs), len(e.subs)+1)
+	copy(subs, e.subs)
+	e.subs = append(subs, subscriber[E]{id: e.nextID, fn: fn})
+	return e.nextID
+}
+
+// Unsubscribe 取消订阅，返回订阅是否存在。
+func (e *Emitter[E]) Unsubscribe(id Subscription) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, s := range e.subs {
+		if s.id == id {
+			subs := make([]subscriber[E], 0, len(e.subs)-1)
+			subs = append(subs, e.subs[:i]...)
+			e.subs = append(subs, e.subs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Len 返回当前订阅者数量。
+func (e *Emitter[E]) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.subs)
+}
+
+// Emit 发布事件。同步模式下按订阅顺序依次调用订阅者后返回；
+// 异步模式下将事件放入队列后返回，队列已满时阻塞。Emitter 已关闭时返回 ErrEmitterClosed。
+// 异步模式下订阅者内部应使用 TryEmit：分发循环阻塞在满队列上时只有 Close 能将其唤醒。
+func (e *Emitter[E]) Emit(event E) error {
+	return e.emit(event, true)
+}
+
+// TryEmit 与 Emit 相同，但异步模式下队列已满时不阻塞，返回 ErrEmitterFull。
+func (e *Emitter[E]) TryEmit(event E) error {
+	return e.emit(event, false)
+}
+
+func (e *Emitter[E]) emit(event E, block bool) error {
+	e.closeMu.RLock()
+	if e.closed {
+		e.closeMu.RUnlock()
+		return ErrEmitterClosed
+	}
+	if e.queue == nil {
+		e.closeMu.RUnlock()
+		// 同步分发不持锁，订阅者内部可以调用 Subscribe、Emit 或 Close
+		e.dispatch(e.snapshot(), event)
+		return nil
+	}
+	e.sending.Add(1)
+	e.closeMu.RUnlock()
+	defer e.sending.Done()
+
+	if !block {
+		select {
+		case e.queue <- event:
+			return nil
+		default:
+			return ErrEmitterFull
+		}
+	}
+	select {
+	case e.queue <- event:
+		return nil
+	case <-e.stop:
+		return ErrEmitterClosed
+	}
+}
+
+// Close 关闭 Emitter。异步模式下会等待队列中已有的事件分发完毕，
+// 阻塞在满队列上的 Emit 返回 ErrEmitterClosed。重复调用是安全的。
+func (e *Emitter[E]) Close() {
+	e.closeMu.Lock()
+	if e.closed {
+		e.closeMu.Unlock()
+		return
+	}
+	e.closed = true
+	e.closeMu.Unlock()
+
+	if e.queue != nil {
+		close(e.stop)
+		// 此后不会有新的发送者，等待进行中的发送结束后关闭队列
+		e.sending.Wait()
+		close(e.queue)
+		<-e.done
+	}
+}
+
+// run 是异步模式的分发循环。
+func (e *Emitter[E]) run() {
+	defer close(e.done)
+	for event := range e.queue {
+		e.dispatch(e.snapshot(), event)
+	}
+}
+
+// snapshot 返回当前订阅者列表。列表写时复制，返回后可在不持锁的情况下遍历。
+func (e *Emitter[E]) snapshot() []subscriber[E] {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.subs
+}
+
+func (e *Emitter[E]) dispatch(subs []subscriber[E], event E) {
+	for _, s := range subs {
+		e.call(s.fn, event)
+	}
+}
+
+// call 调用单个订阅者并隔离其 panic。
+func (e *Emitter[E]) call(fn func(E), event E) {
+	defer func() {
+		if v := recover(); v != nil && e.cfg.OnPanic != nil {
+			e.cfg.OnPanic(&PanicError{Value: v, Stack: debug.Stack()})
+		}
+	}()
+	fn(event)
+}
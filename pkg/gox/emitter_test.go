@@ -0,0 +1,112 @@
+package gox
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitter_SyncDeliversInOrder(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{})
+	var got []string
+	e.Subscribe(func(n int) { got = append(got, "a") })
+	e.Subscribe(func(n int) { got = append(got, "b") })
+
+	require.NoError(t, e.Emit(1))
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestEmitter_Unsubscribe(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{})
+	calls := 0
+	id := e.Subscribe(func(int) { calls++ })
+
+	assert.True(t, e.Unsubscribe(id))
+	assert.False(t, e.Unsubscribe(id))
+	assert.Equal(t, 0, e.Len())
+	require.NoError(t, e.Emit(1))
+	assert.Equal(t, 0, calls)
+}
+
+func TestEmitter_IsolatesSubscriberPanics(t *testing.T) {
+	var panics []*PanicError
+	e := NewEmitter[string](EmitterConfig{OnPanic: func(err *PanicError) { panics = append(panics, err) }})
+	delivered := false
+	e.Subscribe(func(string) { panic("boom") })
+	e.Subscribe(func(string) { delivered = true })
+
+	require.NoError(t, e.Emit("event"))
+	assert.True(t, delivered)
+	require.Len(t, panics, 1)
+	assert.Equal(t, "boom", panics[0].Value)
+}
+
+func TestEmitter_SubscriberMayUnsubscribeDuringEmit(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{})
+	var id Subscription
+	calls := 0
+	id = e.Subscribe(func(int) {
+		calls++
+		e.Unsubscribe(id)
+	})
+
+	require.NoError(t, e.Emit(1))
+	require.NoError(t, e.Emit(2))
+	assert.Equal(t, 1, calls)
+}
+
+func TestEmitter_AsyncDrainsOnClose(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{BufferSize: 4})
+	var sum atomic.Int64
+	e.Subscribe(func(n int) { sum.Add(int64(n)) })
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 100; i++ {
+		wg.Go(func() { _ = e.Emit(i) })
+	}
+	wg.Wait()
+	e.Close()
+
+	assert.Equal(t, int64(5050), sum.Load())
+	assert.ErrorIs(t, e.Emit(1), ErrEmitterClosed)
+	e.Close()
+}
+
+func TestEmitter_SyncEmitAfterClose(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{})
+	e.Close()
+	assert.ErrorIs(t, e.Emit(1), ErrEmitterClosed)
+}
+
+func TestEmitter_AsyncReentrantEmitDoesNotBlockClose(t *testing.T) {
+	e := NewEmitter[int](EmitterConfig{BufferSize: 1})
+	release := make(chan struct{})
+	var errs []error
+	e.Subscribe(func(n int) {
+		if n == 1 {
+			<-release
+			// 队列已满，分发循环内的 Emit 阻塞到 Close
+			errs = append(errs, e.Emit(3))
+		}
+	})
+	require.NoError(t, e.Emit(1))
+	require.Eventually(t, func() bool { return e.TryEmit(2) == nil }, time.Second, time.Millisecond)
+	assert.ErrorIs(t, e.TryEmit(4), ErrEmitterFull)
+	close(release)
+
+	closed := make(chan struct{})
+	go func() {
+		e.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked by reentrant Emit")
+	}
+	assert.Equal(t, []error{ErrEmitterClosed}, errs)
+}
@@ -1,14 +1,20 @@
 package gox
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // MultiError 聚合多个错误为一个。
 // 适用于批量操作、并行处理或收集所有验证失败。
 type MultiError struct {
 	errors []error
+	// labels 与 errors 一一对应，未标记的错误为空字符串；可能短于 errors。
+	labels []string
 }
 
 // NewMultiError 创建一个新的空 MultiError。
@@ -23,6 +29,20 @@ func (m *MultiError) Add(err error) {
 	}
 }
 
+// AddLabeled 添加一个带标签（通常为字段名）的错误。nil 错误会被忽略。
+//
+//	errs.AddLabeled("email", errors.New("is required"))
+func (m *MultiError) AddLabeled(label string, err error) {
+	if err == nil {
+		return
+	}
+	for len(m.labels) < len(m.errors) {
+		m.labels = append(m.labels, "")
+	}
+	m.errors = append(m.errors, err)
+	m.labels = append(m.labels, label)
+}
+
 // AddAll 添加多个错误。nil 错误会被忽略。
 func (m *MultiError) AddAll(errs ...error) {
 	for _, err := range errs {
@@ -89,3 +109,187 @@ func (m *MultiError) First() error {
 func (m *MultiError) Unwrap() []error {
 	return m.errors
 }
+
+// Label 返回第 i 个错误的标签，未标记时返回空字符串。
+func (m *MultiError) Label(i int) string {
+	if i < len(m.labels) {
+		return m.labels[i]
+	}
+	return ""
+}
+
+// HasLabels 返回是否有任何带标签的错误。
+func (m *MultiError) HasLabels() bool {
+	return slices.ContainsFunc(m.labels, func(l string) bool { return l != "" })
+}
+
+// Map 按标签分组返回错误，未标记的错误归入空字符串键。
+func (m *MultiError) Map() map[string][]error {
+	result := make(map[string][]error)
+	for i, err := range m.errors {
+		label := m.Label(i)
+		result[label] = append(result[label], err)
+	}
+	return result
+}
+
+// MarshalJSON 按标签输出错误消息，例如 {"email":["is required"]}。
+// 未标记的错误归入空字符串键。
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	result := make(map[string][]string)
+	for i, err := range m.errors {
+		label := m.Label(i)
+		result[label] = append(result[label], err.Error())
+	}
+	return json.Marshal(result)
+}
+
+// SyncMultiError 是并发安全的 MultiError，适用于从多个 goroutine 收集错误。
+// 零值可直接使用。
+//
+//	var errs gox.SyncMultiError
+//	var wg sync.WaitGroup
+//	for _, item := range items {
+//	    wg.Go(func() { errs.Add(process(item)) })
+//	}
+//	wg.Wait()
+//	return errs.ErrorOrNil()
+type SyncMultiError struct {
+	errors []error
+	mu     sync.Mutex
+}
+
+// NewSyncMultiError 创建一个新的空 SyncMultiError。
+func NewSyncMultiError() *SyncMultiError {
+	return &SyncMultiError{}
+}
+
+// Add 添加一个错误到集合。nil 错误会被忽略。
+func (m *SyncMultiError) Add(err error) {
+	if err == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors = append(m.errors, err)
+}
+
+// AddAll 添加多个错误。nil 错误会被忽略。
+func (m *SyncMultiError) AddAll(errs ...error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, err := range errs {
+		if err != nil {
+			m.errors = append(m.errors, err)
+		}
+	}
+}
+
+// Errors 返回所有收集的错误的副本。
+func (m *SyncMultiError) Errors() []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.errors)
+}
+
+// HasErrors 返回是否有任何错误。
+func (m *SyncMultiError) HasErrors() bool {
+	return m.Len() > 0
+}
+
+// Len 返回错误数量。
+func (m *SyncMultiError) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.errors)
+}
+
+// Error 实现 error 接口，格式与 MultiError 相同。
+func (m *SyncMultiError) Error() string {
+	return m.Snapshot().Error()
+}
+
+// ErrorOrNil 如果没有错误返回 nil，否则返回当前错误的 *MultiError 快照。
+// 返回快照而非自身，调用方读取时不会与后续的 Add 竞争。
+func (m *SyncMultiError) ErrorOrNil() error {
+	return m.Snapshot().ErrorOrNil()
+}
+
+// First 返回第一个错误或 nil。
+func (m *SyncMultiError) First() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.errors) == 0 {
+		return nil
+	}
+	return m.errors[0]
+}
+
+// Unwrap 返回错误列表的副本，供 errors.Is/As 使用。
+func (m *SyncMultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Snapshot 返回当前错误的 MultiError 副本。
+func (m *SyncMultiError) Snapshot() *MultiError {
+	return &MultiError{errors: m.Errors()}
+}
+
+// --- 带错误码的错误 ---
+
+// ErrorCode 是 CodedError 支持的错误码类型。
+type ErrorCode interface {
+	~string | ~int
+}
+
+// CodedError 是带机器可读错误码的错误，适用于与 HTTP 框架无关的服务层。
+// 对应 ginm.APIError，但不包含 HTTP 状态码。
+//
+//	const ErrCodeNotFound = "user_not_found"
+//	return gox.NewCodedError(ErrCodeNotFound, "user not found")
+type CodedError[C ErrorCode] struct {
+	Err     error
+	Message string
+	Code    C
+}
+
+// NewCodedError 创建带错误码的错误。
+func NewCodedError[C ErrorCode](code C, message string) *CodedError[C] {
+	return &CodedError[C]{Code: code, Message: message}
+}
+
+// WrapCoded 创建包装底层错误的带错误码错误。
+func WrapCoded[C ErrorCode](code C, message string, err error) *CodedError[C] {
+	return &CodedError[C]{Code: code, Message: message, Err: err}
+}
+
+// Error 实现 error 接口。
+func (e *CodedError[C]) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%v: %s", e.Code, e.Message)
+}
+
+// Unwrap 返回被包装的错误。
+func (e *CodedError[C]) Unwrap() error {
+	return e.Err
+}
+
+// Is 在错误码相同时返回 true，使 errors.Is 可与预定义的 CodedError 比较。
+func (e *CodedError[C]) Is(target error) bool {
+	t, ok := target.(*CodedError[C])
+	return ok && t.Code == e.Code
+}
+
+// CodeOf 通过 errors.As 提取错误链中第一个 CodedError[C] 的错误码。
+//
+//	if code, ok := gox.CodeOf[string](err); ok { ... }
+func CodeOf[C ErrorCode](err error) (C, bool) {
+	var coded *CodedError[C]
+	if errors.As(err, &coded) {
+		return coded.Code, true
+	}
+	var zero C
+	return zero, false
+}
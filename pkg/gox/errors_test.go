@@ -1,7 +1,10 @@
 package gox
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,3 +117,112 @@ func TestMultiError_WorksWithErrorsIs(t *testing.T) {
 	m.Add(target)
 	assert.ErrorIs(t, m, target)
 }
+
+func TestSyncMultiError_ConcurrentAdd(t *testing.T) {
+	var m SyncMultiError
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Go(func() {
+			m.Add(fmt.Errorf("worker %d", i))
+			m.Add(nil)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, m.Len())
+	assert.True(t, m.HasErrors())
+	assert.Len(t, m.Errors(), 100)
+}
+
+func TestSyncMultiError_ErrorOrNil_ReturnsSnapshot(t *testing.T) {
+	m := NewSyncMultiError()
+	assert.NoError(t, m.ErrorOrNil())
+
+	target := errors.New("target")
+	m.AddAll(target, nil)
+	err := m.ErrorOrNil()
+	m.Add(errors.New("later"))
+
+	var multi *MultiError
+	require.ErrorAs(t, err, &multi)
+	assert.Equal(t, 1, multi.Len())
+	assert.ErrorIs(t, err, target)
+	assert.Equal(t, "2 errors: target; later", m.Error())
+}
+
+func TestSyncMultiError_First(t *testing.T) {
+	m := NewSyncMultiError()
+	assert.NoError(t, m.First())
+	m.Add(errors.New("first"))
+	m.Add(errors.New("second"))
+	assert.EqualError(t, m.First(), "first")
+	assert.ErrorIs(t, m, m.First())
+}
+
+func TestMultiError_AddLabeled_GroupsByLabel(t *testing.T) {
+	m := NewMultiError()
+	m.Add(errors.New("unlabeled"))
+	m.AddLabeled("email", errors.New("is required"))
+	m.AddLabeled("email", errors.New("is invalid"))
+	m.AddLabeled("name", nil)
+	m.AddLabeled("age", errors.New("must be positive"))
+
+	assert.Equal(t, 4, m.Len())
+	assert.True(t, m.HasLabels())
+	assert.Equal(t, "", m.Label(0))
+	assert.Equal(t, "email", m.Label(1))
+
+	grouped := m.Map()
+	assert.Len(t, grouped["email"], 2)
+	assert.Len(t, grouped["age"], 1)
+	assert.Len(t, grouped[""], 1)
+}
+
+func TestMultiError_HasLabels_FalseForPlainErrors(t *testing.T) {
+	m := NewMultiError()
+	m.Add(errors.New("a"))
+	assert.False(t, m.HasLabels())
+	assert.Equal(t, "", m.Label(5))
+}
+
+func TestMultiError_MarshalJSON_GroupsMessages(t *testing.T) {
+	m := NewMultiError()
+	m.AddLabeled("email", errors.New("is required"))
+	m.AddLabeled("email", errors.New("is invalid"))
+	m.AddLabeled("age", errors.New("must be positive"))
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email":["is required","is invalid"],"age":["must be positive"]}`, string(data))
+}
+
+func TestCodedError_ErrorIncludesCodeAndCause(t *testing.T) {
+	assert.EqualError(t, NewCodedError("not_found", "user not found"), "not_found: user not found")
+
+	cause := errors.New("connection reset")
+	err := WrapCoded(503, "db unavailable", cause)
+	assert.EqualError(t, err, "503: db unavailable: connection reset")
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestCodedError_IsMatchesByCode(t *testing.T) {
+	errNotFound := NewCodedError("not_found", "not found")
+	err := fmt.Errorf("load user: %w", NewCodedError("not_found", "user 42 not found"))
+
+	assert.ErrorIs(t, err, errNotFound)
+	assert.NotErrorIs(t, err, NewCodedError("conflict", "conflict"))
+}
+
+func TestCodeOf_ExtractsFromChain(t *testing.T) {
+	type appCode int
+	err := fmt.Errorf("handler: %w", WrapCoded(appCode(42), "bad input", nil))
+
+	code, ok := CodeOf[appCode](err)
+	assert.True(t, ok)
+	assert.Equal(t, appCode(42), code)
+
+	_, ok = CodeOf[string](err)
+	assert.False(t, ok)
+	_, ok = CodeOf[appCode](errors.New("plain"))
+	assert.False(t, ok)
+}
@@ -1,6 +1,10 @@
 package gox
 
-import "slices"
+import (
+	"context"
+	"iter"
+	"slices"
+)
 
 // Map 对切片中每个元素应用函数，返回转换后的新切片。
 func Map[T, R any](items []T, fn func(T) R) []R {
@@ -28,6 +32,39 @@ func Filter[T any](items []T, fn func(T) bool) []T {
 	return result
 }
 
+// MapIndexed 与 Map 相同，但同时向 fn 传入元素下标。
+func MapIndexed[T, R any](items []T, fn func(int, T) R) []R {
+	if items == nil {
+		return nil
+	}
+	result := make([]R, len(items))
+	for i, item := range items {
+		result[i] = fn(i, item)
+	}
+	return result
+}
+
+// FilterIndexed 与 Filter 相同，但同时向 fn 传入元素下标。
+func FilterIndexed[T any](items []T, fn func(int, T) bool) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, 0)
+	for i, item := range items {
+		if fn(i, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ForEachIndexed 依次用下标和元素调用 fn。
+func ForEachIndexed[T any](items []T, fn func(int, T)) {
+	for i, item := range items {
+		fn(i, item)
+	}
+}
+
 // Reduce 使用累加函数将切片归约为单个值。
 func Reduce[T, R any](items []T, init R, fn func(R, T) R) R {
 	result := init
@@ -105,6 +142,35 @@ func GroupBy[T any, K comparable](items []T, fn func(T) K) map[K][]T {
 	return result
 }
 
+// GroupReduce 单次遍历 seq，按键分组并用 reducer 归约，不保留各组的元素，适合大数据量的流式聚合。
+// 每组以 init 的副本作为初始值；init 为切片或 map 等引用类型时各组会共享底层数据，应在 reducer 中自行复制。
+//
+//	totals := gox.GroupReduce(rows, func(r Row) string { return r.Region }, 0.0,
+//	    func(sum float64, r Row) float64 { return sum + r.Amount })
+func GroupReduce[T any, K comparable, R any](seq iter.Seq[T], keyFn func(T) K, init R, reducer func(R, T) R) map[K]R {
+	result := make(map[K]R)
+	for item := range seq {
+		key := keyFn(item)
+		acc, ok := result[key]
+		if !ok {
+			acc = init
+		}
+		result[key] = reducer(acc, item)
+	}
+	return result
+}
+
+// ChanSeq 将通道转换为迭代器，通道关闭时结束，可与 GroupReduce 等迭代器函数配合使用。
+func ChanSeq[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // Chunk 将切片分割成指定大小的块。
 func Chunk[T any](items []T, size int) [][]T {
 	if size <= 0 || len(items) == 0 {
@@ -160,6 +226,42 @@ func Reverse[T any](items []T) []T {
 	return result
 }
 
+// --- Context 感知迭代 ---
+
+// ForEachCtx 依次对每个元素调用 fn，每个元素之前检查 ctx.Err()，ctx 取消或 fn 返回错误时立即停止并返回该错误。
+func ForEachCtx[T any](ctx context.Context, items []T, fn func(ctx context.Context, item T) error) error {
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MapCtx 与 Map 相同，但每个元素之前检查 ctx.Err()，ctx 取消或 fn 返回错误时立即停止并返回 RErr。
+//
+//	rows, err := gox.MapCtx(c.Request.Context(), ids, loadRow).GetWithError()
+func MapCtx[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error)) Result[[]R] {
+	if items == nil {
+		return ROk[[]R](nil)
+	}
+	result := make([]R, len(items))
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return RErr[[]R](err)
+		}
+		v, err := fn(ctx, item)
+		if err != nil {
+			return RErr[[]R](err)
+		}
+		result[i] = v
+	}
+	return ROk(result)
+}
+
 // --- 指针工具 ---
 
 // Ptr 返回给定值的指针。
@@ -206,6 +308,58 @@ func CoalescePtr[T any](ptrs ...*T) (T, bool) {
 	return zero, false
 }
 
+// --- 零值工具 ---
+
+// IsZero 返回 v 是否为类型的零值。
+func IsZero[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
+
+// Default 在 v 为零值时返回 def，否则返回 v。
+//
+//	port := gox.Default(cfg.Port, 8080)
+func Default[T comparable](v, def T) T {
+	if IsZero(v) {
+		return def
+	}
+	return v
+}
+
+// DefaultFn 在 v 为零值时调用 fn 计算默认值，否则返回 v。
+func DefaultFn[T comparable](v T, fn func() T) T {
+	if IsZero(v) {
+		return fn()
+	}
+	return v
+}
+
+// NonZero 在 v 非零值时返回 Some(v)，否则返回 None。
+func NonZero[T comparable](v T) Optional[T] {
+	if IsZero(v) {
+		return ONone[T]()
+	}
+	return OSome(v)
+}
+
+// --- 副作用工具 ---
+
+// Tap 用 v 调用 fn 后原样返回 v，用于在链式调用中插入日志、指标等副作用，相当于普通值的 Result.Inspect。
+//
+//	users := gox.Tap(loadUsers(), func(u []User) { log.Printf("loaded %d users", len(u)) })
+func Tap[T any](v T, fn func(T)) T {
+	fn(v)
+	return v
+}
+
+// TapEach 用每个元素调用 fn 后原样返回切片本身。
+func TapEach[T any](items []T, fn func(T)) []T {
+	for _, item := range items {
+		fn(item)
+	}
+	return items
+}
+
 // --- 三元运算符 ---
 
 // If 根据条件返回 trueVal 或 falseVal。
@@ -225,6 +379,63 @@ func IfFn[T any](cond bool, trueFn, falseFn func() T) T {
 	return falseFn()
 }
 
+// --- Switch 表达式 ---
+
+// Switcher 是表达式形式的 switch，由 Switch 创建。
+// 按添加顺序匹配，第一个匹配的分支生效，之后的分支不再求值。
+type Switcher[T comparable, R any] struct {
+	value   T
+	result  R
+	matched bool
+}
+
+// Switch 以 v 为匹配值开始一个 switch 表达式。
+//
+//	label := gox.Switch[int, string](status).
+//	    Case(200, "ok").
+//	    CaseFn(func(s int) bool { return s >= 500 }, func(int) string { return "server error" }).
+//	    Default("unknown")
+func Switch[T comparable, R any](v T) Switcher[T, R] {
+	return Switcher[T, R]{value: v}
+}
+
+// Case 在值等于 match 时返回 result。
+func (s Switcher[T, R]) Case(match T, result R) Switcher[T, R] {
+	if !s.matched && s.value == match {
+		s.result, s.matched = result, true
+	}
+	return s
+}
+
+// CaseFn 在 pred 返回 true 时以 fn 计算结果。fn 仅在匹配时调用。
+func (s Switcher[T, R]) CaseFn(pred func(T) bool, fn func(T) R) Switcher[T, R] {
+	if !s.matched && pred(s.value) {
+		s.result, s.matched = fn(s.value), true
+	}
+	return s
+}
+
+// Default 返回匹配分支的结果，没有匹配时返回 def。
+func (s Switcher[T, R]) Default(def R) R {
+	if s.matched {
+		return s.result
+	}
+	return def
+}
+
+// DefaultFn 返回匹配分支的结果，没有匹配时调用 fn。
+func (s Switcher[T, R]) DefaultFn(fn func(T) R) R {
+	if s.matched {
+		return s.result
+	}
+	return fn(s.value)
+}
+
+// Get 返回匹配分支的结果，没有匹配时返回 None。
+func (s Switcher[T, R]) Get() Optional[R] {
+	return OFromOk(s.result, s.matched)
+}
+
 // --- 转换工具 ---
 
 // Keys 返回 map 的所有键。
@@ -272,6 +483,44 @@ func Entries[K comparable, V any](m map[K]V) []struct {
 	return result
 }
 
+// SortedKeys 返回按升序排列的 map 键。
+// 适用于生成响应、签名或哈希等需要确定性顺序的场景。
+func SortedKeys[K Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedEntries 返回按键升序排列的键值对切片。
+func SortedEntries[K Ordered, V any](m map[K]V) []struct {
+	Key   K
+	Value V
+} {
+	if m == nil {
+		return nil
+	}
+	result := make([]struct {
+		Key   K
+		Value V
+	}, 0, len(m))
+	for _, k := range SortedKeys(m) {
+		result = append(result, struct {
+			Key   K
+			Value V
+		}{Key: k, Value: m[k]})
+	}
+	return result
+}
+
+// RangeSorted 按键升序遍历 map，fn 返回 false 时停止遍历。
+func RangeSorted[K Ordered, V any](m map[K]V, fn func(K, V) bool) {
+	for _, k := range SortedKeys(m) {
+		if !fn(k, m[k]) {
+			return
+		}
+	}
+}
+
 // FromEntries 从键值对切片创建 map。
 func FromEntries[K comparable, V any](entries []struct {
 	Key   K
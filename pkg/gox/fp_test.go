@@ -1,6 +1,10 @@
 package gox
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -253,6 +257,66 @@ func TestCoalescePtr_ReturnsFalseIfAllNil(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestIsZero(t *testing.T) {
+	assert.True(t, IsZero(0))
+	assert.True(t, IsZero(""))
+	assert.True(t, IsZero[*int](nil))
+	assert.False(t, IsZero("x"))
+	assert.False(t, IsZero(struct{ A int }{1}))
+}
+
+func TestDefault_ReturnsDefaultForZero(t *testing.T) {
+	assert.Equal(t, 8080, Default(0, 8080))
+	assert.Equal(t, 9090, Default(9090, 8080))
+	assert.Equal(t, "anon", Default("", "anon"))
+}
+
+func TestDefaultFn_CallsFnOnlyForZero(t *testing.T) {
+	calls := 0
+	fn := func() string { calls++; return "computed" }
+
+	assert.Equal(t, "set", DefaultFn("set", fn))
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, "computed", DefaultFn("", fn))
+	assert.Equal(t, 1, calls)
+}
+
+func TestNonZero(t *testing.T) {
+	assert.Equal(t, 5, NonZero(5).MustGet())
+	assert.True(t, NonZero(0).IsNone())
+}
+
+func httpLabel(status int) Switcher[int, string] {
+	return Switch[int, string](status).
+		Case(200, "ok").
+		Case(404, "not found").
+		CaseFn(func(s int) bool { return s >= 500 }, func(s int) string { return "server error" })
+}
+
+func TestSwitch_ReturnsFirstMatchingCase(t *testing.T) {
+	assert.Equal(t, "ok", httpLabel(200).Default("other"))
+	assert.Equal(t, "not found", httpLabel(404).Default("other"))
+	assert.Equal(t, "server error", httpLabel(503).Default("other"))
+	assert.Equal(t, "other", httpLabel(302).Default("other"))
+}
+
+func TestSwitch_SkipsLaterCasesAfterMatch(t *testing.T) {
+	called := false
+	result := Switch[string, int]("a").
+		Case("a", 1).
+		Case("a", 2).
+		CaseFn(func(string) bool { called = true; return true }, func(string) int { return 3 }).
+		Default(0)
+	assert.Equal(t, 1, result)
+	assert.False(t, called)
+}
+
+func TestSwitch_DefaultFnAndGet(t *testing.T) {
+	assert.Equal(t, "status 302", httpLabel(302).DefaultFn(func(s int) string { return fmt.Sprintf("status %d", s) }))
+	assert.True(t, httpLabel(302).Get().IsNone())
+	assert.Equal(t, "ok", httpLabel(200).Get().MustGet())
+}
+
 func TestIf_ReturnsTrueValue(t *testing.T) {
 	result := If(true, "yes", "no")
 	assert.Equal(t, "yes", result)
@@ -302,3 +366,148 @@ func TestValues_ReturnsNilForNilMap(t *testing.T) {
 	result := Values(m)
 	assert.Nil(t, result)
 }
+
+func TestSortedKeys_ReturnsKeysInOrder(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+	assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(m))
+}
+
+func TestSortedKeys_ReturnsNilForNilMap(t *testing.T) {
+	var m map[string]int
+	assert.Nil(t, SortedKeys(m))
+}
+
+func TestSortedEntries_ReturnsEntriesInKeyOrder(t *testing.T) {
+	m := map[int]string{2: "b", 1: "a", 3: "c"}
+	entries := SortedEntries(m)
+	require.Len(t, entries, 3)
+	assert.Equal(t, 1, entries[0].Key)
+	assert.Equal(t, "a", entries[0].Value)
+	assert.Equal(t, 3, entries[2].Key)
+	assert.Equal(t, "c", entries[2].Value)
+}
+
+func TestRangeSorted_VisitsInOrderAndStops(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	var visited []string
+	RangeSorted(m, func(k string, v int) bool {
+		visited = append(visited, k)
+		return k != "b"
+	})
+	assert.Equal(t, []string{"a", "b"}, visited)
+}
+
+func TestForEachCtx_VisitsAllElements(t *testing.T) {
+	var got []int
+	err := ForEachCtx(context.Background(), []int{1, 2, 3}, func(_ context.Context, n int) error {
+		got = append(got, n)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestForEachCtx_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var got []int
+	err := ForEachCtx(ctx, []int{1, 2, 3}, func(_ context.Context, n int) error {
+		got = append(got, n)
+		if n == 2 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestForEachCtx_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	err := ForEachCtx(context.Background(), []int{1, 2, 3}, func(_ context.Context, n int) error {
+		calls++
+		if n == 1 {
+			return boom
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMapCtx_TransformsElements(t *testing.T) {
+	r := MapCtx(context.Background(), []int{1, 2, 3}, func(_ context.Context, n int) (string, error) {
+		return fmt.Sprint(n * 2), nil
+	})
+	assert.Equal(t, []string{"2", "4", "6"}, r.Unwrap())
+}
+
+func TestMapCtx_ReturnsNilForNilInput(t *testing.T) {
+	r := MapCtx(context.Background(), []int(nil), func(_ context.Context, n int) (int, error) { return n, nil })
+	assert.True(t, r.IsOk())
+	assert.Nil(t, r.Unwrap())
+}
+
+func TestMapCtx_AbortsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	r := MapCtx(ctx, []int{1, 2}, func(_ context.Context, n int) (int, error) {
+		calls++
+		return n, nil
+	})
+	assert.ErrorIs(t, r.Error(), context.Canceled)
+	assert.Equal(t, 0, calls)
+}
+
+func TestGroupReduce_SumsPerKey(t *testing.T) {
+	nums := slices.Values([]int{1, 2, 3, 4, 5})
+	sums := GroupReduce(nums, func(n int) bool { return n%2 == 0 }, 0, func(acc, n int) int { return acc + n })
+	assert.Equal(t, map[bool]int{true: 6, false: 9}, sums)
+}
+
+func TestGroupReduce_EmptySeq(t *testing.T) {
+	sums := GroupReduce(slices.Values([]int(nil)), func(n int) int { return n }, 0, func(acc, n int) int { return acc + n })
+	assert.Empty(t, sums)
+}
+
+func TestChanSeq_FeedsGroupReduce(t *testing.T) {
+	ch := make(chan string, 4)
+	for _, s := range []string{"a", "bb", "cc", "d"} {
+		ch <- s
+	}
+	close(ch)
+	counts := GroupReduce(ChanSeq(ch), func(s string) int { return len(s) }, 0, func(acc int, _ string) int { return acc + 1 })
+	assert.Equal(t, map[int]int{1: 2, 2: 2}, counts)
+}
+
+func TestTap_ReturnsValueAfterSideEffect(t *testing.T) {
+	var seen int
+	v := Tap(42, func(n int) { seen = n })
+	assert.Equal(t, 42, v)
+	assert.Equal(t, 42, seen)
+}
+
+func TestTapEach_VisitsElementsInChain(t *testing.T) {
+	var seen []int
+	doubled := Map(TapEach([]int{1, 2, 3}, func(n int) { seen = append(seen, n) }), func(n int) int { return n * 2 })
+	assert.Equal(t, []int{2, 4, 6}, doubled)
+	assert.Equal(t, []int{1, 2, 3}, seen)
+}
+
+func TestMapIndexed_PassesIndex(t *testing.T) {
+	got := MapIndexed([]string{"a", "b"}, func(i int, s string) string { return fmt.Sprintf("%d:%s", i, s) })
+	assert.Equal(t, []string{"0:a", "1:b"}, got)
+	assert.Nil(t, MapIndexed([]string(nil), func(int, string) int { return 0 }))
+}
+
+func TestFilterIndexed_SelectsByIndex(t *testing.T) {
+	got := FilterIndexed([]string{"a", "b", "c", "d"}, func(i int, _ string) bool { return i%2 == 0 })
+	assert.Equal(t, []string{"a", "c"}, got)
+}
+
+func TestForEachIndexed_VisitsInOrder(t *testing.T) {
+	var got []string
+	ForEachIndexed([]string{"x", "y"}, func(i int, s string) { got = append(got, fmt.Sprint(i, s)) })
+	assert.Equal(t, []string{"0x", "1y"}, got)
+}
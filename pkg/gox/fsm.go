@@ -0,0 +1,175 @@
+package gox
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrInvalidTransition 表示当前状态下不允许触发该事件。
+var ErrInvalidTransition = errors.New("invalid transition")
+
+// ErrFSMBusy 表示同一实例上已有 Fire 正在执行守卫或钩子，例如在钩子内再次调用 Fire。
+var ErrFSMBusy = errors.New("fsm is firing another event")
+
+// ErrEnterHookFailed 表示 OnExit 钩子已全部执行后 OnEnter 钩子失败。
+// 此时状态保持不变，但 OnExit 的副作用不会被回滚，需要补偿时由调用方根据该错误处理。
+var ErrEnterHookFailed = errors.New("enter hook failed")
+
+// FSMGuard 在转换前调用，返回错误时拒绝转换。
+type FSMGuard[S, E comparable] func(from S, event E) error
+
+// FSMHook 是状态进入/退出钩子，返回 Err 时中止转换，状态保持不变。
+// 可直接返回 TryE 的结果：
+//
+//	fsm.OnEnter(Shipped, func(from, to Status, e Event) gox.Result[struct{}] {
+//	    return gox.TryE(func() error { return notify(to) })
+//	})
+type FSMHook[S, E comparable] func(from, to S, event E) Result[struct{}]
+
+type fsmKey[S, E comparable] struct {
+	from  S
+	event E
+}
+
+type fsmTransition[S, E comparable] struct {
+	to    S
+	guard FSMGuard[S, E]
+}
+
+// fsmTable 是状态机的转换表和钩子，由同一定义创建的所有实例共享。
+type fsmTable[S, E comparable] struct {
+	transitions map[fsmKey[S, E]]fsmTransition[S, E]
+	onEnter     map[S][]FSMHook[S, E]
+	onExit      map[S][]FSMHook[S, E]
+}
+
+// FSM 是类型化的有限状态机，并发安全。
+// 转换表应在使用前配置完成；WithState 创建的实例与原实例共享转换表。
+//
+//	orders := gox.NewFSM[Status, Event](Pending).
+//	    Permit(Pending, Pay, Paid).
+//	    PermitIf(Paid, Ship, Shipped, hasAddress).
+//	    OnEnter(Shipped, notifyCustomer)
+//	fsm := orders.WithState(order.Status)
+//	next, err := fsm.Fire(Ship).GetWithError()
+type FSM[S, E comparable] struct {
+	table  *fsmTable[S, E]
+	state  S
+	mu     sync.Mutex
+	firing bool
+}
+
+// NewFSM 创建初始状态为 initial 的状态机。
+func NewFSM[S, E comparable](initial S) *FSM[S, E] {
+	return &FSM[S, E]{
+		state: initial,
+		table: &fsmTable[S, E]{
+			transitions: make(map[fsmKey[S, E]]fsmTransition[S, E]),
+			onEnter:     make(map[S][]FSMHook[S, E]),
+			onExit:      make(map[S][]FSMHook[S, E]),
+		},
+	}
+}
+
+// Permit 允许在 from 状态下通过 event 转换到 to。
+func (f *FSM[S, E]) Permit(from S, event E, to S) *FSM[S, E] {
+	return f.PermitIf(from, event, to, nil)
+}
+
+// PermitIf 与 Permit 相同，但只有 guard 返回 nil 时才允许转换。
+func (f *FSM[S, E]) PermitIf(from S, event E, to S, guard FSMGuard[S, E]) *FSM[S, E] {
+	f.table.transitions[fsmKey[S, E]{from, event}] = fsmTransition[S, E]{to: to, guard: guard}
+	return f
+}
+
+// OnEnter 注册进入 state 时调用的钩子。
+func (f *FSM[S, E]) OnEnter(state S, hook FSMHook[S, E]) *FSM[S, E] {
+	f.table.onEnter[state] = append(f.table.onEnter[state], hook)
+	return f
+}
+
+// OnExit 注册离开 state 时调用的钩子。
+func (f *FSM[S, E]) OnExit(state S, hook FSMHook[S, E]) *FSM[S, E] {
+	f.table.onExit[state] = append(f.table.onExit[state], hook)
+	return f
+}
+
+// WithState 返回共享转换表、当前状态为 state 的新实例，
+// 适用于从持久化状态恢复单个订单或任务的状态机。
+func (f *FSM[S, E]) WithState(state S) *FSM[S, E] {
+	return &FSM[S, E]{table: f.table, state: state}
+}
+
+// State 返回当前状态。
+func (f *FSM[S, E]) State() S {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state
+}
+
+// Can 返回当前状态下是否定义了 event 的转换（不执行守卫）。
+func (f *FSM[S, E]) Can(event E) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.table.transitions[fsmKey[S, E]{f.state, event}]
+	return ok
+}
+
+// Fire 触发事件，依次执行守卫、OnExit 和 OnEnter 钩子，成功后返回新状态。
+// 未定义转换时返回包装 ErrInvalidTransition 的错误；守卫或钩子失败时状态保持不变。
+// OnEnter 失败时返回的错误同时包装 ErrEnterHookFailed 和钩子的错误，表示 OnExit 已执行。
+//
+// 守卫和钩子执行时不持有锁，可以调用 State 和 Can（看到的是转换前的状态）。
+// 此期间同一实例上的其他 Fire（包括钩子内的调用）不等待，直接返回 ErrFSMBusy。
+func (f *FSM[S, E]) Fire(event E) Result[S] {
+	f.mu.Lock()
+	if f.firing {
+		f.mu.Unlock()
+		return RErr[S](fmt.Errorf("%w: %v", ErrFSMBusy, event))
+	}
+	from := f.state
+	t, ok := f.table.transitions[fsmKey[S, E]{from, event}]
+	if !ok {
+		f.mu.Unlock()
+		return RErr[S](fmt.Errorf("%w: %v on %v", ErrInvalidTransition, from, event))
+	}
+	f.firing = true
+	f.mu.Unlock()
+
+	committed := false
+	defer func() {
+		f.mu.Lock()
+		if committed {
+			f.state = t.to
+		}
+		f.firing = false
+		f.mu.Unlock()
+	}()
+
+	if err := f.runHooks(from, t, event); err != nil {
+		return RErr[S](err)
+	}
+	committed = true
+	return ROk(t.to)
+}
+
+// runHooks 执行转换的守卫、OnExit 和 OnEnter 钩子。
+func (f *FSM[S, E]) runHooks(from S, t fsmTransition[S, E], event E) error {
+	if t.guard != nil {
+		if err := t.guard(from, event); err != nil {
+			return err
+		}
+	}
+	for _, hook := range f.table.onExit[from] {
+		if err := hook(from, t.to, event).Error(); err != nil {
+			return err
+		}
+	}
+	for _, hook := range f.table.onEnter[t.to] {
+		if err := hook(from, t.to, event).Error(); err != nil {
+			return fmt.Errorf("%w: %w", ErrEnterHookFailed, err)
+		}
+	}
+	return nil
+}
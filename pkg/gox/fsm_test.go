@@ -0,0 +1,137 @@
+package gox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderStatus string
+
+const (
+	statusPending orderStatus = "pending"
+	statusPaid    orderStatus = "paid"
+	statusShipped orderStatus = "shipped"
+)
+
+type orderEvent string
+
+const (
+	eventPay  orderEvent = "pay"
+	eventShip orderEvent = "ship"
+)
+
+func newOrderFSM() *FSM[orderStatus, orderEvent] {
+	return NewFSM[orderStatus, orderEvent](statusPending).
+		Permit(statusPending, eventPay, statusPaid).
+		Permit(statusPaid, eventShip, statusShipped)
+}
+
+func TestFSM_FireTransitions(t *testing.T) {
+	f := newOrderFSM()
+
+	assert.Equal(t, statusPaid, f.Fire(eventPay).Unwrap())
+	assert.Equal(t, statusShipped, f.Fire(eventShip).Unwrap())
+	assert.Equal(t, statusShipped, f.State())
+}
+
+func TestFSM_RejectsUndefinedTransition(t *testing.T) {
+	f := newOrderFSM()
+
+	assert.False(t, f.Can(eventShip))
+	r := f.Fire(eventShip)
+	assert.ErrorIs(t, r.Error(), ErrInvalidTransition)
+	assert.EqualError(t, r.Error(), "invalid transition: pending on ship")
+	assert.Equal(t, statusPending, f.State())
+}
+
+func TestFSM_GuardBlocksTransition(t *testing.T) {
+	errUnpaid := errors.New("payment declined")
+	f := NewFSM[orderStatus, orderEvent](statusPending).
+		PermitIf(statusPending, eventPay, statusPaid, func(orderStatus, orderEvent) error { return errUnpaid })
+
+	assert.True(t, f.Can(eventPay))
+	assert.ErrorIs(t, f.Fire(eventPay).Error(), errUnpaid)
+	assert.Equal(t, statusPending, f.State())
+}
+
+func TestFSM_HooksRunInOrderAndCanAbort(t *testing.T) {
+	var calls []string
+	errAbort := errors.New("abort")
+	f := newOrderFSM().
+		OnExit(statusPending, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+			calls = append(calls, "exit "+string(from))
+			return ROk(struct{}{})
+		}).
+		OnEnter(statusPaid, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+			calls = append(calls, "enter "+string(to))
+			return ROk(struct{}{})
+		}).
+		OnExit(statusPaid, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+			calls = append(calls, "exit "+string(from))
+			return ROk(struct{}{})
+		}).
+		OnEnter(statusShipped, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+			return TryE(func() error { return errAbort })
+		})
+
+	require.True(t, f.Fire(eventPay).IsOk())
+	assert.Equal(t, []string{"exit pending", "enter paid"}, calls)
+
+	err := f.Fire(eventShip).Error()
+	assert.ErrorIs(t, err, errAbort)
+	assert.ErrorIs(t, err, ErrEnterHookFailed)
+	assert.Equal(t, []string{"exit pending", "enter paid", "exit paid"}, calls)
+	assert.Equal(t, statusPaid, f.State())
+}
+
+func TestFSM_ExitHookErrorIsNotEnterFailure(t *testing.T) {
+	errAbort := errors.New("abort")
+	f := newOrderFSM().OnExit(statusPending, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+		return RErr[struct{}](errAbort)
+	})
+
+	err := f.Fire(eventPay).Error()
+	assert.ErrorIs(t, err, errAbort)
+	assert.NotErrorIs(t, err, ErrEnterHookFailed)
+	assert.Equal(t, statusPending, f.State())
+}
+
+func TestFSM_WithStateSharesTable(t *testing.T) {
+	def := newOrderFSM()
+	order := def.WithState(statusPaid)
+
+	assert.Equal(t, statusShipped, order.Fire(eventShip).Unwrap())
+	assert.Equal(t, statusPending, def.State())
+}
+
+func TestFSM_HooksCanReadStateWithoutDeadlock(t *testing.T) {
+	var f *FSM[orderStatus, orderEvent]
+	var seen []orderStatus
+	var canShip bool
+	var nested error
+	f = newOrderFSM().OnEnter(statusPaid, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+		seen = append(seen, f.State())
+		canShip = f.Can(eventShip)
+		nested = f.Fire(eventShip).Error()
+		return ROk(struct{}{})
+	})
+
+	assert.Equal(t, statusPaid, f.Fire(eventPay).Unwrap())
+	assert.Equal(t, []orderStatus{statusPending}, seen)
+	assert.False(t, canShip)
+	assert.ErrorIs(t, nested, ErrFSMBusy)
+	assert.Equal(t, statusShipped, f.Fire(eventShip).Unwrap())
+}
+
+func TestFSM_PanickingHookReleasesFSM(t *testing.T) {
+	f := newOrderFSM().OnExit(statusPending, func(from, to orderStatus, e orderEvent) Result[struct{}] {
+		panic("boom")
+	})
+
+	assert.Panics(t, func() { f.Fire(eventPay) })
+	assert.Equal(t, statusPending, f.State())
+	assert.Panics(t, func() { f.Fire(eventPay) }, "a second Fire must run the hooks again, not report busy")
+}
@@ -0,0 +1,77 @@
+package gox
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Interval 是闭区间 [Start, End]，适用于时间段、端口范围、IP 段等。Start > End 的区间视为空。
+type Interval[T Ordered] struct {
+	Start T `json:"start"`
+	End   T `json:"end"`
+}
+
+// NewInterval 创建区间，a、b 顺序颠倒时自动交换。
+func NewInterval[T Ordered](a, b T) Interval[T] {
+	if a > b {
+		a, b = b, a
+	}
+	return Interval[T]{Start: a, End: b}
+}
+
+// IsEmpty 检查区间是否为空（Start > End）。
+func (i Interval[T]) IsEmpty() bool {
+	return i.Start > i.End
+}
+
+// Contains 检查 v 是否在区间内（含端点）。
+func (i Interval[T]) Contains(v T) bool {
+	return i.Start <= v && v <= i.End
+}
+
+// Overlaps 检查两个区间是否有交集，端点相接也算重叠。
+func (i Interval[T]) Overlaps(o Interval[T]) bool {
+	return !i.IsEmpty() && !o.IsEmpty() && i.Start <= o.End && o.Start <= i.End
+}
+
+// Intersect 返回两个区间的交集，不重叠时返回 None。
+func (i Interval[T]) Intersect(o Interval[T]) Optional[Interval[T]] {
+	if !i.Overlaps(o) {
+		return ONone[Interval[T]]()
+	}
+	return OSome(Interval[T]{Start: max(i.Start, o.Start), End: min(i.End, o.End)})
+}
+
+// Union 返回两个重叠区间的并集，不重叠时返回 None（并集不是单个区间）。
+func (i Interval[T]) Union(o Interval[T]) Optional[Interval[T]] {
+	if !i.Overlaps(o) {
+		return ONone[Interval[T]]()
+	}
+	return OSome(Interval[T]{Start: min(i.Start, o.Start), End: max(i.End, o.End)})
+}
+
+// String 返回 "[Start, End]" 形式的字符串。
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Start, i.End)
+}
+
+// MergeIntervals 合并所有重叠的区间，返回按 Start 升序排列的不相交区间，空区间被忽略。不修改输入切片。
+//
+//	gox.MergeIntervals([]gox.Interval[int]{{1, 3}, {2, 6}, {8, 10}}) // [{1 6} {8 10}]
+func MergeIntervals[T Ordered](items []Interval[T]) []Interval[T] {
+	if items == nil {
+		return nil
+	}
+	sorted := Filter(items, func(i Interval[T]) bool { return !i.IsEmpty() })
+	slices.SortFunc(sorted, OrderBy(func(i Interval[T]) T { return i.Start }))
+
+	result := make([]Interval[T], 0, len(sorted))
+	for _, item := range sorted {
+		if n := len(result); n > 0 && result[n-1].Overlaps(item) {
+			result[n-1].End = max(result[n-1].End, item.End)
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
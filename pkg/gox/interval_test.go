@@ -0,0 +1,51 @@
+package gox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInterval_SwapsReversedBounds(t *testing.T) {
+	assert.Equal(t, Interval[int]{Start: 1, End: 5}, NewInterval(5, 1))
+}
+
+func TestInterval_Contains(t *testing.T) {
+	i := NewInterval(80, 443)
+	assert.True(t, i.Contains(80))
+	assert.True(t, i.Contains(443))
+	assert.False(t, i.Contains(444))
+}
+
+func TestInterval_Overlaps(t *testing.T) {
+	i := NewInterval(1, 5)
+	assert.True(t, i.Overlaps(NewInterval(5, 10)))
+	assert.True(t, i.Overlaps(NewInterval(2, 3)))
+	assert.False(t, i.Overlaps(NewInterval(6, 10)))
+	assert.False(t, i.Overlaps(Interval[int]{Start: 3, End: 2}))
+}
+
+func TestInterval_Intersect(t *testing.T) {
+	assert.Equal(t, NewInterval(3, 5), NewInterval(1, 5).Intersect(NewInterval(3, 8)).MustGet())
+	assert.True(t, NewInterval(1, 2).Intersect(NewInterval(3, 4)).IsNone())
+}
+
+func TestInterval_Union(t *testing.T) {
+	assert.Equal(t, NewInterval(1, 8), NewInterval(1, 5).Union(NewInterval(3, 8)).MustGet())
+	assert.True(t, NewInterval(1, 2).Union(NewInterval(3, 4)).IsNone())
+}
+
+func TestInterval_String(t *testing.T) {
+	assert.Equal(t, "[a, c]", NewInterval("a", "c").String())
+}
+
+func TestMergeIntervals_MergesOverlapping(t *testing.T) {
+	items := []Interval[int]{{8, 10}, {1, 3}, {2, 6}, {6, 7}, {5, 4}}
+	merged := MergeIntervals(items)
+	assert.Equal(t, []Interval[int]{{1, 7}, {8, 10}}, merged)
+	assert.Equal(t, Interval[int]{8, 10}, items[0], "input must not be modified")
+}
+
+func TestMergeIntervals_NilInput(t *testing.T) {
+	assert.Nil(t, MergeIntervals[int](nil))
+}
@@ -0,0 +1,71 @@
+package gox
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// --- JSON 序列化，返回 Result ---
+
+// ToJSON 将值序列化为 JSON。
+func ToJSON[T any](v T) Result[[]byte] {
+	return Try(func() ([]byte, error) { return json.Marshal(v) })
+}
+
+// FromJSON 将 JSON 反序列化为类型 T。
+func FromJSON[T any](data []byte) Result[T] {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return RErr[T](err)
+	}
+	return ROk(v)
+}
+
+// MustToJSON 将值序列化为 JSON，失败时 panic。
+func MustToJSON[T any](v T) []byte {
+	return ToJSON(v).Unwrap()
+}
+
+// MustFromJSON 将 JSON 反序列化为类型 T，失败时 panic。
+func MustFromJSON[T any](data []byte) T {
+	return FromJSON[T](data).Unwrap()
+}
+
+// --- 数值安全的严格解码 ---
+
+// DecodeJSONAs 使用严格模式将 JSON 解码为类型 T。
+// 与 FromJSON 不同：
+//   - 启用 UseNumber，any 中的数字解码为 json.Number 而非 float64，避免大整数 ID 丢失精度
+//   - 启用 DisallowUnknownFields，未知字段返回错误
+//   - 顶层值之后存在多余数据时返回错误
+func DecodeJSONAs[T any](data []byte) Result[T] {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+
+	var v T
+	if err := dec.Decode(&v); err != nil {
+		return RErr[T](err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return RErr[T](errors.New("json: unexpected data after top-level value"))
+	}
+	return ROk(v)
+}
+
+// DecodeJSONNumber 将 json.Number 转换为数值类型 T。
+// 整数形式按 int64/uint64 精确解析，不经过 float64；
+// 目标类型无法精确表示时返回包装 ErrNumericOverflow 的 Err。
+func DecodeJSONNumber[T Numeric](n json.Number) Result[T] {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if strings.HasPrefix(s, "-") {
+			return AndThenTo(ParseInt64(s), ConvertNumeric[T, int64])
+		}
+		return AndThenTo(ParseUint64(s), ConvertNumeric[T, uint64])
+	}
+	return AndThenTo(ParseFloat(s), ConvertNumeric[T, float64])
+}
@@ -0,0 +1,86 @@
+package gox
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonUser struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestToJSON_ReturnsOk(t *testing.T) {
+	r := ToJSON(jsonUser{Name: "alice", Age: 30})
+	assert.True(t, r.IsOk())
+	assert.JSONEq(t, `{"name":"alice","age":30}`, string(r.Unwrap()))
+}
+
+func TestToJSON_ReturnsErrForUnsupportedType(t *testing.T) {
+	r := ToJSON(make(chan int))
+	assert.True(t, r.IsErr())
+}
+
+func TestFromJSON_ReturnsOk(t *testing.T) {
+	r := FromJSON[jsonUser]([]byte(`{"name":"bob","age":25}`))
+	assert.Equal(t, jsonUser{Name: "bob", Age: 25}, r.Unwrap())
+}
+
+func TestFromJSON_ReturnsErrForInvalidJSON(t *testing.T) {
+	r := FromJSON[jsonUser]([]byte(`{invalid`))
+	assert.True(t, r.IsErr())
+}
+
+func TestFromJSON_ComposesWithAndThenTo(t *testing.T) {
+	r := AndThenTo(ToJSON(jsonUser{Name: "carol"}), FromJSON[jsonUser])
+	assert.Equal(t, "carol", r.Unwrap().Name)
+}
+
+func TestMustToJSON_PanicsOnError(t *testing.T) {
+	assert.Panics(t, func() { MustToJSON(make(chan int)) })
+}
+
+func TestMustFromJSON_PanicsOnError(t *testing.T) {
+	assert.Panics(t, func() { MustFromJSON[int]([]byte(`"x"`)) })
+	assert.Equal(t, 42, MustFromJSON[int]([]byte(`42`)))
+}
+
+func TestDecodeJSONAs_PreservesLargeIntegers(t *testing.T) {
+	r := DecodeJSONAs[map[string]any]([]byte(`{"id":9007199254740993}`))
+	assert.Equal(t, json.Number("9007199254740993"), r.Unwrap()["id"])
+}
+
+func TestDecodeJSONAs_RejectsUnknownFields(t *testing.T) {
+	r := DecodeJSONAs[jsonUser]([]byte(`{"name":"alice","extra":1}`))
+	assert.True(t, r.IsErr())
+}
+
+func TestDecodeJSONAs_RejectsTrailingData(t *testing.T) {
+	r := DecodeJSONAs[jsonUser]([]byte(`{"name":"alice"} {}`))
+	assert.True(t, r.IsErr())
+}
+
+func TestDecodeJSONAs_DecodesStruct(t *testing.T) {
+	r := DecodeJSONAs[jsonUser]([]byte(`{"name":"alice","age":30}`))
+	assert.Equal(t, jsonUser{Name: "alice", Age: 30}, r.Unwrap())
+}
+
+func TestDecodeJSONNumber_ParsesIntegersExactly(t *testing.T) {
+	assert.Equal(t, int64(9007199254740993), DecodeJSONNumber[int64]("9007199254740993").Unwrap())
+	assert.Equal(t, uint64(math.MaxUint64), DecodeJSONNumber[uint64]("18446744073709551615").Unwrap())
+	assert.Equal(t, int32(-5), DecodeJSONNumber[int32]("-5").Unwrap())
+}
+
+func TestDecodeJSONNumber_ParsesFloats(t *testing.T) {
+	assert.InDelta(t, 1.5, DecodeJSONNumber[float64]("1.5").Unwrap(), 0)
+	assert.Equal(t, 100, DecodeJSONNumber[int]("1e2").Unwrap())
+}
+
+func TestDecodeJSONNumber_FailsOnOverflowOrTruncation(t *testing.T) {
+	assert.ErrorIs(t, DecodeJSONNumber[int8]("300").Error(), ErrNumericOverflow)
+	assert.ErrorIs(t, DecodeJSONNumber[int]("1.5").Error(), ErrNumericOverflow)
+	assert.True(t, DecodeJSONNumber[int]("abc").IsErr())
+}
@@ -0,0 +1,85 @@
+package gox
+
+import "sync"
+
+// onceEntry 是 OnceMap 中单个键的初始化状态。
+type onceEntry[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// OnceMap 对每个键最多成功执行一次初始化，之后返回缓存的值，并发安全。
+// 同一键的并发调用共享同一次初始化；初始化失败（包括 panic）不会被缓存，下次调用会重试。
+//
+//	dbs := gox.NewOnceMap(func(tenant string) (*sql.DB, error) {
+//	    return sql.Open("postgres", dsnFor(tenant))
+//	})
+//	db, err := dbs.Get(tenantID).GetWithError()
+type OnceMap[K comparable, V any] struct {
+	init    func(K) (V, error)
+	entries map[K]*onceEntry[V]
+	mu      sync.Mutex
+}
+
+// NewOnceMap 创建使用 init 初始化各键的 OnceMap。
+func NewOnceMap[K comparable, V any](init func(K) (V, error)) *OnceMap[K, V] {
+	return &OnceMap[K, V]{init: init, entries: make(map[K]*onceEntry[V])}
+}
+
+// Get 返回键对应的值，首次访问时调用 init。
+func (m *OnceMap[K, V]) Get(key K) Result[V] {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if ok {
+		m.mu.Unlock()
+		<-e.done
+		return Try(func() (V, error) { return e.value, e.err })
+	}
+	e = &onceEntry[V]{done: make(chan struct{})}
+	m.entries[key] = e
+	m.mu.Unlock()
+
+	// init panic 时转换为错误，避免等待中的调用永久阻塞
+	r := CatchE(func() (V, error) { return m.init(key) })
+	e.value, e.err = r.data, r.err
+	if e.err != nil {
+		m.mu.Lock()
+		if m.entries[key] == e {
+			delete(m.entries, key)
+		}
+		m.mu.Unlock()
+	}
+	close(e.done)
+	return r
+}
+
+// Peek 返回已初始化成功的值，不触发初始化。
+func (m *OnceMap[K, V]) Peek(key K) Optional[V] {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	m.mu.Unlock()
+	if !ok {
+		return ONone[V]()
+	}
+	select {
+	case <-e.done:
+		return OFromOk(e.value, e.err == nil)
+	default:
+		return ONone[V]()
+	}
+}
+
+// Delete 移除键的缓存值，下次 Get 会重新初始化。正在进行的初始化不受影响。
+func (m *OnceMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// Len 返回已缓存（包括正在初始化）的键数量。
+func (m *OnceMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
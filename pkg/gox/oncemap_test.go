@@ -0,0 +1,64 @@
+package gox
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnceMap_InitializesOncePerKey(t *testing.T) {
+	var calls atomic.Int32
+	m := NewOnceMap(func(k string) (string, error) {
+		calls.Add(1)
+		return "db:" + k, nil
+	})
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			assert.Equal(t, "db:a", m.Get("a").Unwrap())
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, "db:b", m.Get("b").Unwrap())
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestOnceMap_RetriesAfterError(t *testing.T) {
+	fail := true
+	m := NewOnceMap(func(k int) (int, error) {
+		if fail {
+			return 0, errors.New("unavailable")
+		}
+		return k * 2, nil
+	})
+
+	assert.Error(t, m.Get(1).Error())
+	assert.True(t, m.Peek(1).IsNone())
+
+	fail = false
+	assert.Equal(t, 2, m.Get(1).Unwrap())
+	assert.Equal(t, 2, m.Peek(1).MustGet())
+}
+
+func TestOnceMap_ConvertsPanicToError(t *testing.T) {
+	m := NewOnceMap(func(k int) (int, error) { panic("bad tenant") })
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, m.Get(1).Error(), &panicErr)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestOnceMap_DeleteForcesReinit(t *testing.T) {
+	var calls int
+	m := NewOnceMap(func(k int) (int, error) { calls++; return calls, nil })
+
+	assert.Equal(t, 1, m.Get(7).Unwrap())
+	m.Delete(7)
+	assert.Equal(t, 2, m.Get(7).Unwrap())
+}
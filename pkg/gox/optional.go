@@ -1,10 +1,22 @@
 package gox
 
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
 // Optional 表示一个可能存在或不存在的值。
 // 灵感来自 Java 的 Optional 和 Rust 的 Option 类型。
+//
+// Optional 实现了 json.Marshaler 和 json.Unmarshaler，None 与 null 互相映射，
+// 可直接嵌入请求/响应结构体。同时实现了 sql.Scanner 和 driver.Valuer，
+// 可替代 sql.NullString、sql.NullInt64 等类型。
 type Optional[T any] struct {
 	value T
 	valid bool
+	set   bool
 }
 
 // OSome 创建一个包含值的 Optional。
@@ -17,6 +29,12 @@ func ONone[T any]() Optional[T] {
 	return Optional[T]{valid: false}
 }
 
+// ONull 创建一个被显式设置为 null 的空 Optional。
+// 与 ONone 的区别在于 IsSet 返回 true。
+func ONull[T any]() Optional[T] {
+	return Optional[T]{set: true}
+}
+
 // OFromPtr 从指针创建 Optional。
 // 如果指针为 nil 返回 None，否则返回 Some(*p)。
 func OFromPtr[T any](p *T) Optional[T] {
@@ -53,6 +71,13 @@ func (o Optional[T]) IsNone() bool {
 	return !o.valid
 }
 
+// IsSet 返回 Optional 是否被显式设置（包括设置为 null）。
+// 从 JSON 解码时，字段出现即视为已设置，可用于区分 PATCH 请求中
+// "字段缺失"（不修改）与 "字段为 null"（清空）。
+func (o Optional[T]) IsSet() bool {
+	return o.valid || o.set
+}
+
 // Get 返回值和表示是否存在的布尔值。
 func (o Optional[T]) Get() (T, bool) {
 	return o.value, o.valid
@@ -182,6 +207,39 @@ func (o Optional[T]) Inspect(fn func(T)) Optional[T] {
 	return o
 }
 
+// --- 原地修改 ---
+
+// GetOrInsert 如果为空则插入 def，返回内部值的指针。
+func (o *Optional[T]) GetOrInsert(def T) *T {
+	if !o.valid {
+		*o = OSome(def)
+	}
+	return &o.value
+}
+
+// GetOrInsertWith 如果为空则插入 fn 的返回值，返回内部值的指针。
+// fn 仅在为空时调用。
+func (o *Optional[T]) GetOrInsertWith(fn func() T) *T {
+	if !o.valid {
+		*o = OSome(fn())
+	}
+	return &o.value
+}
+
+// Take 取出当前值并将自身置为 None。
+func (o *Optional[T]) Take() Optional[T] {
+	old := *o
+	*o = ONone[T]()
+	return old
+}
+
+// Replace 将自身替换为 Some(v)，返回原来的 Optional。
+func (o *Optional[T]) Replace(v T) Optional[T] {
+	old := *o
+	*o = OSome(v)
+	return old
+}
+
 // OMatch 如果有值执行 someFn，否则执行 noneFn。
 func OMatch[T, R any](o Optional[T], someFn func(T) R, noneFn func() R) R {
 	if o.valid {
@@ -225,3 +283,95 @@ func OUnzip[T, U any](o Optional[struct {
 	}
 	return OSome(o.value.First), OSome(o.value.Second)
 }
+
+// OCollect 将 Optional 切片收集为切片的 Optional。
+// 全部为 Some 时返回包含所有值的 Some，任一为 None 时返回 None。
+func OCollect[T any](opts []Optional[T]) Optional[[]T] {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if !o.valid {
+			return ONone[[]T]()
+		}
+		values = append(values, o.value)
+	}
+	return OSome(values)
+}
+
+// OSequence 是 OCollect 的可变参数形式，适用于组合多个可选字段。
+//
+//	gox.OSequence(req.First, req.Last).OrElse(nil)
+func OSequence[T any](opts ...Optional[T]) Optional[[]T] {
+	return OCollect(opts)
+}
+
+// OValues 返回所有 Some 的值，忽略 None。
+func OValues[T any](opts []Optional[T]) []T {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if o.valid {
+			values = append(values, o.value)
+		}
+	}
+	return values
+}
+
+// OFilterMap 对每个元素调用 fn，仅保留返回 Some 的结果。
+//
+//	users := gox.OFilterMap(ids, func(id int) gox.Optional[User] {
+//	    return gox.OFromOk(cache[id])
+//	})
+func OFilterMap[T, R any](items []T, fn func(T) Optional[R]) []R {
+	result := make([]R, 0, len(items))
+	for _, item := range items {
+		if o := fn(item); o.valid {
+			result = append(result, o.value)
+		}
+	}
+	return result
+}
+
+// --- JSON 支持 ---
+
+// MarshalJSON 实现 json.Marshaler。None 序列化为 null。
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler。null 反序列化为 None。
+// 无论是否为 null，解码后 IsSet 都返回 true。
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = ONull[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OSome(v)
+	return nil
+}
+
+// --- database/sql 支持 ---
+
+// Scan 实现 sql.Scanner。数据库 NULL 扫描为 None。
+// 支持 database/sql 能够转换的所有基础类型（string、int64、float64、bool、time.Time 等）。
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OFromOk(n.V, n.Valid)
+	return nil
+}
+
+// Value 实现 driver.Valuer。None 写入为 NULL。
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
@@ -1,7 +1,11 @@
 package gox
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,3 +240,176 @@ func TestOZip_ReturnsNoneIfAnyIsNone(t *testing.T) {
 	result := OZip(a, b)
 	assert.True(t, result.IsNone())
 }
+
+func TestONull_IsNoneButSet(t *testing.T) {
+	opt := ONull[int]()
+	assert.True(t, opt.IsNone())
+	assert.True(t, opt.IsSet())
+	assert.False(t, ONone[int]().IsSet())
+	assert.True(t, OSome(1).IsSet())
+}
+
+func TestOptional_MarshalJSON(t *testing.T) {
+	type payload struct {
+		Name Optional[string] `json:"name"`
+		Age  Optional[int]    `json:"age"`
+	}
+	data, err := json.Marshal(payload{Name: OSome("alice"), Age: ONone[int]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"alice","age":null}`, string(data))
+}
+
+func TestOptional_UnmarshalJSON_DistinguishesMissingNullAndValue(t *testing.T) {
+	type patch struct {
+		Name  Optional[string] `json:"name"`
+		Email Optional[string] `json:"email"`
+		Age   Optional[int]    `json:"age"`
+	}
+	var p patch
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"bob","email":null}`), &p))
+
+	assert.Equal(t, "bob", p.Name.MustGet())
+	assert.True(t, p.Name.IsSet())
+
+	assert.True(t, p.Email.IsNone())
+	assert.True(t, p.Email.IsSet())
+
+	assert.True(t, p.Age.IsNone())
+	assert.False(t, p.Age.IsSet())
+}
+
+func TestOptional_UnmarshalJSON_ReturnsErrorForInvalidValue(t *testing.T) {
+	var opt Optional[int]
+	require.Error(t, json.Unmarshal([]byte(`"x"`), &opt))
+	assert.True(t, opt.IsNone())
+}
+
+func TestOptional_ImplementsSQLInterfaces(t *testing.T) {
+	var _ sql.Scanner = (*Optional[string])(nil)
+	var _ driver.Valuer = Optional[string]{}
+}
+
+func TestOptional_Scan_NullBecomesNone(t *testing.T) {
+	opt := OSome("old")
+	require.NoError(t, opt.Scan(nil))
+	assert.True(t, opt.IsNone())
+}
+
+func TestOptional_Scan_ConvertsBaseTypes(t *testing.T) {
+	var s Optional[string]
+	require.NoError(t, s.Scan([]byte("alice")))
+	assert.Equal(t, "alice", s.MustGet())
+
+	var i Optional[int64]
+	require.NoError(t, i.Scan(int64(42)))
+	assert.Equal(t, int64(42), i.MustGet())
+
+	var f Optional[float64]
+	require.NoError(t, f.Scan("1.5"))
+	assert.InDelta(t, 1.5, f.MustGet(), 0)
+
+	var b Optional[bool]
+	require.NoError(t, b.Scan(true))
+	assert.True(t, b.MustGet())
+
+	now := time.Now()
+	var ts Optional[time.Time]
+	require.NoError(t, ts.Scan(now))
+	assert.Equal(t, now, ts.MustGet())
+}
+
+func TestOptional_Scan_ReturnsErrorOnMismatch(t *testing.T) {
+	var i Optional[int64]
+	require.Error(t, i.Scan("abc"))
+}
+
+func TestOptional_Value(t *testing.T) {
+	v, err := ONone[string]().Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = OSome(int32(7)).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), v)
+
+	v, err = OSome("x").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "x", v)
+}
+
+func TestOptional_GetOrInsert_InsertsWhenNone(t *testing.T) {
+	opt := ONone[int]()
+	p := opt.GetOrInsert(5)
+	assert.Equal(t, 5, *p)
+	*p = 6
+	assert.Equal(t, 6, opt.MustGet())
+}
+
+func TestOptional_GetOrInsert_KeepsExistingValue(t *testing.T) {
+	opt := OSome(1)
+	assert.Equal(t, 1, *opt.GetOrInsert(5))
+}
+
+func TestOptional_GetOrInsertWith_CallsFnOnlyWhenNone(t *testing.T) {
+	calls := 0
+	fn := func() []string { calls++; return []string{"a"} }
+
+	var opt Optional[[]string]
+	tags := opt.GetOrInsertWith(fn)
+	*tags = append(*tags, "b")
+	opt.GetOrInsertWith(fn)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"a", "b"}, opt.MustGet())
+}
+
+func TestOptional_Take_MovesValueOut(t *testing.T) {
+	opt := OSome(42)
+	taken := opt.Take()
+	assert.Equal(t, 42, taken.MustGet())
+	assert.True(t, opt.IsNone())
+	assert.True(t, opt.Take().IsNone())
+}
+
+func TestOptional_Replace_ReturnsOldValue(t *testing.T) {
+	opt := ONone[int]()
+	assert.True(t, opt.Replace(1).IsNone())
+	assert.Equal(t, 1, opt.Replace(2).MustGet())
+	assert.Equal(t, 2, opt.MustGet())
+}
+
+func TestOCollect_ReturnsSomeWhenAllSome(t *testing.T) {
+	r := OCollect([]Optional[int]{OSome(1), OSome(2)})
+	assert.Equal(t, []int{1, 2}, r.MustGet())
+}
+
+func TestOCollect_ReturnsNoneWhenAnyNone(t *testing.T) {
+	r := OCollect([]Optional[int]{OSome(1), ONone[int](), OSome(3)})
+	assert.True(t, r.IsNone())
+}
+
+func TestOCollect_EmptyInputIsSome(t *testing.T) {
+	r := OCollect[int](nil)
+	assert.True(t, r.IsSome())
+	assert.Empty(t, r.MustGet())
+}
+
+func TestOSequence_CollectsVariadicOptionals(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, OSequence(OSome("a"), OSome("b")).MustGet())
+	assert.True(t, OSequence(OSome("a"), ONull[string]()).IsNone())
+}
+
+func TestOValues_SkipsNone(t *testing.T) {
+	opts := []Optional[int]{OSome(1), ONone[int](), ONull[int](), OSome(3)}
+	assert.Equal(t, []int{1, 3}, OValues(opts))
+	assert.Empty(t, OValues[int](nil))
+}
+
+func TestOFilterMap_KeepsSomeResults(t *testing.T) {
+	lookup := map[int]string{1: "a", 3: "c"}
+	result := OFilterMap([]int{1, 2, 3}, func(id int) Optional[string] {
+		v, ok := lookup[id]
+		return OFromOk(v, ok)
+	})
+	assert.Equal(t, []string{"a", "c"}, result)
+}
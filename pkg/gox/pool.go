@@ -0,0 +1,36 @@
+package gox
+
+import "sync"
+
+// Pool 是 sync.Pool 的类型安全包装。
+// T 通常应为指针类型，否则 Put 时装箱会产生额外分配。
+//
+//	bufs := gox.NewPool(func() *bytes.Buffer { return new(bytes.Buffer) }, (*bytes.Buffer).Reset)
+//	buf := bufs.Get()
+//	defer bufs.Put(buf)
+type Pool[T any] struct {
+	pool  sync.Pool
+	reset func(T)
+}
+
+// NewPool 创建对象池。newFn 在池为空时创建新对象；
+// reset 在对象归还前调用以清理状态，可为 nil。
+func NewPool[T any](newFn func() T, reset func(T)) *Pool[T] {
+	return &Pool[T]{
+		pool:  sync.Pool{New: func() any { return newFn() }},
+		reset: reset,
+	}
+}
+
+// Get 从池中取出一个对象，池为空时调用 newFn 创建。
+func (p *Pool[T]) Get() T {
+	return p.pool.Get().(T)
+}
+
+// Put 重置对象并归还到池中。
+func (p *Pool[T]) Put(v T) {
+	if p.reset != nil {
+		p.reset(v)
+	}
+	p.pool.Put(v)
+}
@@ -0,0 +1,31 @@
+package gox
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_GetCreatesWhenEmpty(t *testing.T) {
+	created := 0
+	p := NewPool(func() *bytes.Buffer {
+		created++
+		return new(bytes.Buffer)
+	}, nil)
+
+	buf := p.Get()
+	assert.NotNil(t, buf)
+	assert.Equal(t, 1, created)
+}
+
+func TestPool_PutResetsBeforeReuse(t *testing.T) {
+	p := NewPool(func() *bytes.Buffer { return new(bytes.Buffer) }, (*bytes.Buffer).Reset)
+
+	buf := p.Get()
+	buf.WriteString("dirty")
+	p.Put(buf)
+
+	assert.Equal(t, 0, buf.Len())
+	assert.Equal(t, 0, p.Get().Len())
+}
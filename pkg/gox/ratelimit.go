@@ -0,0 +1,119 @@
+package gox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited 表示调用超出速率限制。
+var ErrRateLimited = errors.New("rate limited")
+
+// Rate 描述令牌桶限速：每秒补充 Limit 个令牌，桶容量为 Burst。
+type Rate struct {
+	// Limit 是每秒允许的调用次数，必须大于 0。
+	Limit float64
+	// Burst 是允许的突发调用数。默认值: 1
+	Burst int
+}
+
+// RateLimiter 是并发安全的令牌桶限速器。
+type RateLimiter struct {
+	last   time.Time
+	now    func() time.Time
+	rate   float64
+	tokens float64
+	burst  float64
+	mu     sync.Mutex
+}
+
+// NewRateLimiter 创建限速器，初始时桶是满的。r.Limit 不大于 0 时 panic。
+func NewRateLimiter(r Rate) *RateLimiter {
+	if !(r.Limit > 0) {
+		panic("RateLimiter Limit must be positive")
+	}
+	if r.Burst <= 0 {
+		r.Burst = 1
+	}
+	l := &RateLimiter{rate: r.Limit, burst: float64(r.Burst), tokens: float64(r.Burst), now: time.Now}
+	l.last = l.now()
+	return l
+}
+
+// Allow 尝试立即取得一个令牌。
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Wait 阻塞直到取得令牌，ctx 取消时返回 ctx.Err() 并归还预留的令牌。
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.refill()
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill 按经过的时间补充令牌，调用方须持有锁。
+func (l *RateLimiter) refill() {
+	now := l.now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// RateLimit 返回限速后的 fn，超出速率时不调用 fn，直接返回 RErr(ErrRateLimited)。
+//
+//	search := gox.RateLimit(client.Search, gox.Rate{Limit: 10, Burst: 5})
+//	r := search(ctx)
+func RateLimit[T any](fn func() (T, error), r Rate) func(ctx context.Context) Result[T] {
+	l := NewRateLimiter(r)
+	return func(ctx context.Context) Result[T] {
+		if err := ctx.Err(); err != nil {
+			return RErr[T](err)
+		}
+		if !l.Allow() {
+			return RErr[T](ErrRateLimited)
+		}
+		return Try(fn)
+	}
+}
+
+// RateLimitWait 与 RateLimit 相同，但超出速率时阻塞等待，ctx 取消时返回 RErr(ctx.Err())。
+func RateLimitWait[T any](fn func() (T, error), r Rate) func(ctx context.Context) Result[T] {
+	l := NewRateLimiter(r)
+	return func(ctx context.Context) Result[T] {
+		if err := l.Wait(ctx); err != nil {
+			return RErr[T](err)
+		}
+		return Try(fn)
+	}
+}
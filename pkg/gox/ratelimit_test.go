@@ -0,0 +1,71 @@
+package gox
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock 返回可手动推进的时钟。
+func fakeClock(l *RateLimiter) func(time.Duration) {
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+	l.last = now
+	return func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimiter_AllowsBurstThenRefills(t *testing.T) {
+	l := NewRateLimiter(Rate{Limit: 2, Burst: 3})
+	advance := fakeClock(l)
+
+	for range 3 {
+		assert.True(t, l.Allow())
+	}
+	assert.False(t, l.Allow())
+
+	advance(500 * time.Millisecond)
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	advance(time.Hour)
+	for range 3 {
+		assert.True(t, l.Allow())
+	}
+	assert.False(t, l.Allow())
+}
+
+func TestNewRateLimiter_PanicsOnNonPositiveLimit(t *testing.T) {
+	assert.Panics(t, func() { NewRateLimiter(Rate{}) })
+	assert.Panics(t, func() { NewRateLimiter(Rate{Limit: -1, Burst: 5}) })
+	assert.Panics(t, func() { RateLimit(func() (int, error) { return 0, nil }, Rate{Limit: math.NaN()}) })
+}
+
+func TestRateLimit_ReturnsErrRateLimited(t *testing.T) {
+	calls := 0
+	limited := RateLimit(func() (int, error) { calls++; return calls, nil }, Rate{Limit: 0.001})
+
+	assert.Equal(t, 1, limited(context.Background()).Unwrap())
+	assert.ErrorIs(t, limited(context.Background()).Error(), ErrRateLimited)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimitWait_BlocksUntilTokenAvailable(t *testing.T) {
+	limited := RateLimitWait(func() (int, error) { return 1, nil }, Rate{Limit: 50})
+
+	start := time.Now()
+	assert.True(t, limited(context.Background()).IsOk())
+	assert.True(t, limited(context.Background()).IsOk())
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+}
+
+func TestRateLimitWait_RespectsContext(t *testing.T) {
+	limited := RateLimitWait(func() (int, error) { return 1, nil }, Rate{Limit: 0.001})
+	assert.True(t, limited(context.Background()).IsOk())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limited(ctx).Error(), context.DeadlineExceeded)
+}
@@ -0,0 +1,90 @@
+package gox
+
+import (
+	"context"
+	"iter"
+	"math/rand/v2"
+	"sync"
+)
+
+// Reservoir 使用蓄水池抽样（Algorithm R）从数据流中维护大小为 k 的均匀随机样本，并发安全。
+//
+//	r := gox.NewReservoir[Event](100)
+//	for ev := range events {
+//	    r.Add(ev)
+//	}
+//	sample := r.Sample()
+type Reservoir[T any] struct {
+	intN   func(n int) int
+	sample []T
+	k      int
+	seen   int
+	mu     sync.Mutex
+}
+
+// NewReservoir 创建样本容量为 k 的蓄水池，k <= 0 时 panic。
+func NewReservoir[T any](k int) *Reservoir[T] {
+	if k <= 0 {
+		panic("Reservoir size must be positive")
+	}
+	return &Reservoir[T]{k: k, sample: make([]T, 0, k), intN: rand.IntN}
+}
+
+// Add 处理一个元素。
+func (r *Reservoir[T]) Add(item T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen++
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, item)
+		return
+	}
+	if j := r.intN(r.seen); j < r.k {
+		r.sample[j] = item
+	}
+}
+
+// AddSeq 处理迭代器中的所有元素。
+func (r *Reservoir[T]) AddSeq(seq iter.Seq[T]) {
+	for item := range seq {
+		r.Add(item)
+	}
+}
+
+// AddChan 处理通道中的元素，直到通道关闭或 ctx 取消，返回 ctx.Err() 或 nil。
+func (r *Reservoir[T]) AddChan(ctx context.Context, ch <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			r.Add(item)
+		}
+	}
+}
+
+// Sample 返回当前样本的副本，处理的元素少于 k 时返回全部元素。
+func (r *Reservoir[T]) Sample() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]T(nil), r.sample...)
+}
+
+// Seen 返回已处理的元素总数。
+func (r *Reservoir[T]) Seen() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen
+}
+
+// Reset 清空样本和计数。
+func (r *Reservoir[T]) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.sample)
+	r.sample = r.sample[:0]
+	r.seen = 0
+}
@@ -0,0 +1,72 @@
+package gox
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservoir_KeepsAllWhenFewerThanK(t *testing.T) {
+	r := NewReservoir[int](5)
+	r.Add(1)
+	r.Add(2)
+	assert.Equal(t, []int{1, 2}, r.Sample())
+	assert.Equal(t, 2, r.Seen())
+}
+
+func TestReservoir_ReplacesUsingRandomIndex(t *testing.T) {
+	r := NewReservoir[int](2)
+	picks := []int{0, 5}
+	r.intN = func(n int) int {
+		p := picks[0]
+		picks = picks[1:]
+		return p
+	}
+	r.AddSeq(slices.Values([]int{1, 2, 3, 4}))
+	assert.Equal(t, []int{3, 2}, r.Sample())
+	assert.Equal(t, 4, r.Seen())
+}
+
+func TestReservoir_SampleIsUniform(t *testing.T) {
+	counts := make([]int, 10)
+	for range 2000 {
+		r := NewReservoir[int](3)
+		r.AddSeq(slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}))
+		for _, v := range r.Sample() {
+			counts[v]++
+		}
+	}
+	// 期望每个元素被选中 600 次
+	for _, c := range counts {
+		assert.InDelta(t, 600, c, 120)
+	}
+}
+
+func TestReservoir_AddChan(t *testing.T) {
+	r := NewReservoir[int](10)
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	require.NoError(t, r.AddChan(context.Background(), ch))
+	assert.Equal(t, []int{1, 2}, r.Sample())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, r.AddChan(ctx, make(chan int)), context.Canceled)
+}
+
+func TestReservoir_Reset(t *testing.T) {
+	r := NewReservoir[int](2)
+	r.Add(1)
+	r.Reset()
+	assert.Empty(t, r.Sample())
+	assert.Equal(t, 0, r.Seen())
+}
+
+func TestNewReservoir_PanicsOnNonPositiveSize(t *testing.T) {
+	assert.Panics(t, func() { NewReservoir[int](0) })
+}
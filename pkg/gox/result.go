@@ -1,5 +1,14 @@
 package gox
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
 // Result 表示一个可能成功（Ok）或失败（Err）的值。
 // 灵感来自 Rust 的 Result 类型，提供了一种无需多返回值的错误处理方式。
 type Result[T any] struct {
@@ -34,6 +43,72 @@ func TryE(fn func() error) Result[struct{}] {
 	return ROk(struct{}{})
 }
 
+// TryCtx 在 goroutine 中执行 fn，context 取消时立即返回 RErr(ctx.Err())。
+// fn 应自行响应 ctx 取消；即使提前返回，fn 仍会在后台运行至结束。
+func TryCtx[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) Result[T] {
+	if err := ctx.Err(); err != nil {
+		return RErr[T](err)
+	}
+
+	done := make(chan Result[T], 1)
+	go func() {
+		done <- Try(func() (T, error) { return fn(ctx) })
+	}()
+
+	select {
+	case r := <-done:
+		return r
+	case <-ctx.Done():
+		return RErr[T](ctx.Err())
+	}
+}
+
+// TryWithTimeout 以带超时的子 context 执行 TryCtx。
+// 超时时返回 RErr(context.DeadlineExceeded)。
+func TryWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) Result[T] {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return TryCtx(ctx, fn)
+}
+
+// PanicError 表示 Catch 捕获到的 panic。
+type PanicError struct {
+	// Value 是传给 panic 的原始值。
+	Value any
+	// Stack 是 panic 发生时的调用栈。
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap 在 panic 值为 error 时返回该错误，供 errors.Is/As 使用。
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Catch 执行 fn 并将其中的 panic 转换为 RErr(*PanicError)。
+// 适用于包装可能 panic 的第三方代码。
+//
+//	r := gox.Catch(func() int { return legacy.MustParse(s) })
+func Catch[T any](fn func() T) Result[T] {
+	return CatchE(func() (T, error) { return fn(), nil })
+}
+
+// CatchE 与 Try 相同，但同时将 fn 中的 panic 转换为 RErr(*PanicError)。
+func CatchE[T any](fn func() (T, error)) (r Result[T]) {
+	defer func() {
+		if v := recover(); v != nil {
+			r = RErr[T](&PanicError{Value: v, Stack: debug.Stack()})
+		}
+	}()
+	return Try(fn)
+}
+
 // IsOk 返回 Result 是否成功。
 func (r Result[T]) IsOk() bool {
 	return r.err == nil
@@ -193,3 +268,137 @@ func Collect[T any](results []Result[T]) Result[[]T] {
 	}
 	return ROk(data)
 }
+
+// CollectAll 将 Result 切片收集为切片的 Result。
+// 与 Collect 不同，它会处理所有元素：全部成功时返回包含所有值的 Ok，
+// 否则返回包含每个失败的 MultiError。
+func CollectAll[T any](results []Result[T]) Result[[]T] {
+	data := make([]T, 0, len(results))
+	m := NewMultiError()
+	for _, r := range results {
+		if r.err != nil {
+			m.Add(r.err)
+			continue
+		}
+		data = append(data, r.data)
+	}
+	if m.HasErrors() {
+		return RErr[[]T](m)
+	}
+	return ROk(data)
+}
+
+// PartitionResults 将 Result 切片拆分为成功值和错误两部分，保持原有顺序。
+// 适用于部分成功的批量操作。
+func PartitionResults[T any](results []Result[T]) ([]T, []error) {
+	values := make([]T, 0, len(results))
+	errs := make([]error, 0)
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		values = append(values, r.data)
+	}
+	return values, errs
+}
+
+// --- 组合 ---
+
+// RZip 将两个 Result 组合为一个。
+// 两者都为 Ok 时返回 Ok，否则返回包含所有错误的 MultiError。
+func RZip[T, U any](a Result[T], b Result[U]) Result[struct {
+	First  T
+	Second U
+}] {
+	return Combine2(a, b, func(t T, u U) struct {
+		First  T
+		Second U
+	} {
+		return struct {
+			First  T
+			Second U
+		}{First: t, Second: u}
+	})
+}
+
+// Combine2 在两个 Result 都为 Ok 时用 fn 组合其值。
+// 任一为 Err 时返回包含所有错误的 MultiError，fn 不会被调用。
+func Combine2[T, U, R any](a Result[T], b Result[U], fn func(T, U) R) Result[R] {
+	m := NewMultiError()
+	m.AddAll(a.err, b.err)
+	if m.HasErrors() {
+		return RErr[R](m)
+	}
+	return ROk(fn(a.data, b.data))
+}
+
+// Combine3 在三个 Result 都为 Ok 时用 fn 组合其值。
+// 任一为 Err 时返回包含所有错误的 MultiError，fn 不会被调用。
+func Combine3[T, U, V, R any](a Result[T], b Result[U], c Result[V], fn func(T, U, V) R) Result[R] {
+	m := NewMultiError()
+	m.AddAll(a.err, b.err, c.err)
+	if m.HasErrors() {
+		return RErr[R](m)
+	}
+	return ROk(fn(a.data, b.data, c.data))
+}
+
+// --- JSON 支持 ---
+
+// ResultJSONKeys 是 Result JSON 序列化使用的字段名。
+type ResultJSONKeys struct {
+	Ok  string
+	Err string
+}
+
+// DefaultResultJSONKeys 返回 MarshalJSON 和 UnmarshalJSON 使用的字段名：
+// Ok 序列化为 {"ok": value}，Err 序列化为 {"error": "..."}。
+func DefaultResultJSONKeys() ResultJSONKeys {
+	return ResultJSONKeys{Ok: "ok", Err: "error"}
+}
+
+// MarshalJSON 实现 json.Marshaler，使用 DefaultResultJSONKeys。
+func (r Result[T]) MarshalJSON() ([]byte, error) {
+	return r.MarshalJSONWith(DefaultResultJSONKeys())
+}
+
+// MarshalJSONWith 使用 keys 指定的字段名序列化。
+func (r Result[T]) MarshalJSONWith(keys ResultJSONKeys) ([]byte, error) {
+	if r.err != nil {
+		return json.Marshal(map[string]string{keys.Err: r.err.Error()})
+	}
+	return json.Marshal(map[string]T{keys.Ok: r.data})
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，使用 DefaultResultJSONKeys。
+// 错误字段会被还原为仅包含消息的 error。
+func (r *Result[T]) UnmarshalJSON(data []byte) error {
+	return r.UnmarshalJSONWith(data, DefaultResultJSONKeys())
+}
+
+// UnmarshalJSONWith 使用 keys 指定的字段名反序列化。
+func (r *Result[T]) UnmarshalJSONWith(data []byte, keys ResultJSONKeys) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if raw, ok := fields[keys.Err]; ok {
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return err
+		}
+		*r = RErr[T](errors.New(msg))
+		return nil
+	}
+	raw, ok := fields[keys.Ok]
+	if !ok {
+		return fmt.Errorf("result JSON must contain %q or %q", keys.Ok, keys.Err)
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	*r = ROk(v)
+	return nil
+}
@@ -1,8 +1,11 @@
 package gox
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,3 +239,209 @@ func TestFlattenResult_PropagatesOuterError(t *testing.T) {
 	result := FlattenResult(nested)
 	assert.True(t, result.IsErr())
 }
+
+func TestResult_MarshalJSON_Ok(t *testing.T) {
+	data, err := json.Marshal(ROk(42))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":42}`, string(data))
+}
+
+func TestResult_MarshalJSON_Err(t *testing.T) {
+	data, err := json.Marshal(RErr[int](errors.New("boom")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":"boom"}`, string(data))
+}
+
+func TestResult_MarshalJSON_InSlice(t *testing.T) {
+	results := []Result[string]{ROk("a"), RErr[string](errors.New("bad"))}
+	data, err := json.Marshal(results)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"ok":"a"},{"error":"bad"}]`, string(data))
+}
+
+func TestResult_UnmarshalJSON_RoundTrip(t *testing.T) {
+	var ok Result[int]
+	require.NoError(t, json.Unmarshal([]byte(`{"ok":7}`), &ok))
+	assert.Equal(t, 7, ok.Unwrap())
+
+	var failed Result[int]
+	require.NoError(t, json.Unmarshal([]byte(`{"error":"boom"}`), &failed))
+	assert.True(t, failed.IsErr())
+	assert.Equal(t, "boom", failed.Error().Error())
+}
+
+func TestResult_UnmarshalJSON_RequiresKnownKey(t *testing.T) {
+	var r Result[int]
+	assert.Error(t, json.Unmarshal([]byte(`{"value":1}`), &r))
+}
+
+func TestResult_JSONWith_UsesGivenKeys(t *testing.T) {
+	keys := ResultJSONKeys{Ok: "data", Err: "message"}
+
+	data, err := ROk("x").MarshalJSONWith(keys)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":"x"}`, string(data))
+
+	var r Result[string]
+	require.NoError(t, r.UnmarshalJSONWith([]byte(`{"message":"nope"}`), keys))
+	assert.True(t, r.IsErr())
+
+	// 默认字段名不受影响
+	data, err = json.Marshal(ROk("x"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":"x"}`, string(data))
+}
+
+func TestRZip_ReturnsPairWhenBothOk(t *testing.T) {
+	r := RZip(ROk(1), ROk("a"))
+	pair := r.Unwrap()
+	assert.Equal(t, 1, pair.First)
+	assert.Equal(t, "a", pair.Second)
+}
+
+func TestRZip_ReturnsErrWhenAnyErr(t *testing.T) {
+	e := errors.New("bad")
+	r := RZip(ROk(1), RErr[string](e))
+	assert.True(t, r.IsErr())
+	assert.ErrorIs(t, r.Error(), e)
+}
+
+func TestCombine2_CombinesValues(t *testing.T) {
+	r := Combine2(ROk(2), ROk(3), func(a, b int) int { return a * b })
+	assert.Equal(t, 6, r.Unwrap())
+}
+
+func TestCombine3_AggregatesAllErrors(t *testing.T) {
+	e1 := errors.New("e1")
+	e3 := errors.New("e3")
+	called := false
+	r := Combine3(RErr[int](e1), ROk("ok"), RErr[bool](e3), func(int, string, bool) int {
+		called = true
+		return 0
+	})
+
+	assert.False(t, called)
+	var m *MultiError
+	require.ErrorAs(t, r.Error(), &m)
+	assert.Equal(t, 2, m.Len())
+	assert.ErrorIs(t, r.Error(), e1)
+	assert.ErrorIs(t, r.Error(), e3)
+}
+
+func TestCombine3_CombinesValues(t *testing.T) {
+	r := Combine3(ROk(1), ROk(2), ROk(3), func(a, b, c int) int { return a + b + c })
+	assert.Equal(t, 6, r.Unwrap())
+}
+
+func TestTryCtx_ReturnsResult(t *testing.T) {
+	r := TryCtx(context.Background(), func(ctx context.Context) (int, error) { return 42, nil })
+	assert.Equal(t, 42, r.Unwrap())
+
+	e := errors.New("fail")
+	r = TryCtx(context.Background(), func(ctx context.Context) (int, error) { return 0, e })
+	assert.ErrorIs(t, r.Error(), e)
+}
+
+func TestTryCtx_ReturnsPromptlyOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	go cancel()
+	r := TryCtx(ctx, func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	assert.ErrorIs(t, r.Error(), context.Canceled)
+}
+
+func TestTryCtx_SkipsFnWhenAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	r := TryCtx(ctx, func(ctx context.Context) (int, error) {
+		called = true
+		return 1, nil
+	})
+	assert.ErrorIs(t, r.Error(), context.Canceled)
+	assert.False(t, called)
+}
+
+func TestTryWithTimeout_ReturnsDeadlineExceeded(t *testing.T) {
+	r := TryWithTimeout(context.Background(), 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	})
+	assert.ErrorIs(t, r.Error(), context.DeadlineExceeded)
+}
+
+func TestTryWithTimeout_ReturnsValueBeforeDeadline(t *testing.T) {
+	r := TryWithTimeout(context.Background(), time.Second, func(ctx context.Context) (string, error) {
+		return "done", nil
+	})
+	assert.Equal(t, "done", r.Unwrap())
+}
+
+func TestCollectAll_ReturnsAllValues(t *testing.T) {
+	r := CollectAll([]Result[int]{ROk(1), ROk(2), ROk(3)})
+	assert.Equal(t, []int{1, 2, 3}, r.Unwrap())
+}
+
+func TestCollectAll_GathersEveryError(t *testing.T) {
+	e1 := errors.New("row 1")
+	e3 := errors.New("row 3")
+	r := CollectAll([]Result[int]{RErr[int](e1), ROk(2), RErr[int](e3)})
+
+	var m *MultiError
+	require.ErrorAs(t, r.Error(), &m)
+	assert.Equal(t, []error{e1, e3}, m.Errors())
+}
+
+func TestCollectAll_EmptyInput(t *testing.T) {
+	r := CollectAll[int](nil)
+	assert.True(t, r.IsOk())
+	assert.Empty(t, r.Unwrap())
+}
+
+func TestPartitionResults_SplitsValuesAndErrors(t *testing.T) {
+	e1 := errors.New("e1")
+	e2 := errors.New("e2")
+	values, errs := PartitionResults([]Result[int]{ROk(1), RErr[int](e1), ROk(3), RErr[int](e2)})
+	assert.Equal(t, []int{1, 3}, values)
+	assert.Equal(t, []error{e1, e2}, errs)
+}
+
+func TestPartitionResults_EmptyInput(t *testing.T) {
+	values, errs := PartitionResults[int](nil)
+	assert.Empty(t, values)
+	assert.Empty(t, errs)
+}
+
+func TestCatch_ReturnsOkWithoutPanic(t *testing.T) {
+	r := Catch(func() int { return 42 })
+	assert.Equal(t, 42, r.Unwrap())
+}
+
+func TestCatch_ConvertsPanicToErr(t *testing.T) {
+	r := Catch(func() int { panic("boom") })
+
+	require.True(t, r.IsErr())
+	var panicErr *PanicError
+	require.ErrorAs(t, r.Error(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+	assert.EqualError(t, r.Error(), "panic: boom")
+}
+
+func TestCatchE_PreservesReturnedError(t *testing.T) {
+	sentinel := errors.New("failed")
+	r := CatchE(func() (int, error) { return 0, sentinel })
+	assert.ErrorIs(t, r.Error(), sentinel)
+}
+
+func TestCatchE_UnwrapsPanickedError(t *testing.T) {
+	sentinel := errors.New("panicked error")
+	r := CatchE(func() (int, error) { panic(sentinel) })
+	assert.ErrorIs(t, r.Error(), sentinel)
+}
@@ -0,0 +1,108 @@
+package gox
+
+import (
+	"math"
+	"sync"
+)
+
+// MovingAvg 计算最近 window 个样本的简单移动平均，并发安全。
+//
+//	latency := gox.NewMovingAvg(100)
+//	latency.Add(float64(elapsed.Milliseconds()))
+type MovingAvg struct {
+	samples []float64
+	next    int
+	count   int
+	sum     float64
+	mu      sync.Mutex
+}
+
+// NewMovingAvg 创建窗口大小为 window 的移动平均，window <= 0 时 panic。
+func NewMovingAvg(window int) *MovingAvg {
+	if window <= 0 {
+		panic("MovingAvg window must be positive")
+	}
+	return &MovingAvg{samples: make([]float64, window)}
+}
+
+// Add 加入一个样本，窗口已满时淘汰最旧的样本。
+func (m *MovingAvg) Add(v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.count == len(m.samples) {
+		m.sum -= m.samples[m.next]
+	} else {
+		m.count++
+	}
+	m.samples[m.next] = v
+	m.sum += v
+	m.next = (m.next + 1) % len(m.samples)
+}
+
+// Value 返回窗口内样本的平均值，没有样本时返回 0。
+func (m *MovingAvg) Value() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.count == 0 {
+		return 0
+	}
+	return m.sum / float64(m.count)
+}
+
+// Count 返回窗口内的样本数。
+func (m *MovingAvg) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count
+}
+
+// Reset 清空所有样本。
+func (m *MovingAvg) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.samples)
+	m.next, m.count, m.sum = 0, 0, 0
+}
+
+// EWMA 是指数加权移动平均：value = alpha*v + (1-alpha)*value，并发安全。
+// alpha 越大越偏重新样本。第一个样本直接作为初始值。
+type EWMA struct {
+	alpha float64
+	value float64
+	init  bool
+	mu    sync.Mutex
+}
+
+// NewEWMA 创建平滑系数为 alpha 的 EWMA，alpha 为 NaN 或不在 (0, 1] 范围内时 panic。
+func NewEWMA(alpha float64) *EWMA {
+	// NaN 与任何值比较都为 false，需要单独检查
+	if math.IsNaN(alpha) || alpha <= 0 || alpha > 1 {
+		panic("EWMA alpha must be in (0, 1]")
+	}
+	return &EWMA{alpha: alpha}
+}
+
+// Add 加入一个样本。
+func (e *EWMA) Add(v float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.init {
+		e.value, e.init = v, true
+		return
+	}
+	e.value += e.alpha * (v - e.value)
+}
+
+// Value 返回当前平均值，没有样本时返回 0。
+func (e *EWMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.value
+}
+
+// Reset 清空状态，下一个样本重新作为初始值。
+func (e *EWMA) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value, e.init = 0, false
+}
@@ -0,0 +1,60 @@
+package gox
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingAvg_AveragesWindow(t *testing.T) {
+	m := NewMovingAvg(3)
+	assert.Equal(t, 0.0, m.Value())
+
+	m.Add(1)
+	m.Add(2)
+	assert.Equal(t, 1.5, m.Value())
+
+	m.Add(3)
+	m.Add(7)
+	assert.Equal(t, 4.0, m.Value())
+	assert.Equal(t, 3, m.Count())
+}
+
+func TestMovingAvg_Reset(t *testing.T) {
+	m := NewMovingAvg(2)
+	m.Add(10)
+	m.Reset()
+	m.Add(4)
+	assert.Equal(t, 4.0, m.Value())
+}
+
+func TestNewMovingAvg_PanicsOnNonPositiveWindow(t *testing.T) {
+	assert.Panics(t, func() { NewMovingAvg(0) })
+}
+
+func TestEWMA_FirstSampleInitializes(t *testing.T) {
+	e := NewEWMA(0.5)
+	assert.Equal(t, 0.0, e.Value())
+	e.Add(10)
+	assert.Equal(t, 10.0, e.Value())
+	e.Add(20)
+	assert.Equal(t, 15.0, e.Value())
+	e.Add(20)
+	assert.Equal(t, 17.5, e.Value())
+}
+
+func TestEWMA_Reset(t *testing.T) {
+	e := NewEWMA(0.1)
+	e.Add(100)
+	e.Reset()
+	e.Add(1)
+	assert.Equal(t, 1.0, e.Value())
+}
+
+func TestNewEWMA_PanicsOnInvalidAlpha(t *testing.T) {
+	assert.Panics(t, func() { NewEWMA(0) })
+	assert.Panics(t, func() { NewEWMA(1.5) })
+	assert.Panics(t, func() { NewEWMA(math.NaN()) })
+	assert.NotPanics(t, func() { NewEWMA(1) })
+}
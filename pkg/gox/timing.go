@@ -0,0 +1,70 @@
+package gox
+
+import (
+	"sync"
+	"time"
+)
+
+// Measure 调用 fn 并返回其结果和耗时。
+//
+//	users, d, err := gox.Measure(func() ([]User, error) { return repo.List(ctx) })
+func Measure[T any](fn func() (T, error)) (T, time.Duration, error) {
+	start := time.Now()
+	v, err := fn()
+	return v, time.Since(start), err
+}
+
+// Lap 是 Stopwatch 记录的一个阶段。
+type Lap struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Stopwatch 记录各阶段耗时，并发安全。
+//
+//	sw := gox.StartStopwatch()
+//	load()
+//	sw.Lap("load")
+//	render()
+//	sw.Lap("render")
+//	slog.Info("request timings", "laps", sw.Laps())
+type Stopwatch struct {
+	start   time.Time
+	lastLap time.Time
+	now     func() time.Time
+	laps    []Lap
+	mu      sync.Mutex
+}
+
+// StartStopwatch 创建并立即启动计时器。
+func StartStopwatch() *Stopwatch {
+	s := &Stopwatch{now: time.Now}
+	s.start = s.now()
+	s.lastLap = s.start
+	return s
+}
+
+// Lap 记录自上一个阶段（或启动）以来的耗时并返回。
+func (s *Stopwatch) Lap(name string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	d := now.Sub(s.lastLap)
+	s.lastLap = now
+	s.laps = append(s.laps, Lap{Name: name, Duration: d})
+	return d
+}
+
+// Elapsed 返回自启动以来的总耗时。
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now().Sub(s.start)
+}
+
+// Laps 返回已记录阶段的副本，按记录顺序排列。
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Lap(nil), s.laps...)
+}
@@ -0,0 +1,35 @@
+package gox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure_ReturnsResultAndDuration(t *testing.T) {
+	boom := errors.New("boom")
+	v, d, err := Measure(func() (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return 7, boom
+	})
+	assert.Equal(t, 7, v)
+	assert.ErrorIs(t, err, boom)
+	assert.GreaterOrEqual(t, d, 5*time.Millisecond)
+}
+
+func TestStopwatch_RecordsLaps(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := StartStopwatch()
+	s.now = func() time.Time { return now }
+	s.start, s.lastLap = now, now
+
+	now = now.Add(10 * time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, s.Lap("load"))
+	now = now.Add(5 * time.Millisecond)
+	s.Lap("render")
+
+	assert.Equal(t, []Lap{{"load", 10 * time.Millisecond}, {"render", 5 * time.Millisecond}}, s.Laps())
+	assert.Equal(t, 15*time.Millisecond, s.Elapsed())
+}
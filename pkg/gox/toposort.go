@@ -0,0 +1,105 @@
+package gox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCycle 表示依赖关系中存在环，可用 errors.Is 判断；具体的环通过 *CycleError 获取。
+var ErrCycle = errors.New("dependency cycle")
+
+// CycleError 描述依赖环，Nodes 按依赖方向排列：Nodes[i] 依赖 Nodes[i+1]，最后一个依赖第一个。
+type CycleError[T comparable] struct {
+	Nodes []T
+}
+
+func (e *CycleError[T]) Error() string {
+	return fmt.Sprintf("%v: %v", ErrCycle, e.Nodes)
+}
+
+// Is 使 errors.Is(err, ErrCycle) 成立。
+func (e *CycleError[T]) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// TopoSort 按依赖顺序排列 nodes，被依赖的节点在前。deps 返回节点直接依赖的节点，
+// 不在 nodes 中的依赖也会加入结果。无依赖关系的节点保持输入顺序。
+// 存在环时返回 RErr(*CycleError)。
+//
+//	order, err := gox.TopoSort(migrations, func(m string) []string { return requires[m] }).GetWithError()
+func TopoSort[T comparable](nodes []T, deps func(T) []T) Result[[]T] {
+	// 收集所有节点并计算入度（未满足的依赖数）
+	var all []T
+	edges := make(map[T][]T)
+	pending := make(map[T]int)
+	var visit func(n T)
+	visit = func(n T) {
+		if _, ok := pending[n]; ok {
+			return
+		}
+		pending[n] = 0
+		all = append(all, n)
+		for _, d := range deps(n) {
+			visit(d)
+			edges[n] = append(edges[n], d)
+		}
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+
+	dependents := make(map[T][]T)
+	for _, n := range all {
+		for _, d := range edges[n] {
+			pending[n]++
+			dependents[d] = append(dependents[d], n)
+		}
+	}
+
+	result := make([]T, 0, len(all))
+	for _, n := range all {
+		if pending[n] == 0 {
+			result = append(result, n)
+		}
+	}
+	for i := 0; i < len(result); i++ {
+		for _, n := range dependents[result[i]] {
+			pending[n]--
+			if pending[n] == 0 {
+				result = append(result, n)
+			}
+		}
+	}
+
+	if len(result) < len(all) {
+		return RErr[[]T](&CycleError[T]{Nodes: findCycle(all, edges, pending)})
+	}
+	return ROk(result)
+}
+
+// findCycle 在仍有未满足依赖的节点中找出一个环。
+func findCycle[T comparable](all []T, edges map[T][]T, pending map[T]int) []T {
+	// 每个剩余节点至少有一条指向剩余节点的边，沿边前进必然回到已访问的节点
+	var start T
+	for _, n := range all {
+		if pending[n] > 0 {
+			start = n
+			break
+		}
+	}
+	index := make(map[T]int)
+	var path []T
+	for n := start; ; {
+		if i, ok := index[n]; ok {
+			return path[i:]
+		}
+		index[n] = len(path)
+		path = append(path, n)
+		for _, d := range edges[n] {
+			if pending[d] > 0 {
+				n = d
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package gox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopoSort_OrdersDependenciesFirst(t *testing.T) {
+	deps := map[string][]string{
+		"app":   {"db", "cache"},
+		"cache": {"config"},
+		"db":    {"config"},
+	}
+	order, err := TopoSort([]string{"app", "db", "cache", "config"}, func(n string) []string { return deps[n] }).GetWithError()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config", "db", "cache", "app"}, order)
+}
+
+func TestTopoSort_IncludesUnlistedDependencies(t *testing.T) {
+	deps := map[int][]int{1: {2}, 2: {3}}
+	order := TopoSort([]int{1}, func(n int) []int { return deps[n] }).Unwrap()
+	assert.Equal(t, []int{3, 2, 1}, order)
+}
+
+func TestTopoSort_IndependentNodesKeepInputOrder(t *testing.T) {
+	order := TopoSort([]string{"c", "a", "b"}, func(string) []string { return nil }).Unwrap()
+	assert.Equal(t, []string{"c", "a", "b"}, order)
+}
+
+func TestTopoSort_ReportsCycle(t *testing.T) {
+	deps := map[string][]string{
+		"start": {"a"},
+		"a":     {"b"},
+		"b":     {"c"},
+		"c":     {"a"},
+	}
+	err := TopoSort([]string{"start", "a", "b", "c"}, func(n string) []string { return deps[n] }).Error()
+	require.ErrorIs(t, err, ErrCycle)
+
+	var cycle *CycleError[string]
+	require.True(t, errors.As(err, &cycle))
+	assert.Equal(t, []string{"a", "b", "c"}, cycle.Nodes)
+	assert.Equal(t, "dependency cycle: [a b c]", err.Error())
+}
+
+func TestTopoSort_SelfDependency(t *testing.T) {
+	var cycle *CycleError[int]
+	err := TopoSort([]int{1}, func(int) []int { return []int{1} }).Error()
+	require.True(t, errors.As(err, &cycle))
+	assert.Equal(t, []int{1}, cycle.Nodes)
+}
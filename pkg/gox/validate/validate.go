@@ -0,0 +1,259 @@
+// Package validate 提供声明式的结构体字段验证，结果为带标签的 gox.MultiError。
+//
+// 字段按 Go 字段名或 json/form 标签名查找，每个字段在第一条未通过的规则处停止：
+//
+//	err := validate.Validate(req).
+//	    Field("email", validate.NotEmpty, validate.Matches(emailRe)).
+//	    Field("age", validate.Between(0, 150)).
+//	    Result()
+//
+// 在 ginm 处理器中直接返回该错误即可按 422 验证错误渲染。
+package validate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+)
+
+// Rule 验证单个字段值，不通过时返回描述原因的错误。
+type Rule func(v any) error
+
+// Validator 收集结构体各字段的验证错误。
+type Validator struct {
+	errs *gox.MultiError
+	v    reflect.Value
+}
+
+// Validate 创建结构体（或结构体指针）的验证器。
+// v 不是结构体时 panic。
+func Validate(v any) *Validator {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: expected struct, got %T", v))
+	}
+	return &Validator{v: rv, errs: gox.NewMultiError()}
+}
+
+// Field 依次对字段应用规则，第一条失败的规则以 name 为标签记录。
+// 字段不存在时 panic。
+func (x *Validator) Field(name string, rules ...Rule) *Validator {
+	f, ok := lookupField(x.v, name)
+	if !ok {
+		panic(fmt.Sprintf("validate: %s has no field %q", x.v.Type(), name))
+	}
+	value := f.Interface()
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			x.errs.AddLabeled(name, err)
+			break
+		}
+	}
+	return x
+}
+
+// Errors 返回收集到的错误集合。
+func (x *Validator) Errors() *gox.MultiError {
+	return x.errs
+}
+
+// Result 没有错误时返回 nil，否则返回带标签的 *gox.MultiError。
+func (x *Validator) Result() error {
+	return x.errs.ErrorOrNil()
+}
+
+// lookupField 按 Go 字段名、json 或 form 标签名查找导出字段。
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Name == name || tagName(f.Tag.Get("json")) == name || tagName(f.Tag.Get("form")) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+// --- 规则 ---
+
+// NotEmpty 要求值不是零值，字符串、切片和 map 的长度不为 0。
+func NotEmpty(v any) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid() || rv.IsZero():
+		return errors.New("must not be empty")
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0:
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// Matches 要求字符串匹配正则表达式。空字符串不做检查，需要时与 NotEmpty 组合。
+func Matches(re *regexp.Regexp) Rule {
+	return func(v any) error {
+		s, err := asString(v)
+		if err != nil || s == "" {
+			return err
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("must match %s", re)
+		}
+		return nil
+	}
+}
+
+// MinLen 要求字符串（按字符）、切片或 map 的长度不小于 n。
+func MinLen(n int) Rule {
+	return func(v any) error {
+		l, err := length(v)
+		if err != nil {
+			return err
+		}
+		if l < n {
+			return fmt.Errorf("must have at least %d characters or items", n)
+		}
+		return nil
+	}
+}
+
+// MaxLen 要求字符串（按字符）、切片或 map 的长度不大于 n。
+func MaxLen(n int) Rule {
+	return func(v any) error {
+		l, err := length(v)
+		if err != nil {
+			return err
+		}
+		if l > n {
+			return fmt.Errorf("must have at most %d characters or items", n)
+		}
+		return nil
+	}
+}
+
+// Between 要求数值在 [lo, hi] 范围内。字段类型可以与 T 不同，比较时不做截断：
+// 整数之间精确比较，涉及浮点数时按 float64 比较，例如 150.7 不满足 Between(0, 150)。NaN 总是不满足。
+func Between[T gox.Numeric](lo, hi T) Rule {
+	return func(v any) error {
+		rv := reflect.ValueOf(v)
+		if !isNumber(rv) {
+			return fmt.Errorf("must be a number, got %T", v)
+		}
+		if rv.CanFloat() && math.IsNaN(rv.Float()) ||
+			compareNumbers(rv, reflect.ValueOf(lo)) < 0 || compareNumbers(rv, reflect.ValueOf(hi)) > 0 {
+			return fmt.Errorf("must be between %v and %v", lo, hi)
+		}
+		return nil
+	}
+}
+
+// OneOf 要求值为给定选项之一。数值与 Between 一样按值比较而不做截断，
+// 例如 1.5 不满足 OneOf(1, 2, 3)，257 不满足 OneOf[int8](1)。
+func OneOf[T comparable](options ...T) Rule {
+	numeric := isNumber(reflect.Zero(reflect.TypeFor[T]()))
+	return func(v any) error {
+		if rv := reflect.ValueOf(v); numeric && isNumber(rv) {
+			if !slices.ContainsFunc(options, func(o T) bool { return compareNumbers(rv, reflect.ValueOf(o)) == 0 }) {
+				return fmt.Errorf("must be one of %v", options)
+			}
+			return nil
+		}
+		x, err := convert[T](v)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(options, x) {
+			return fmt.Errorf("must be one of %v", options)
+		}
+		return nil
+	}
+}
+
+func asString(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", fmt.Errorf("must be a string, got %T", v)
+	}
+	return rv.String(), nil
+}
+
+func length(v any) (int, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return len([]rune(rv.String())), nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), nil
+	default:
+		return 0, fmt.Errorf("has no length (%T)", v)
+	}
+}
+
+func isNumber(rv reflect.Value) bool {
+	return rv.IsValid() && (rv.CanInt() || rv.CanUint() || rv.CanFloat())
+}
+
+// compareNumbers 比较两个数值，返回 -1、0 或 1。a、b 须满足 isNumber。
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case a.CanFloat() || b.CanFloat():
+		return cmp.Compare(toFloat(a), toFloat(b))
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanInt():
+		// a 有符号、b 无符号
+		if a.Int() < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(a.Int()), b.Uint())
+	default:
+		return -compareNumbers(b, a)
+	}
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanFloat():
+		return v.Float()
+	case v.CanInt():
+		return float64(v.Int())
+	default:
+		return float64(v.Uint())
+	}
+}
+
+// convert 将字段值转换为 T，支持底层类型相同的命名类型之间的转换。
+// 数值之间的转换可能截断，数值比较应使用 compareNumbers。
+func convert[T any](v any) (T, error) {
+	if x, ok := v.(T); ok {
+		return x, nil
+	}
+	var zero T
+	target := reflect.TypeFor[T]()
+	rv := reflect.ValueOf(v)
+	// 禁止数值与字符串互转（int 转 string 会得到 rune）
+	stringMismatch := rv.IsValid() && (rv.Kind() == reflect.String) != (target.Kind() == reflect.String)
+	if !rv.IsValid() || stringMismatch || !rv.CanConvert(target) {
+		return zero, fmt.Errorf("must be %s, got %T", target, v)
+	}
+	return rv.Convert(target).Interface().(T), nil
+}
@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/lwmacct/251219-go-pkg-ginm/pkg/gox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var emailRe = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+type signup struct {
+	Email string   `json:"email"`
+	Role  string   `json:"role"`
+	Tags  []string `form:"tags"`
+	Age   int8     `json:"age,omitempty"`
+}
+
+func TestValidate_ReturnsNilWhenValid(t *testing.T) {
+	req := signup{Email: "a@b.c", Age: 30, Role: "admin", Tags: []string{"x"}}
+	err := Validate(req).
+		Field("email", NotEmpty, Matches(emailRe)).
+		Field("age", Between(0, 150)).
+		Field("role", OneOf("admin", "user")).
+		Field("tags", NotEmpty, MaxLen(3)).
+		Result()
+	assert.NoError(t, err)
+}
+
+func TestValidate_CollectsLabeledErrors(t *testing.T) {
+	req := &signup{Email: "not-an-email", Age: -1, Role: "root"}
+	err := Validate(req).
+		Field("email", NotEmpty, Matches(emailRe)).
+		Field("age", Between(0, 150)).
+		Field("role", OneOf("admin", "user")).
+		Field("tags", NotEmpty).
+		Result()
+
+	var multi *gox.MultiError
+	require.ErrorAs(t, err, &multi)
+	data, jsonErr := json.Marshal(multi)
+	require.NoError(t, jsonErr)
+	assert.JSONEq(t, `{
+		"email": ["must match ^[^@\\s]+@[^@\\s]+$"],
+		"age": ["must be between 0 and 150"],
+		"role": ["must be one of [admin user]"],
+		"tags": ["must not be empty"]
+	}`, string(data))
+}
+
+func TestValidate_StopsAtFirstFailingRulePerField(t *testing.T) {
+	v := Validate(signup{}).Field("email", NotEmpty, Matches(emailRe))
+	assert.Equal(t, 1, v.Errors().Len())
+	assert.EqualError(t, v.Errors().First(), "must not be empty")
+}
+
+func TestValidate_LooksUpGoNameAndTags(t *testing.T) {
+	req := signup{Email: "a@b.c", Tags: []string{"a"}}
+	assert.NotPanics(t, func() {
+		Validate(req).Field("Email", NotEmpty).Field("email", NotEmpty).Field("tags", NotEmpty)
+	})
+	assert.Panics(t, func() { Validate(req).Field("missing", NotEmpty) })
+	assert.Panics(t, func() { Validate("not a struct") })
+}
+
+func TestRules_LengthCountsCharacters(t *testing.T) {
+	assert.NoError(t, MinLen(2)("日本"))
+	assert.Error(t, MaxLen(1)("日本"))
+	assert.Error(t, MinLen(1)(42))
+}
+
+func TestBetween_ComparesWithoutTruncation(t *testing.T) {
+	assert.Error(t, Between(0, 150)(150.7))
+	assert.NoError(t, Between(0, 150)(150.0))
+	assert.Error(t, Between(0, 150)(-0.5))
+	assert.Error(t, Between(0, 150)(math.NaN()))
+	assert.NoError(t, Between(0.5, 1.5)(1))
+	assert.Error(t, Between(0, 10)(uint64(math.MaxUint64)))
+	assert.Error(t, Between[uint](1, 10)(-1))
+	assert.NoError(t, Between[int8](-1, 1)(uint8(1)))
+}
+
+func TestOneOf_ComparesNumbersWithoutTruncation(t *testing.T) {
+	assert.Error(t, OneOf(1, 2, 3)(1.5))
+	assert.NoError(t, OneOf(1, 2, 3)(2.0))
+	assert.Error(t, OneOf[int8](1)(257))
+	assert.Error(t, OneOf[uint8](255)(-1))
+	assert.NoError(t, OneOf[int8](1)(uint64(1)))
+	assert.Error(t, OneOf(1)(math.NaN()))
+}
+
+func TestRules_RejectIncompatibleTypes(t *testing.T) {
+	assert.Error(t, Between(0, 10)("5"))
+	assert.Error(t, OneOf("a")(97))
+	assert.Error(t, Matches(emailRe)(42))
+}
@@ -0,0 +1,163 @@
+package gox
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrPoolStopped 表示向已停止的 WorkerPool 提交任务。
+var ErrPoolStopped = errors.New("worker pool stopped")
+
+// ErrorPolicy 控制 WorkerPool 遇到任务错误时的行为。
+type ErrorPolicy int
+
+const (
+	// ContinueOnError 将错误作为结果发送并继续处理后续任务（默认）。
+	ContinueOnError ErrorPolicy = iota
+	// StopOnError 在第一个错误后停止：取消 context，跳过排队中的任务并拒绝新的提交。
+	StopOnError
+)
+
+// WorkerPoolConfig 包含 WorkerPool 的配置。
+type WorkerPoolConfig struct {
+	// Workers 是并发 worker 数量。默认值: runtime.NumCPU()
+	Workers int
+	// QueueSize 是任务队列和结果通道的容量。默认值: Workers
+	QueueSize int
+	// ErrorPolicy 控制任务出错时的行为。默认值: ContinueOnError
+	ErrorPolicy ErrorPolicy
+}
+
+// WorkerPool 以固定数量的 goroutine 处理任务，并通过 Results 输出类型化结果。
+// 调用方必须持续消费 Results，否则结果通道写满后 worker 会阻塞。
+//
+//	pool := gox.NewWorkerPool(ctx, fetch, gox.WorkerPoolConfig{Workers: 8})
+//	go func() {
+//	    for _, url := range urls {
+//	        _ = pool.Submit(url)
+//	    }
+//	    pool.Stop()
+//	}()
+//	for r := range pool.Results() { ... }
+type WorkerPool[In, Out any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	fn      func(ctx context.Context, in In) (Out, error)
+	jobs    chan In
+	results chan Result[Out]
+	err     error
+	cfg     WorkerPoolConfig
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	errMu   sync.Mutex
+	mu      sync.RWMutex // 保护 stopped 并与进行中的 Submit 同步
+	stopped bool
+}
+
+// NewWorkerPool 创建并启动 worker。ctx 取消时 worker 退出，未开始的任务被丢弃。
+func NewWorkerPool[In, Out any](ctx context.Context, fn func(ctx context.Context, in In) (Out, error), cfg WorkerPoolConfig) *WorkerPool[In, Out] {
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = cfg.Workers
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p := &WorkerPool[In, Out]{
+		ctx:     ctx,
+		cancel:  cancel,
+		fn:      fn,
+		cfg:     cfg,
+		jobs:    make(chan In, cfg.QueueSize),
+		results: make(chan Result[Out], cfg.QueueSize),
+	}
+	p.wg.Add(cfg.Workers)
+	for range cfg.Workers {
+		go p.work()
+	}
+	go func() {
+		p.wg.Wait()
+		cancel()
+		close(p.results)
+	}()
+	return p
+}
+
+// Submit 提交任务，队列已满时阻塞。池已停止或 context 已取消时返回 ErrPoolStopped。
+func (p *WorkerPool[In, Out]) Submit(in In) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped || p.ctx.Err() != nil {
+		return ErrPoolStopped
+	}
+	select {
+	case p.jobs <- in:
+		return nil
+	case <-p.ctx.Done():
+		return ErrPoolStopped
+	}
+}
+
+// Results 返回结果通道。调用 Stop 且排队任务处理完毕、或 context 取消（包括 StopOnError 触发）后通道关闭。
+func (p *WorkerPool[In, Out]) Results() <-chan Result[Out] {
+	return p.results
+}
+
+// Stop 停止接受新任务，已排队的任务会继续处理（StopOnError 触发后除外）。
+// Stop 不等待任务完成，通过 Results 关闭或 Wait 判断结束。重复调用是安全的。
+func (p *WorkerPool[In, Out]) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.jobs)
+	}
+}
+
+// Wait 等待所有 worker 退出，返回触发 StopOnError 的错误。
+// 需要先调用 Stop 或取消 context，并持续消费 Results。
+func (p *WorkerPool[In, Out]) Wait() error {
+	p.wg.Wait()
+	return p.Err()
+}
+
+// Err 返回触发 StopOnError 的第一个错误；ContinueOnError 下始终为 nil。
+func (p *WorkerPool[In, Out]) Err() error {
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	return p.err
+}
+
+func (p *WorkerPool[In, Out]) work() {
+	defer p.wg.Done()
+	for {
+		var in In
+		select {
+		case <-p.ctx.Done():
+			return
+		case job, ok := <-p.jobs:
+			// 两个分支同时就绪时 select 随机选择，context 取消后不再执行已取出的任务
+			if !ok || p.ctx.Err() != nil {
+				return
+			}
+			in = job
+		}
+
+		out, err := p.fn(p.ctx, in)
+		if err != nil {
+			if p.cfg.ErrorPolicy == StopOnError {
+				p.errOnce.Do(func() {
+					p.errMu.Lock()
+					p.err = err
+					p.errMu.Unlock()
+					p.cancel()
+				})
+			}
+			p.results <- RErr[Out](err)
+			continue
+		}
+		p.results <- ROk(out)
+	}
+}
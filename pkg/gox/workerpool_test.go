@@ -0,0 +1,105 @@
+package gox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectResults[T any](ch <-chan Result[T]) (oks []T, errs []error) {
+	for r := range ch {
+		if v, err := r.GetWithError(); err != nil {
+			errs = append(errs, err)
+		} else {
+			oks = append(oks, v)
+		}
+	}
+	return oks, errs
+}
+
+func TestWorkerPool_ProcessesAllJobs(t *testing.T) {
+	pool := NewWorkerPool(context.Background(), func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	}, WorkerPoolConfig{Workers: 4})
+
+	go func() {
+		for i := 1; i <= 10; i++ {
+			assert.NoError(t, pool.Submit(i))
+		}
+		pool.Stop()
+	}()
+
+	oks, errs := collectResults(pool.Results())
+	assert.Empty(t, errs)
+	assert.Equal(t, 385, Sum(oks))
+	assert.NoError(t, pool.Wait())
+}
+
+func TestWorkerPool_ContinueOnErrorReportsErrors(t *testing.T) {
+	pool := NewWorkerPool(context.Background(), func(ctx context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	}, WorkerPoolConfig{Workers: 2, QueueSize: 4})
+
+	for _, s := range []string{"1", "x", "3"} {
+		require.NoError(t, pool.Submit(s))
+	}
+	pool.Stop()
+
+	oks, errs := collectResults(pool.Results())
+	assert.ElementsMatch(t, []int{1, 3}, oks)
+	assert.Len(t, errs, 1)
+	assert.NoError(t, pool.Err())
+}
+
+func TestWorkerPool_StopOnErrorSkipsQueuedJobs(t *testing.T) {
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+	pool := NewWorkerPool(context.Background(), func(ctx context.Context, n int) (int, error) {
+		<-release
+		if n == 1 {
+			return 0, errBoom
+		}
+		return n, nil
+	}, WorkerPoolConfig{Workers: 1, QueueSize: 10, ErrorPolicy: StopOnError})
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, pool.Submit(i))
+	}
+	close(release)
+
+	oks, errs := collectResults(pool.Results())
+	assert.Empty(t, oks)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], errBoom)
+	assert.ErrorIs(t, pool.Wait(), errBoom)
+	assert.ErrorIs(t, pool.Submit(6), ErrPoolStopped)
+}
+
+func TestWorkerPool_SubmitAfterStop(t *testing.T) {
+	pool := NewWorkerPool(context.Background(), func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	}, WorkerPoolConfig{})
+	pool.Stop()
+	pool.Stop()
+
+	assert.ErrorIs(t, pool.Submit(1), ErrPoolStopped)
+	_, errs := collectResults(pool.Results())
+	assert.Empty(t, errs)
+}
+
+func TestWorkerPool_ContextCancelStopsPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewWorkerPool(ctx, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	}, WorkerPoolConfig{Workers: 1})
+	cancel()
+
+	assert.ErrorIs(t, pool.Submit(1), ErrPoolStopped)
+	pool.Stop()
+	for range pool.Results() {
+	}
+}